@@ -1 +1,12 @@
 module github.com/harmony-one/asym-key-pkgs
+
+go 1.26.0
+
+require (
+	filippo.io/bigmod v0.1.0
+	github.com/cloudflare/circl v1.6.5
+	golang.org/x/crypto v0.57.0
+	golang.org/x/text v0.42.0
+)
+
+require golang.org/x/sys v0.48.0
@@ -31,11 +31,10 @@ type Packer interface {
 type packers map[reflect.Type][]Packer
 
 // Packers is the global packer registry.
-var Packers packers
-
-func init() {
-	Packers = make(packers)
-}
+//
+// It is initialized along with its declaration (not in init),
+// so that package-level method values such as Pack bind to the live map.
+var Packers = make(packers)
 
 // Register registers a packer under the private key types that it handles.
 func (packers packers) Register(packer Packer, types ...interface{}) {
@@ -63,7 +62,7 @@ func (packers packers) Pack(
 		return nil, errors.New("nil private key")
 	}
 	for _, packer := range packers[typ] {
-		pkg, err = packer.Pack(priv, pub)
+		pkg, err = packer.Pack(priv, pub, options...)
 		if err != ErrSkip {
 			return
 		}
@@ -86,11 +85,7 @@ type Unpacker interface {
 type unpackers map[string][]Unpacker
 
 // Unpackers is the global unpacker registry.
-var Unpackers unpackers
-
-func init() {
-	Unpackers = make(unpackers)
-}
+var Unpackers = make(unpackers)
 
 // Register registers an unpacker under the algorithm OIDs that it handles.
 func (unpackers unpackers) Register(
@@ -168,7 +163,7 @@ func Encode(
 	if err != nil {
 		return
 	}
-	return asn1.Marshal(pkg)
+	return asn1.Marshal(*pkg)
 }
 
 // Decode decodes an ASN.1-encoded key package into a private/public key pair.
@@ -180,14 +175,21 @@ func Encode(
 func Decode(encoded []byte) (
 	priv interface{}, pub interface{}, extras []interface{}, err error,
 ) {
+	pkg, err := unmarshal(encoded)
+	if err != nil {
+		return
+	}
+	return Unpack(pkg)
+}
+
+func unmarshal(encoded []byte) (*OneAsymmetricKey, error) {
 	var pkg OneAsymmetricKey
 	rest, err := asn1.Unmarshal(encoded, &pkg)
 	if err != nil {
-		return
+		return nil, err
 	}
 	if len(rest) > 0 {
-		err = fmt.Errorf("trailing data after key package")
-		return
+		return nil, fmt.Errorf("trailing data after key package")
 	}
-	return Unpack(&pkg)
+	return &pkg, nil
 }
@@ -0,0 +1,123 @@
+package akp
+
+import (
+	"encoding/asn1"
+	"errors"
+	"unicode/utf16"
+)
+
+// Attribute types
+var (
+	// OIDFriendlyName is the PKCS #9 friendlyName attribute type
+	// (RFC 2985, section 5.5.1), whose value is a BMPString.
+	OIDFriendlyName = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 20}
+
+	// OIDLocalKeyID is the PKCS #9 localKeyId attribute type
+	// (RFC 2985, section 5.5.2), whose value is an OCTET STRING.
+	OIDLocalKeyID = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 21}
+)
+
+// Attribute returns the first attribute of the given type, or nil.
+func (pkg *OneAsymmetricKey) Attribute(typ asn1.ObjectIdentifier) *Attribute {
+	for i := range pkg.Attributes {
+		if pkg.Attributes[i].Type.Equal(typ) {
+			return &pkg.Attributes[i]
+		}
+	}
+	return nil
+}
+
+// SetAttribute replaces all attributes of the given type with one attribute
+// with the given values, or removes them if there are no values.
+func (pkg *OneAsymmetricKey) SetAttribute(
+	typ asn1.ObjectIdentifier, values ...asn1.RawValue,
+) {
+	attrs := pkg.Attributes[:0]
+	for _, attr := range pkg.Attributes {
+		if !attr.Type.Equal(typ) {
+			attrs = append(attrs, attr)
+		}
+	}
+	if len(values) > 0 {
+		attrs = append(attrs, Attribute{Type: typ, Values: values})
+	}
+	if len(attrs) == 0 {
+		attrs = nil
+	}
+	pkg.Attributes = attrs
+}
+
+// FriendlyName returns the friendlyName attribute of the key package.
+func (pkg *OneAsymmetricKey) FriendlyName() (name string, ok bool) {
+	attr := pkg.Attribute(OIDFriendlyName)
+	if attr == nil || len(attr.Values) == 0 {
+		return "", false
+	}
+	name, err := decodeBMPString(attr.Values[0])
+	return name, err == nil
+}
+
+// SetFriendlyName sets the friendlyName attribute of the key package,
+// or removes it if name is empty.
+func (pkg *OneAsymmetricKey) SetFriendlyName(name string) {
+	if name == "" {
+		pkg.SetAttribute(OIDFriendlyName)
+		return
+	}
+	pkg.SetAttribute(OIDFriendlyName, encodeBMPString(name))
+}
+
+// LocalKeyID returns the localKeyId attribute of the key package.
+func (pkg *OneAsymmetricKey) LocalKeyID() (id []byte, ok bool) {
+	attr := pkg.Attribute(OIDLocalKeyID)
+	if attr == nil || len(attr.Values) == 0 {
+		return nil, false
+	}
+	v := attr.Values[0]
+	if v.Class != asn1.ClassUniversal || v.Tag != asn1.TagOctetString ||
+		v.IsCompound {
+		return nil, false
+	}
+	return v.Bytes, true
+}
+
+// SetLocalKeyID sets the localKeyId attribute of the key package,
+// or removes it if id is nil.
+func (pkg *OneAsymmetricKey) SetLocalKeyID(id []byte) {
+	if id == nil {
+		pkg.SetAttribute(OIDLocalKeyID)
+		return
+	}
+	pkg.SetAttribute(OIDLocalKeyID, asn1.RawValue{
+		Class: asn1.ClassUniversal, Tag: asn1.TagOctetString, Bytes: id,
+	})
+}
+
+const tagBMPString = 30
+
+func encodeBMPString(s string) asn1.RawValue {
+	units := utf16.Encode([]rune(s))
+	b := make([]byte, 0, 2*len(units))
+	for _, u := range units {
+		b = append(b, byte(u>>8), byte(u))
+	}
+	return asn1.RawValue{
+		Class: asn1.ClassUniversal, Tag: tagBMPString, Bytes: b,
+	}
+}
+
+func decodeBMPString(v asn1.RawValue) (string, error) {
+	switch {
+	case v.Class == asn1.ClassUniversal && v.Tag == asn1.TagUTF8String:
+		return string(v.Bytes), nil
+	case v.Class != asn1.ClassUniversal || v.Tag != tagBMPString:
+		return "", errors.New("not a BMPString")
+	case len(v.Bytes)%2 != 0:
+		return "", errors.New("odd-length BMPString")
+	}
+	units := make([]uint16, len(v.Bytes)/2)
+	for i := range units {
+		units[i] = uint16(v.Bytes[2*i])<<8 | uint16(v.Bytes[2*i+1])
+	}
+	return string(utf16.Decode(units)), nil
+}
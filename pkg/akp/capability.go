@@ -0,0 +1,72 @@
+package akp
+
+import "strings"
+
+// Capability is a set of operations that a key can perform.
+type Capability uint
+
+// Capabilities
+const (
+	// CanSign means the private key can sign (crypto.Signer).
+	CanSign Capability = 1 << iota
+
+	// CanDecrypt means the private key can decrypt (crypto.Decrypter).
+	CanDecrypt
+
+	// CanAgree means the private key can perform key agreement,
+	// e.g. Diffie-Hellman.
+	CanAgree
+
+	// CanDecapsulate means the private key can decapsulate shared secrets
+	// of a key encapsulation mechanism.
+	CanDecapsulate
+)
+
+var capabilityNames = []string{"sign", "decrypt", "agree", "decapsulate"}
+
+func (c Capability) String() string {
+	var names []string
+	for i, name := range capabilityNames {
+		if c&(1<<uint(i)) != 0 {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return "none"
+	}
+	return strings.Join(names, "|")
+}
+
+// CapabilityReporter is an optional interface that an Unpacker may implement
+// to tell what the keys of a key package are good for, without unpacking it.
+//
+// The result may depend on the algorithm parameters or attributes in the key
+// package, e.g. a key restricted to signing.
+type CapabilityReporter interface {
+	Capabilities(pkg *OneAsymmetricKey) Capability
+}
+
+// Capabilities returns what the key in the key package is good for,
+// according to the unpackers registered for its algorithm OID.
+//
+// It does not unpack the key package.
+// The result is the union of the capabilities reported by the unpackers
+// that implement CapabilityReporter.
+func (unpackers unpackers) Capabilities(pkg *OneAsymmetricKey) Capability {
+	if pkg == nil {
+		panic("key package is nil")
+	}
+	var c Capability
+	s := algo2str(pkg.PrivateKeyAlgorithm.Algorithm)
+	for _, unpacker := range unpackers[s] {
+		if reporter, ok := unpacker.(CapabilityReporter); ok {
+			c |= reporter.Capabilities(pkg)
+		}
+	}
+	return c
+}
+
+// Capabilities returns what the key in the key package is good for.
+func (pkg *OneAsymmetricKey) Capabilities() Capability {
+	return Unpackers.Capabilities(pkg)
+}
@@ -0,0 +1,154 @@
+package akp
+
+import (
+	"crypto/sha1" // nolint: RFC 5280 key identifiers are defined over SHA-1
+	"crypto/sha256"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/json"
+	"reflect"
+)
+
+// JWKThumbprinter is an optional interface that a Packer may implement
+// to provide the required members of the JSON Web Key of public keys of its
+// type, as listed in RFC 7638, section 3.2.
+//
+// JWKThumbprintMembers returns ErrSkip for a public key with an unrecognized
+// type.
+type JWKThumbprinter interface {
+	JWKThumbprintMembers(pub interface{}) (members map[string]string, err error)
+}
+
+// Fingerprint contains the identifiers of a public key.
+type Fingerprint struct {
+	// KeyID1 is the RFC 5280 method 1 key identifier:
+	// The SHA-1 hash of the public key bits.
+	KeyID1 []byte
+
+	// KeyID2 is the RFC 5280 method 2 key identifier:
+	// The four-bit type field 0100 followed by the least significant 60 bits
+	// of the SHA-1 hash of the public key bits.
+	KeyID2 []byte
+
+	// KeyIDSHA256 is the RFC 7093 method 1 key identifier:
+	// The leftmost 160 bits of the SHA-256 hash of the public key bits.
+	KeyIDSHA256 []byte
+
+	// SSHSHA256 is the OpenSSH SHA256 fingerprint, e.g. "SHA256:…",
+	// or empty if the key type has no SSH public key format.
+	SSHSHA256 string
+
+	// JWKThumbprint is the RFC 7638 SHA-256 JWK thumbprint,
+	// or nil if the key type has no JSON Web Key format.
+	JWKThumbprint []byte
+}
+
+// JWKThumbprintString returns the base64url encoding of the JWK thumbprint,
+// as used in "kid" values.
+func (fp *Fingerprint) JWKThumbprintString() string {
+	if fp.JWKThumbprint == nil {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString(fp.JWKThumbprint)
+}
+
+// KeyFingerprint computes the identifiers of a private/public key pair.
+//
+// The public key is optional; it is derived from the private key if nil.
+func (packers packers) KeyFingerprint(priv interface{}, pub interface{}) (
+	fp *Fingerprint, err error,
+) {
+	if pub == nil {
+		if pub, err = packers.DerivePublicKey(priv); err != nil {
+			return nil, err
+		}
+	}
+	bits, err := packers.PackPublicKey(priv, pub)
+	if err != nil {
+		return nil, err
+	}
+	return packers.fingerprint(priv, pub, bits)
+}
+
+// fingerprint computes the identifiers of a private/public key pair whose
+// public key bits are given.
+func (packers packers) fingerprint(
+	priv interface{}, pub interface{}, bits asn1.BitString,
+) (fp *Fingerprint, err error) {
+	fp = new(Fingerprint)
+	sum1 := sha1.Sum(bits.Bytes) // nolint
+	fp.KeyID1 = sum1[:]
+	fp.KeyID2 = append([]byte(nil), sum1[12:]...)
+	fp.KeyID2[0] = 0x40 | fp.KeyID2[0]&0x0f
+	sum256 := sha256.Sum256(bits.Bytes)
+	fp.KeyIDSHA256 = sum256[:20]
+	ssh, err := packers.MarshalSSHPublicKey(priv, pub)
+	switch err {
+	case nil:
+		sum := sha256.Sum256(ssh)
+		fp.SSHSHA256 = "SHA256:" + base64.RawStdEncoding.EncodeToString(sum[:])
+	case ErrSkip:
+	default:
+		return nil, err
+	}
+	members, err := packers.jwkThumbprintMembers(priv, pub)
+	switch err {
+	case nil:
+		// encoding/json sorts map keys and emits no whitespace,
+		// as required by RFC 7638, section 3.3.
+		encoded, err := json.Marshal(members)
+		if err != nil {
+			return nil, err
+		}
+		sum := sha256.Sum256(encoded)
+		fp.JWKThumbprint = sum[:]
+	case ErrSkip:
+	default:
+		return nil, err
+	}
+	return fp, nil
+}
+
+func (packers packers) jwkThumbprintMembers(priv interface{}, pub interface{}) (
+	members map[string]string, err error,
+) {
+	for _, packer := range packers[reflect.TypeOf(priv)] {
+		if thumbprinter, ok := packer.(JWKThumbprinter); ok {
+			members, err = thumbprinter.JWKThumbprintMembers(pub)
+			if err != ErrSkip {
+				return
+			}
+		}
+	}
+	return nil, ErrSkip
+}
+
+// KeyFingerprint computes the identifiers of a private/public key pair.
+//
+// The public key is optional; it is derived from the private key if nil.
+var KeyFingerprint = Packers.KeyFingerprint
+
+// Fingerprint computes the identifiers of the public key in the key package.
+//
+// If the key package does not contain the public key,
+// Fingerprint derives it from the private key.
+// The key identifiers hash the public key bits of the SubjectPublicKeyInfo
+// of the key package, so that they match those of certificates for it,
+// even if the key package keeps its public key in a different encoding
+// (e.g. a compressed point) than PackPublicKey.
+func (pkg *OneAsymmetricKey) Fingerprint() (*Fingerprint, error) {
+	priv, pub, _, err := Unpack(pkg)
+	if err != nil {
+		return nil, err
+	}
+	if pub == nil {
+		if pub, err = DerivePublicKey(priv); err != nil {
+			return nil, err
+		}
+	}
+	spki, err := pkg.PublicKeyInfo()
+	if err != nil {
+		return nil, err
+	}
+	return Packers.fingerprint(priv, pub, spki.PublicKey)
+}
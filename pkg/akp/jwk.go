@@ -0,0 +1,79 @@
+package akp
+
+import "reflect"
+
+// JWKCodec is an optional interface that a Packer may implement to convert
+// private keys of its type to and from JSON Web Key members
+// (RFC 7517, RFC 7518).
+type JWKCodec interface {
+	// JWKKeyTypes returns the "kty" values that the codec handles,
+	// e.g. "RSA".
+	JWKKeyTypes() []string
+
+	// MarshalJWK returns the members of the private JWK for the given private
+	// key, including "kty" but excluding generic members such as "kid".
+	//
+	// It returns ErrSkip for a private key with an unrecognized type.
+	MarshalJWK(priv interface{}) (members map[string]interface{}, err error)
+
+	// UnmarshalJWK converts the members of a private JWK into a
+	// private/public key pair.
+	//
+	// It returns ErrSkip for a JWK that it does not handle,
+	// e.g. one with an unrecognized "crv".
+	UnmarshalJWK(members map[string]interface{}) (
+		priv interface{}, pub interface{}, err error,
+	)
+}
+
+// MarshalJWK returns the members of the private JWK for the given private key.
+//
+// It searches the receiver for the right packers for the private key type,
+// and tries those that implement JWKCodec, in the order of registration.
+// It returns ErrSkip if none of them can convert the private key.
+func (packers packers) MarshalJWK(priv interface{}) (
+	members map[string]interface{}, err error,
+) {
+	for _, packer := range packers[reflect.TypeOf(priv)] {
+		if codec, ok := packer.(JWKCodec); ok {
+			members, err = codec.MarshalJWK(priv)
+			if err != ErrSkip {
+				return
+			}
+		}
+	}
+	return nil, ErrSkip
+}
+
+// UnmarshalJWK converts the members of a private JWK into a private/public key
+// pair.
+//
+// It tries the JWK codecs of the receiver that handle the "kty" of the JWK.
+// It returns ErrSkip if none of them can convert the JWK.
+func (packers packers) UnmarshalJWK(members map[string]interface{}) (
+	priv interface{}, pub interface{}, err error,
+) {
+	kty, _ := members["kty"].(string)
+	for _, typePackers := range packers {
+		for _, packer := range typePackers {
+			codec, ok := packer.(JWKCodec)
+			if !ok || !contains(codec.JWKKeyTypes(), kty) {
+				continue
+			}
+			priv, pub, err = codec.UnmarshalJWK(members)
+			if err != ErrSkip {
+				return
+			}
+		}
+	}
+	return nil, nil, ErrSkip
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
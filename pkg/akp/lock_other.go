@@ -0,0 +1,16 @@
+//go:build !unix && !windows
+
+package akp
+
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
+// lockFile fails: this system has no file locks that StatefulSigner can
+// rely on.
+func lockFile(filename string) (*os.File, error) {
+	return nil, fmt.Errorf("cannot lock %s: %w",
+		filename, errors.ErrUnsupported)
+}
@@ -0,0 +1,28 @@
+//go:build unix
+
+package akp
+
+import (
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// lockFile opens the given file, creating it if needed, and takes an
+// exclusive lock on it, which lasts until the file is closed.
+// It fails with ErrLocked if another open file holds the lock.
+func lockFile(filename string) (*os.File, error) {
+	file, err := os.OpenFile(filename, os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	err = unix.Flock(int(file.Fd()), unix.LOCK_EX|unix.LOCK_NB)
+	if err != nil {
+		file.Close() // nolint
+		if err == unix.EWOULDBLOCK {
+			return nil, ErrLocked
+		}
+		return nil, err
+	}
+	return file, nil
+}
@@ -0,0 +1,30 @@
+//go:build windows
+
+package akp
+
+import (
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// lockFile opens the given file, creating it if needed, and takes an
+// exclusive lock on it, which lasts until the file is closed.
+// It fails with ErrLocked if another open file holds the lock.
+func lockFile(filename string) (*os.File, error) {
+	file, err := os.OpenFile(filename, os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	err = windows.LockFileEx(windows.Handle(file.Fd()),
+		windows.LOCKFILE_EXCLUSIVE_LOCK|windows.LOCKFILE_FAIL_IMMEDIATELY,
+		0, 1, 0, new(windows.Overlapped))
+	if err != nil {
+		file.Close() // nolint
+		if err == windows.ERROR_LOCK_VIOLATION {
+			return nil, ErrLocked
+		}
+		return nil, err
+	}
+	return file, nil
+}
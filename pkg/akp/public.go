@@ -0,0 +1,136 @@
+package akp
+
+import (
+	"crypto"
+	"encoding/asn1"
+	"errors"
+	"reflect"
+)
+
+// PublicKeyDeriver is an optional interface that a Packer may implement
+// if it can derive the public key from a private key of its type.
+//
+// DerivePublicKey returns ErrSkip for a private key with an unrecognized type.
+type PublicKeyDeriver interface {
+	DerivePublicKey(priv interface{}) (pub interface{}, err error)
+}
+
+// DerivePublicKey derives the public key from the given private key.
+//
+// It searches the receiver for the right packers for the private key type,
+// and tries those that implement PublicKeyDeriver,
+// in the order of registration.
+// If none of them can derive the public key,
+// it falls back to the Public method of the private key, if any.
+func (packers packers) DerivePublicKey(priv interface{}) (
+	pub interface{}, err error,
+) {
+	typ := reflect.TypeOf(priv)
+	if typ == nil {
+		return nil, errors.New("nil private key")
+	}
+	for _, packer := range packers[typ] {
+		deriver, ok := packer.(PublicKeyDeriver)
+		if !ok {
+			continue
+		}
+		pub, err = deriver.DerivePublicKey(priv)
+		if err != ErrSkip {
+			return
+		}
+	}
+	if signer, ok := priv.(interface{ Public() crypto.PublicKey }); ok {
+		return signer.Public(), nil
+	}
+	return nil, errors.New("cannot derive public key")
+}
+
+// DerivePublicKey derives the public key from the given private key.
+var DerivePublicKey = Packers.DerivePublicKey
+
+// PublicKeyPacker is an optional interface that a Packer may implement
+// if it can pack a public key of its type on its own,
+// i.e. without the private key.
+//
+// PackPublicKey returns ErrSkip for a public key with an unrecognized type.
+type PublicKeyPacker interface {
+	PackPublicKey(pub interface{}) (bits asn1.BitString, err error)
+}
+
+// PackPublicKey returns the public key portion of a private/public key pair,
+// as it is stored in the PublicKey field of a key package.
+//
+// If pub is nil, it is derived from priv.
+//
+// It searches the receiver for the right packers for the private key type,
+// and tries those that implement PublicKeyPacker, in the order of
+// registration.
+// If none of them can pack the public key,
+// it packs the whole key pair and extracts the public key portion.
+func (packers packers) PackPublicKey(priv interface{}, pub interface{}) (
+	bits asn1.BitString, err error,
+) {
+	if pub == nil {
+		if pub, err = packers.DerivePublicKey(priv); err != nil {
+			return
+		}
+	}
+	for _, packer := range packers[reflect.TypeOf(priv)] {
+		if pubPacker, ok := packer.(PublicKeyPacker); ok {
+			bits, err = pubPacker.PackPublicKey(pub)
+			if err != ErrSkip {
+				return
+			}
+		}
+	}
+	pkg, err := packers.Pack(priv, pub)
+	if err != nil {
+		return
+	}
+	if pkg.PublicKey.Bytes == nil {
+		err = errors.New("packer did not pack public key")
+		return
+	}
+	return pkg.PublicKey, nil
+}
+
+// PackPublicKey returns the public key portion of a private/public key pair,
+// as it is stored in the PublicKey field of a key package.
+//
+// If pub is nil, it is derived from priv.
+var PackPublicKey = Packers.PackPublicKey
+
+// PublicKeyBits returns the public key portion of the key package,
+// as it is stored in its PublicKey field.
+//
+// If the key package does not contain the public key,
+// PublicKeyBits unpacks the private key and derives the public key from it.
+// If unpacking returns extras, they are the options to repack the key pair
+// with, which may select the encoding of the public key (e.g. a compressed
+// point), so the key pair is repacked with them.
+func (pkg *OneAsymmetricKey) PublicKeyBits() (bits asn1.BitString, err error) {
+	if pkg.PublicKey.Bytes != nil {
+		return pkg.PublicKey, nil
+	}
+	priv, pub, extras, err := Unpack(pkg)
+	if err != nil {
+		return
+	}
+	if len(extras) == 0 {
+		return PackPublicKey(priv, pub)
+	}
+	if pub == nil {
+		if pub, err = DerivePublicKey(priv); err != nil {
+			return
+		}
+	}
+	repacked, err := Pack(priv, pub, extras...)
+	if err != nil {
+		return
+	}
+	if repacked.PublicKey.Bytes == nil {
+		err = errors.New("packer did not pack public key")
+		return
+	}
+	return repacked.PublicKey, nil
+}
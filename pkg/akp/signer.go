@@ -0,0 +1,237 @@
+package akp
+
+import (
+	"crypto"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+)
+
+// ErrNotSigner means the private key cannot sign.
+var ErrNotSigner = errors.New("private key cannot sign")
+
+// ErrNotDecrypter means the private key cannot decrypt.
+var ErrNotDecrypter = errors.New("private key cannot decrypt")
+
+// SignerProvider is an optional interface that a Packer may implement
+// to adapt private keys of its type that do not implement crypto.Signer
+// themselves.
+//
+// Signer returns ErrSkip for a private key with an unrecognized type.
+type SignerProvider interface {
+	Signer(priv interface{}) (signer crypto.Signer, err error)
+}
+
+// DecrypterProvider is an optional interface that a Packer may implement
+// to adapt private keys of its type that do not implement crypto.Decrypter
+// themselves.
+//
+// Decrypter returns ErrSkip for a private key with an unrecognized type.
+type DecrypterProvider interface {
+	Decrypter(priv interface{}) (decrypter crypto.Decrypter, err error)
+}
+
+// RestrictedSignerProvider is an optional interface that a Packer may
+// implement to provide signers that honor the restrictions of a key package,
+// such as the parameters of an RSASSA-PSS key,
+// given as the extras returned by unpacking it.
+//
+// RestrictedSigner returns ErrSkip for a private key with an unrecognized
+// type, or if the extras do not restrict it.
+type RestrictedSignerProvider interface {
+	RestrictedSigner(priv interface{}, extras []interface{}) (
+		signer crypto.Signer, err error,
+	)
+}
+
+// RestrictedDecrypterProvider is an optional interface that a Packer may
+// implement to provide decrypters that honor the restrictions of a key
+// package, such as the parameters of an RSAES-OAEP key,
+// given as the extras returned by unpacking it.
+//
+// RestrictedDecrypter returns ErrSkip for a private key with an
+// unrecognized type, or if the extras do not restrict it.
+type RestrictedDecrypterProvider interface {
+	RestrictedDecrypter(priv interface{}, extras []interface{}) (
+		decrypter crypto.Decrypter, err error,
+	)
+}
+
+// Signer returns a crypto.Signer for the given private key.
+//
+// It searches the receiver for the right packers for the private key type,
+// and tries those that implement SignerProvider, in the order of
+// registration.
+// If none of them can provide a signer, it falls back to the private key
+// itself if it implements crypto.Signer.
+//
+// The returned error wraps ErrNotSigner if the private key cannot sign.
+// It is ErrStatefulKey for a StatefulKey: use OpenStatefulSigner instead.
+func (packers packers) Signer(priv interface{}) (
+	signer crypto.Signer, err error,
+) {
+	if _, ok := priv.(StatefulKey); ok {
+		return nil, ErrStatefulKey
+	}
+	for _, packer := range packers[reflect.TypeOf(priv)] {
+		if provider, ok := packer.(SignerProvider); ok {
+			signer, err = provider.Signer(priv)
+			if err != ErrSkip {
+				return
+			}
+		}
+	}
+	if signer, ok := priv.(crypto.Signer); ok {
+		return signer, nil
+	}
+	return nil, fmt.Errorf("%w: %T", ErrNotSigner, priv)
+}
+
+// Decrypter returns a crypto.Decrypter for the given private key.
+//
+// It searches the receiver for the right packers for the private key type,
+// and tries those that implement DecrypterProvider, in the order of
+// registration.
+// If none of them can provide a decrypter, it falls back to the private key
+// itself if it implements crypto.Decrypter.
+//
+// The returned error wraps ErrNotDecrypter if the private key cannot decrypt.
+func (packers packers) Decrypter(priv interface{}) (
+	decrypter crypto.Decrypter, err error,
+) {
+	for _, packer := range packers[reflect.TypeOf(priv)] {
+		if provider, ok := packer.(DecrypterProvider); ok {
+			decrypter, err = provider.Decrypter(priv)
+			if err != ErrSkip {
+				return
+			}
+		}
+	}
+	if decrypter, ok := priv.(crypto.Decrypter); ok {
+		return decrypter, nil
+	}
+	return nil, fmt.Errorf("%w: %T", ErrNotDecrypter, priv)
+}
+
+// RestrictedSigner returns a crypto.Signer for the given private key,
+// restricted by the given extras, as returned by unpacking its key package.
+//
+// It tries the packers that implement RestrictedSignerProvider first,
+// then falls back to Signer.
+// It does not check the capabilities of the key package:
+// callers that have it must check CanSign first, as UnpackSigner does.
+func (packers packers) RestrictedSigner(
+	priv interface{}, extras []interface{},
+) (signer crypto.Signer, err error) {
+	for _, packer := range packers[reflect.TypeOf(priv)] {
+		if provider, ok := packer.(RestrictedSignerProvider); ok {
+			signer, err = provider.RestrictedSigner(priv, extras)
+			if err != ErrSkip {
+				return
+			}
+		}
+	}
+	return packers.Signer(priv)
+}
+
+// RestrictedDecrypter is the crypto.Decrypter counterpart of
+// RestrictedSigner.
+func (packers packers) RestrictedDecrypter(
+	priv interface{}, extras []interface{},
+) (decrypter crypto.Decrypter, err error) {
+	for _, packer := range packers[reflect.TypeOf(priv)] {
+		if provider, ok := packer.(RestrictedDecrypterProvider); ok {
+			decrypter, err = provider.RestrictedDecrypter(priv, extras)
+			if err != ErrSkip {
+				return
+			}
+		}
+	}
+	return packers.Decrypter(priv)
+}
+
+// UnpackSigner unpacks a key package into a crypto.Signer.
+//
+// It fails with ErrNotSigner unless the unpackers of the key package report
+// the CanSign capability, so that a key restricted to decryption cannot
+// sign.
+// The signer honors the restrictions of the key package,
+// e.g. the parameters of an RSASSA-PSS key.
+func UnpackSigner(pkg *OneAsymmetricKey) (crypto.Signer, error) {
+	if pkg.Capabilities()&CanSign == 0 {
+		return nil, fmt.Errorf("%w: %v key package", ErrNotSigner,
+			pkg.PrivateKeyAlgorithm.Algorithm)
+	}
+	priv, _, extras, err := Unpack(pkg)
+	if err != nil {
+		return nil, err
+	}
+	return Packers.RestrictedSigner(priv, extras)
+}
+
+// DecodeSigner decodes an ASN.1-encoded key package into a crypto.Signer.
+func DecodeSigner(encoded []byte) (crypto.Signer, error) {
+	pkg, err := unmarshal(encoded)
+	if err != nil {
+		return nil, err
+	}
+	return UnpackSigner(pkg)
+}
+
+// LoadSigner loads a crypto.Signer from the given file.
+func LoadSigner(filename string) (crypto.Signer, error) {
+	encoded, err := readFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	return DecodeSigner(encoded)
+}
+
+// UnpackDecrypter unpacks a key package into a crypto.Decrypter.
+//
+// It fails with ErrNotDecrypter unless the unpackers of the key package
+// report the CanDecrypt capability, so that a key restricted to signing
+// cannot decrypt.
+// The decrypter honors the restrictions of the key package,
+// e.g. the parameters of an RSAES-OAEP key.
+func UnpackDecrypter(pkg *OneAsymmetricKey) (crypto.Decrypter, error) {
+	if pkg.Capabilities()&CanDecrypt == 0 {
+		return nil, fmt.Errorf("%w: %v key package", ErrNotDecrypter,
+			pkg.PrivateKeyAlgorithm.Algorithm)
+	}
+	priv, _, extras, err := Unpack(pkg)
+	if err != nil {
+		return nil, err
+	}
+	return Packers.RestrictedDecrypter(priv, extras)
+}
+
+// DecodeDecrypter decodes an ASN.1-encoded key package into a
+// crypto.Decrypter.
+func DecodeDecrypter(encoded []byte) (crypto.Decrypter, error) {
+	pkg, err := unmarshal(encoded)
+	if err != nil {
+		return nil, err
+	}
+	return UnpackDecrypter(pkg)
+}
+
+// LoadDecrypter loads a crypto.Decrypter from the given file.
+func LoadDecrypter(filename string) (crypto.Decrypter, error) {
+	encoded, err := readFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	return DecodeDecrypter(encoded)
+}
+
+func readFile(filename string) ([]byte, error) {
+	file, err := os.Open(filename) // nolint
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close() // nolint
+	v, _, err := readValue(file)
+	return v, err
+}
@@ -0,0 +1,95 @@
+package akp
+
+import (
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+)
+
+// SubjectPublicKeyInfo is the X.509 public key structure
+// (RFC 5280, section 4.1).
+type SubjectPublicKeyInfo struct {
+	Algorithm pkix.AlgorithmIdentifier
+	PublicKey asn1.BitString
+}
+
+// PublicKeyInfoUnpacker is an optional interface that an Unpacker may
+// implement if the SubjectPublicKeyInfo of its algorithm cannot be assembled
+// from the key package as is, e.g. if the public key uses a different
+// algorithm identifier than the private key.
+//
+// UnpackPublicKeyInfo returns ErrSkip for a key package that it does not
+// handle; Unpackers.PublicKeyInfo then falls back to the default assembly.
+type PublicKeyInfoUnpacker interface {
+	UnpackPublicKeyInfo(pkg *OneAsymmetricKey) (
+		spki *SubjectPublicKeyInfo, err error,
+	)
+}
+
+// PublicKeyInfo returns the SubjectPublicKeyInfo of the key package.
+//
+// It searches the receiver for the right unpackers for the algorithm OID,
+// and tries those that implement PublicKeyInfoUnpacker,
+// in the order of registration.
+// If none of them can handle the key package,
+// the SubjectPublicKeyInfo is assembled from the private key algorithm and
+// the public key, which is derived from the private key if the key package
+// does not contain it.
+//
+// The private key is never returned to the caller.
+func (unpackers unpackers) PublicKeyInfo(pkg *OneAsymmetricKey) (
+	spki *SubjectPublicKeyInfo, err error,
+) {
+	if pkg == nil {
+		panic("key package is nil")
+	}
+	s := algo2str(pkg.PrivateKeyAlgorithm.Algorithm)
+	for _, unpacker := range unpackers[s] {
+		if spkiUnpacker, ok := unpacker.(PublicKeyInfoUnpacker); ok {
+			spki, err = spkiUnpacker.UnpackPublicKeyInfo(pkg)
+			if err != ErrSkip {
+				return
+			}
+		}
+	}
+	bits, err := pkg.PublicKeyBits()
+	if err != nil {
+		return nil, err
+	}
+	return &SubjectPublicKeyInfo{
+		Algorithm: pkg.PrivateKeyAlgorithm,
+		PublicKey: bits,
+	}, nil
+}
+
+// PublicKeyInfo returns the SubjectPublicKeyInfo of the key package.
+func (pkg *OneAsymmetricKey) PublicKeyInfo() (*SubjectPublicKeyInfo, error) {
+	return Unpackers.PublicKeyInfo(pkg)
+}
+
+// MarshalPublicKeyInfo returns the DER encoding of the SubjectPublicKeyInfo of
+// the key package, as accepted by x509.ParsePKIXPublicKey.
+func (pkg *OneAsymmetricKey) MarshalPublicKeyInfo() ([]byte, error) {
+	spki, err := pkg.PublicKeyInfo()
+	if err != nil {
+		return nil, err
+	}
+	return asn1.Marshal(*spki)
+}
+
+// PublicKeyInfoPEMType is the PEM block type of a SubjectPublicKeyInfo
+// (RFC 7468, section 13).
+const PublicKeyInfoPEMType = "PUBLIC KEY"
+
+// MarshalPublicKeyInfoPEM returns the PEM encoding of the SubjectPublicKeyInfo
+// of the key package.
+func (pkg *OneAsymmetricKey) MarshalPublicKeyInfoPEM() ([]byte, error) {
+	der, err := pkg.MarshalPublicKeyInfo()
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{
+		Type:  PublicKeyInfoPEMType,
+		Bytes: der,
+	}), nil
+}
@@ -0,0 +1,94 @@
+package akp
+
+import "reflect"
+
+// SSHPublicKeyMarshaler is an optional interface that a Packer may implement
+// to encode public keys of its type in the SSH public key format
+// (RFC 4253, section 6.6).
+//
+// MarshalSSHPublicKey returns ErrSkip for a public key with an unrecognized
+// type.
+type SSHPublicKeyMarshaler interface {
+	MarshalSSHPublicKey(pub interface{}) (encoded []byte, err error)
+}
+
+// SSHPrivateKeyCodec is an optional interface that a Packer may implement
+// to convert private keys of its type to and from the key-type-specific
+// portion of a private key in the OpenSSH private key format
+// (PROTOCOL.key in the OpenSSH distribution).
+type SSHPrivateKeyCodec interface {
+	// SSHKeyTypes returns the SSH key type names that the codec handles,
+	// e.g. "ssh-rsa".
+	SSHKeyTypes() []string
+
+	// MarshalSSHPrivateKey returns the SSH key type name and the fields of
+	// the given private key, excluding the key type name itself.
+	//
+	// It returns ErrSkip for a private key with an unrecognized type.
+	MarshalSSHPrivateKey(priv interface{}) (
+		keyType string, fields []byte, err error,
+	)
+
+	// UnmarshalSSHPrivateKey parses the fields of a private key of the given
+	// SSH key type at the start of data,
+	// and returns the private/public key pair and the data after the fields.
+	UnmarshalSSHPrivateKey(keyType string, data []byte) (
+		priv interface{}, pub interface{}, rest []byte, err error,
+	)
+}
+
+// MarshalSSHPublicKey encodes the public key of a key pair in the SSH public
+// key format.
+//
+// It searches the receiver for the right packers for the private key type,
+// and tries those that implement SSHPublicKeyMarshaler,
+// in the order of registration.
+// It returns ErrSkip if none of them can encode the public key.
+func (packers packers) MarshalSSHPublicKey(priv interface{}, pub interface{}) (
+	encoded []byte, err error,
+) {
+	for _, packer := range packers[reflect.TypeOf(priv)] {
+		if marshaler, ok := packer.(SSHPublicKeyMarshaler); ok {
+			encoded, err = marshaler.MarshalSSHPublicKey(pub)
+			if err != ErrSkip {
+				return
+			}
+		}
+	}
+	return nil, ErrSkip
+}
+
+// MarshalSSHPrivateKey returns the SSH key type name and the fields of the
+// given private key.
+//
+// It searches the receiver for the right packers for the private key type,
+// and tries those that implement SSHPrivateKeyCodec,
+// in the order of registration.
+// It returns ErrSkip if none of them can encode the private key.
+func (packers packers) MarshalSSHPrivateKey(priv interface{}) (
+	keyType string, fields []byte, err error,
+) {
+	for _, packer := range packers[reflect.TypeOf(priv)] {
+		if codec, ok := packer.(SSHPrivateKeyCodec); ok {
+			keyType, fields, err = codec.MarshalSSHPrivateKey(priv)
+			if err != ErrSkip {
+				return
+			}
+		}
+	}
+	return "", nil, ErrSkip
+}
+
+// SSHPrivateKeyCodec returns the codec registered for the given SSH key type
+// name, or nil if there is none.
+func (packers packers) SSHPrivateKeyCodec(keyType string) SSHPrivateKeyCodec {
+	for _, typePackers := range packers {
+		for _, packer := range typePackers {
+			codec, ok := packer.(SSHPrivateKeyCodec)
+			if ok && contains(codec.SSHKeyTypes(), keyType) {
+				return codec
+			}
+		}
+	}
+	return nil
+}
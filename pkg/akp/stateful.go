@@ -0,0 +1,259 @@
+package akp
+
+import (
+	"crypto"
+	"encoding/asn1"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// ErrKeyExhausted means a stateful private key has no signatures left.
+var ErrKeyExhausted = errors.New("stateful private key is exhausted")
+
+// ErrStatefulKey means a stateful private key was asked for a signer other
+// than a StatefulSigner,
+// which could sign again with indices that a saved copy of the key still has.
+var ErrStatefulKey = errors.New(
+	"stateful private key can only sign through OpenStatefulSigner")
+
+// ErrLocked means the file of a stateful private key is already open in a
+// StatefulSigner.
+var ErrLocked = errors.New("stateful private key file is in use")
+
+// StatefulKey is implemented by private keys of stateful hash-based
+// signature schemes, such as LMS/HSS and XMSS.
+//
+// Each signature consumes a one-time index of the key, which must never be
+// used again; the index is part of the packed private key.
+// So saving a key and loading it again after signing reuses indices,
+// unless the key was saved after it signed,
+// which StatefulSigner takes care of.
+// Signer, UnpackSigner, DecodeSigner and LoadSigner refuse stateful keys
+// with ErrStatefulKey for that reason.
+type StatefulKey interface {
+	crypto.Signer
+
+	// Remaining returns the number of signatures that the key can make.
+	Remaining() uint64
+
+	// Reserve splits off the next n indices of the key:
+	// it returns a key that can only make the next n signatures,
+	// and advances the receiver past them.
+	Reserve(n uint64) (StatefulKey, error)
+}
+
+// StatefulSigner signs with a stateful private key saved in a file,
+// keeping the file up to date so that no index is ever used twice.
+//
+// It reserves indices in batches: before it releases the first signature
+// of a batch, it saves the key advanced past the whole batch, atomically
+// replacing the file.
+// Only the private key changes: the key package keeps its attributes and
+// public key.
+// A crash thus loses at most the unused indices of the current batch,
+// and never causes an index to be reused.
+//
+// From OpenStatefulSigner until Close, the signer holds an exclusive lock on
+// a lock file next to the key file, named after it with a ".lock" suffix,
+// so that no other StatefulSigner, in this process or another, signs with
+// the key at the same time.
+// Nothing else may write the key file while the signer is open.
+// A StatefulSigner is safe for concurrent use.
+type StatefulSigner struct {
+	filename string
+	batch    uint64
+
+	mu       sync.Mutex
+	lock     *os.File
+	pkg      OneAsymmetricKey
+	key      StatefulKey
+	options  []interface{}
+	reserved StatefulKey
+}
+
+// OpenStatefulSigner opens the stateful private key saved in the given file
+// for signing, reserving batch indices at a time.
+//
+// It fails with ErrLocked if another StatefulSigner has the file open.
+// Larger batches mean fewer writes but more indices lost in a crash.
+func OpenStatefulSigner(filename string, batch uint64) (
+	signer *StatefulSigner, err error,
+) {
+	if batch == 0 {
+		return nil, errors.New("batch size is zero")
+	}
+	lock, err := lockFile(filename + ".lock")
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			lock.Close() // nolint
+		}
+	}()
+	encoded, err := readFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	pkg, err := unmarshal(encoded)
+	if err != nil {
+		return nil, err
+	}
+	priv, _, extras, err := Unpack(pkg)
+	if err != nil {
+		return nil, err
+	}
+	key, ok := priv.(StatefulKey)
+	if !ok {
+		return nil, errors.New("private key is not stateful")
+	}
+	return &StatefulSigner{
+		filename: filename,
+		batch:    batch,
+		lock:     lock,
+		pkg:      *pkg,
+		key:      key,
+		options:  extras,
+	}, nil
+}
+
+// Close releases the lock on the key file.
+// The unused indices of the current batch are lost,
+// and the signer cannot sign anymore.
+func (signer *StatefulSigner) Close() error {
+	signer.mu.Lock()
+	defer signer.mu.Unlock()
+	if signer.lock == nil {
+		return os.ErrClosed
+	}
+	err := signer.lock.Close()
+	signer.lock = nil
+	signer.reserved = nil
+	return err
+}
+
+// Public returns the public key.
+func (signer *StatefulSigner) Public() crypto.PublicKey {
+	return signer.key.Public()
+}
+
+// Remaining returns the number of signatures that the signer can make.
+func (signer *StatefulSigner) Remaining() uint64 {
+	signer.mu.Lock()
+	defer signer.mu.Unlock()
+	remaining := signer.key.Remaining()
+	if signer.reserved != nil {
+		remaining += signer.reserved.Remaining()
+	}
+	return remaining
+}
+
+// Sign signs with the next index of the key,
+// first reserving and saving a new batch if needed.
+func (signer *StatefulSigner) Sign(
+	rand io.Reader, digest []byte, opts crypto.SignerOpts,
+) ([]byte, error) {
+	signer.mu.Lock()
+	defer signer.mu.Unlock()
+	if signer.lock == nil {
+		return nil, os.ErrClosed
+	}
+	if signer.reserved == nil || signer.reserved.Remaining() == 0 {
+		if err := signer.reserve(); err != nil {
+			return nil, err
+		}
+	}
+	return signer.reserved.Sign(rand, digest, opts)
+}
+
+func (signer *StatefulSigner) reserve() error {
+	n := min(signer.batch, signer.key.Remaining())
+	if n == 0 {
+		return ErrKeyExhausted
+	}
+	reserved, err := signer.key.Reserve(n)
+	if err != nil {
+		return err
+	}
+	// If saving fails, the batch is dropped: it is safe to skip indices.
+	advanced, err := Pack(signer.key, nil, signer.options...)
+	if err != nil {
+		return err
+	}
+	signer.pkg.PrivateKey = advanced.PrivateKey
+	encoded, err := asn1.Marshal(signer.pkg)
+	if err != nil {
+		return err
+	}
+	if err = writeFileAtomic(signer.filename, encoded); err != nil {
+		return err
+	}
+	signer.reserved = reserved
+	return nil
+}
+
+// SaveAtomic saves the given private/public key pair in a file, like Save,
+// but atomically: the file has either its old or its new contents, even if
+// the process or the system crashes, and the new contents are on stable
+// storage when SaveAtomic returns.
+//
+// An existing file keeps its permissions; a new file is only readable and
+// writable by its owner.
+func SaveAtomic(
+	filename string, priv interface{}, pub interface{}, options ...interface{},
+) error {
+	encoded, err := Encode(priv, pub, options...)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(filename, encoded)
+}
+
+// writeFileAtomic writes a file atomically, as SaveAtomic does.
+func writeFileAtomic(filename string, encoded []byte) (err error) {
+	dir, base := filepath.Split(filename)
+	if dir == "" {
+		dir = "."
+	}
+	file, err := os.CreateTemp(dir, "."+base+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			file.Close()           // nolint
+			os.Remove(file.Name()) // nolint
+		}
+	}()
+	if info, err := os.Stat(filename); err == nil {
+		if err = file.Chmod(info.Mode().Perm()); err != nil {
+			return err
+		}
+	}
+	if _, err = file.Write(encoded); err != nil {
+		return err
+	}
+	if err = file.Sync(); err != nil {
+		return err
+	}
+	if err = file.Close(); err != nil {
+		return err
+	}
+	if err = os.Rename(file.Name(), filename); err != nil {
+		return err
+	}
+	return syncDir(dir)
+}
+
+// syncDir commits a rename in the given directory to stable storage.
+func syncDir(dir string) error {
+	d, err := os.Open(dir) // nolint
+	if err != nil {
+		return err
+	}
+	defer d.Close() // nolint
+	return d.Sync()
+}
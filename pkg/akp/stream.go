@@ -21,10 +21,14 @@ func Write(
 func Read(r io.Reader) (
 	priv interface{}, pub interface{}, extras []interface{}, n int, err error,
 ) {
-	v, err := dvr.New(r).Read()
-	n = len(v)
+	v, n, err := readValue(r)
 	if err == nil {
 		priv, pub, extras, err = Decode(v)
 	}
 	return
 }
+
+func readValue(r io.Reader) (v []byte, n int, err error) {
+	v, err = dvr.New(r).Read()
+	return v, len(v), err
+}
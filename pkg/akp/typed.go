@@ -0,0 +1,136 @@
+package akp
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+)
+
+// TypeMismatchError means an unpacked key does not have the type requested by
+// the caller of one of the typed helpers, such as UnpackAs.
+type TypeMismatchError struct {
+	// Public tells whether the mismatching key is the public key.
+	Public bool
+
+	// Expected is the requested key type.
+	Expected reflect.Type
+
+	// Actual is the type of the unpacked key.
+	Actual reflect.Type
+}
+
+func (e *TypeMismatchError) Error() string {
+	half := "private"
+	if e.Public {
+		half = "public"
+	}
+	return fmt.Sprintf("%s key is a %v, not a %v", half, e.Actual, e.Expected)
+}
+
+func typeOf[T any]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+// UnpackWith unpacks a key package using the given unpacker,
+// and asserts the types of the unpacked keys.
+//
+// The public key is returned only if present in the key package;
+// otherwise pub is the zero value of Q.
+//
+// It returns a *TypeMismatchError if either key has an unexpected type.
+func UnpackWith[P, Q any](unpacker Unpacker, pkg *OneAsymmetricKey) (
+	priv P, pub Q, extras []interface{}, err error,
+) {
+	privKey, pubKey, extras, err := unpacker.Unpack(pkg)
+	if err != nil {
+		return priv, pub, nil, err
+	}
+	var ok bool
+	if priv, ok = privKey.(P); !ok {
+		return priv, pub, nil, &TypeMismatchError{
+			Expected: typeOf[P](), Actual: reflect.TypeOf(privKey),
+		}
+	}
+	if pubKey == nil {
+		return priv, pub, extras, nil
+	}
+	if pub, ok = pubKey.(Q); !ok {
+		var zero P
+		return zero, pub, nil, &TypeMismatchError{
+			Public: true, Expected: typeOf[Q](), Actual: reflect.TypeOf(pubKey),
+		}
+	}
+	return priv, pub, extras, nil
+}
+
+// UnpackAs unpacks a key package into a private/public key pair of the given
+// types, e.g. UnpackAs[*rsa.PrivateKey, *rsa.PublicKey](pkg).
+//
+// The public key is returned only if present in the key package;
+// otherwise pub is the zero value of Q.
+//
+// It returns a *TypeMismatchError if either key has an unexpected type.
+func UnpackAs[P, Q any](pkg *OneAsymmetricKey) (
+	priv P, pub Q, extras []interface{}, err error,
+) {
+	return UnpackWith[P, Q](Unpackers, pkg)
+}
+
+// DecodeAs decodes an ASN.1-encoded key package into a private/public key
+// pair of the given types.
+//
+// It returns a *TypeMismatchError if either key has an unexpected type.
+func DecodeAs[P, Q any](encoded []byte) (
+	priv P, pub Q, extras []interface{}, err error,
+) {
+	pkg, err := unmarshal(encoded)
+	if err != nil {
+		return
+	}
+	return UnpackAs[P, Q](pkg)
+}
+
+// ReadAs reads a private/public key pair of the given types from the given
+// reader.
+//
+// It returns a *TypeMismatchError if either key has an unexpected type.
+func ReadAs[P, Q any](r io.Reader) (
+	priv P, pub Q, extras []interface{}, n int, err error,
+) {
+	v, n, err := readValue(r)
+	if err == nil {
+		priv, pub, extras, err = DecodeAs[P, Q](v)
+	}
+	return
+}
+
+// LoadAs loads a private/public key pair of the given types from the given
+// file.
+//
+// It returns a *TypeMismatchError if either key has an unexpected type.
+func LoadAs[P, Q any](filename string) (
+	priv P, pub Q, extras []interface{}, err error,
+) {
+	file, err := os.Open(filename) // nolint
+	if err != nil {
+		return
+	}
+	defer file.Close() // nolint
+	priv, pub, extras, _, err = ReadAs[P, Q](file)
+	return
+}
+
+// PackAs packs a private/public key pair of the given types into a key
+// package.
+//
+// The zero value of Q, e.g. a nil pointer, means the public key is absent.
+func PackAs[P, Q any](priv P, pub Q, options ...interface{}) (
+	pkg *OneAsymmetricKey, err error,
+) {
+	var pubKey interface{} = pub
+	if v := reflect.ValueOf(&pub).Elem(); v.IsZero() {
+		pubKey = nil
+	}
+	return Pack(priv, pubKey, options...)
+}
@@ -0,0 +1,62 @@
+// Package blskp implements BLS12-381 signature keys
+// (draft-irtf-cfrg-bls-signature).
+//
+// Keys are *bls.PrivateKey and *bls.PublicKey values of
+// github.com/cloudflare/circl/sign/bls, with public keys in either G1
+// (bls.KeyG1SigG2, the minimal-pubkey-size variant) or G2 (bls.KeyG2SigG1).
+// Signer signs in the basic scheme of the draft, whose signatures do not
+// verify under other schemes or hash-to-curve methods, such as the
+// proof-of-possession scheme of Ethereum or the herumi BLS library that
+// Harmony validators use.
+//
+// Like RFC 8410 keys, the private key is an OCTET STRING wrapping the
+// 32-byte big-endian secret scalar,
+// and the public key is the compressed encoding of the G1 or G2 point
+// (48 or 96 bytes).
+//
+// The BLS signature draft does not assign OIDs to BLS keys, and no OIDs are
+// registered for them.
+// Until they are, this package uses provisional OIDs under the 2.999
+// example arc (ITU-T X.660), one for each public key group,
+// which no other implementation recognizes and which may change.
+// Pack refuses to use them unless it is given the Provisional option;
+// Unpack accepts them and returns Provisional as an extra.
+package blskp
+
+import (
+	"encoding/asn1"
+	"errors"
+
+	"github.com/cloudflare/circl/sign/bls"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+)
+
+var (
+	// algorithmOIDG1 is the provisional OID of BLS12-381 keys in G1.
+	algorithmOIDG1 = asn1.ObjectIdentifier{2, 999, 12381, 1}
+
+	// algorithmOIDG2 is the provisional OID of BLS12-381 keys in G2.
+	algorithmOIDG2 = asn1.ObjectIdentifier{2, 999, 12381, 2}
+)
+
+// provisional is the type of the Provisional option.
+type provisional struct{}
+
+// Provisional is the Pack option that accepts the provisional OIDs of BLS
+// keys.
+var Provisional provisional
+
+// ErrProvisional means a BLS key was packed without the Provisional option.
+var ErrProvisional = errors.New(
+	"BLS12-381 key OIDs are provisional; pack with the Provisional option")
+
+func init() {
+	akp.Packers.Register(Packer,
+		&bls.PrivateKey[bls.G1]{}, &bls.PrivateKey[bls.G2]{})
+	akp.Unpackers.Register(Unpacker, algorithmOIDG1, algorithmOIDG2)
+}
+
+// secretKeyLen is the length of the secret scalar (I2OSP(SK, 32) in the
+// BLS signature draft).
+const secretKeyLen = 32
@@ -0,0 +1,175 @@
+package blskp
+
+import (
+	"crypto"
+	"crypto/rand"
+	"encoding/asn1"
+	"math/big"
+	"testing"
+
+	"github.com/cloudflare/circl/sign/bls"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+)
+
+func generateKey[K bls.KeyGroup](t *testing.T) *bls.PrivateKey[K] {
+	ikm := make([]byte, 32)
+	if _, err := rand.Read(ikm); err != nil {
+		t.Fatal(err)
+	}
+	priv, err := bls.KeyGen[K](ikm, nil, nil)
+	if err != nil {
+		t.Fatalf("cannot generate BLS key pair: %v", err)
+	}
+	return priv
+}
+
+func testRoundTrip[K bls.KeyGroup](t *testing.T) {
+	priv := generateKey[K](t)
+	subtest := func(t *testing.T, pub *bls.PublicKey[K]) {
+		var pkg *akp.OneAsymmetricKey
+		var err error
+		if pub == nil {
+			pkg, err = Packer.Pack(priv, nil, Provisional)
+		} else {
+			pkg, err = Packer.Pack(priv, pub, Provisional)
+		}
+		if err != nil {
+			t.Fatalf("cannot pack BLS key pair: %v", err)
+		}
+		priv2, pub2, extras, err := Unpack[K](pkg)
+		if err != nil {
+			t.Fatalf("cannot unpack BLS key pair: %v", err)
+		}
+		if !priv.Equal(priv2) {
+			t.Error("reconstructed key is different from the original")
+		}
+		if pub == nil && pub2 != nil || pub != nil && !pub.Equal(pub2) {
+			t.Errorf("expected public key %v but got %v", pub, pub2)
+		}
+		if len(extras) != 1 || extras[0] != Provisional {
+			t.Errorf("extras are %+v; expected the Provisional option", extras)
+		}
+	}
+	t.Run("WithoutPublic", func(t *testing.T) { subtest(t, nil) })
+	t.Run("WithPublic", func(t *testing.T) {
+		subtest(t, priv.PublicKey())
+	})
+}
+
+func TestRoundTrip(t *testing.T) {
+	t.Run("G1", testRoundTrip[bls.G1])
+	t.Run("G2", testRoundTrip[bls.G2])
+}
+
+func TestProvisional(t *testing.T) {
+	priv := generateKey[bls.G1](t)
+	if _, err := akp.Pack(priv, nil); err != ErrProvisional {
+		t.Errorf("packing without the Provisional option returned %v; "+
+			"expected %v", err, ErrProvisional)
+	}
+	pkg, err := akp.Pack(priv, nil, Provisional)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Without its public key, the key package is repacked with the
+	// Provisional extra to compute the public key bits.
+	if _, err = pkg.PublicKeyBits(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestUnpack(t *testing.T) {
+	priv := generateKey[bls.G1](t)
+	pkg, err := Pack(priv, priv.PublicKey(), Provisional)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, _, err = Unpack[bls.G2](pkg); err != ErrNotBLS {
+		t.Errorf("unpacking G1 key as G2 returned %v; expected %v",
+			err, ErrNotBLS)
+	}
+
+	t.Run("MismatchingPublicKey", func(t *testing.T) {
+		other, err := Pack(generateKey[bls.G1](t), nil, Provisional)
+		if err != nil {
+			t.Fatal(err)
+		}
+		bad := *pkg
+		bad.PrivateKey = other.PrivateKey
+		if _, _, _, err = Unpacker.Unpack(&bad); err == nil {
+			t.Error("mismatching public key was accepted")
+		}
+	})
+
+	t.Run("NotInSubgroup", func(t *testing.T) {
+		// Find a point on the curve y² = x³ + 4 outside the prime-order
+		// subgroup, as almost all points are.
+		p, _ := new(big.Int).SetString("1a0111ea397fe69a4b1ba7b6434bacd7"+
+			"64774b84f38512bf6730d2a0f6b0f6241eabfffeb153ffffb9feffffffffaaab",
+			16)
+		x := big.NewInt(1)
+		for ; ; x.Add(x, big.NewInt(1)) {
+			y2 := new(big.Int).Exp(x, big.NewInt(3), p)
+			y2.Add(y2, big.NewInt(4))
+			if new(big.Int).ModSqrt(y2.Mod(y2, p), p) != nil {
+				break
+			}
+		}
+		point := x.FillBytes(make([]byte, 48))
+		point[0] |= 0x80 // compressed
+		bad := *pkg
+		bad.PublicKey = asn1.BitString{Bytes: point, BitLength: 8 * 48}
+		if _, _, _, err = Unpacker.Unpack(&bad); err == nil {
+			t.Error("public key outside the subgroup was accepted")
+		}
+	})
+
+	t.Run("BadLength", func(t *testing.T) {
+		bad := *pkg
+		bad.PrivateKey, _ = asn1.Marshal(make([]byte, 33))
+		if _, _, _, err = Unpacker.Unpack(&bad); err == nil {
+			t.Error("33-byte private key was accepted")
+		}
+	})
+}
+
+func TestSigner(t *testing.T) {
+	pkg, err := Pack(generateKey[bls.G1](t), nil, Provisional)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pkg.Capabilities() != akp.CanSign {
+		t.Errorf("capabilities are %v", pkg.Capabilities())
+	}
+	signer, err := akp.UnpackSigner(pkg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	message := []byte("hello, world")
+	sig, err := signer.Sign(rand.Reader, message, crypto.Hash(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub := signer.Public().(*bls.PublicKey[bls.G1])
+	if !bls.Verify(pub, message, sig) {
+		t.Error("cannot verify signature")
+	}
+	if _, err = signer.Sign(rand.Reader, message, crypto.SHA256); err == nil {
+		t.Error("signing a digest was accepted")
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	pkg, err := Pack(generateKey[bls.G2](t), nil, Provisional)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fp, err := pkg.Fingerprint()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fp.KeyIDSHA256 == nil {
+		t.Error("no SHA-256 key ID")
+	}
+}
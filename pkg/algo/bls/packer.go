@@ -0,0 +1,98 @@
+package blskp
+
+import (
+	"crypto/x509/pkix"
+	"encoding/asn1"
+
+	"github.com/cloudflare/circl/sign/bls"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+)
+
+type packer struct{}
+
+// Packer is the singleton packer instance.
+var Packer packer
+
+func (packer packer) Pack(
+	priv interface{}, pub interface{}, options ...interface{},
+) (pkg *akp.OneAsymmetricKey, err error) {
+	switch privKey := priv.(type) {
+	case *bls.PrivateKey[bls.G1]:
+		return packAny(privKey, pub, options...)
+	case *bls.PrivateKey[bls.G2]:
+		return packAny(privKey, pub, options...)
+	}
+	return nil, akp.ErrSkip
+}
+
+func packAny[K bls.KeyGroup](
+	privKey *bls.PrivateKey[K], pub interface{}, options ...interface{},
+) (pkg *akp.OneAsymmetricKey, err error) {
+	if pub == nil {
+		return Pack(privKey, nil, options...)
+	}
+	pubKey, ok := pub.(*bls.PublicKey[K])
+	if !ok {
+		return nil, akp.ErrSkip
+	}
+	return Pack(privKey, pubKey, options...)
+}
+
+// algorithmOID returns the algorithm OID of keys in the group K.
+func algorithmOID[K bls.KeyGroup]() asn1.ObjectIdentifier {
+	var k K
+	if _, ok := any(k).(bls.G1); ok {
+		return algorithmOIDG1
+	}
+	return algorithmOIDG2
+}
+
+// Pack packs the given BLS12-381 key pair into a key package.
+//
+// As the OIDs of BLS keys are provisional, it fails with ErrProvisional
+// unless the Provisional option is given.
+func Pack[K bls.KeyGroup](
+	privKey *bls.PrivateKey[K], pubKey *bls.PublicKey[K],
+	options ...interface{},
+) (pkg *akp.OneAsymmetricKey, err error) {
+	allowed := false
+	for _, option := range options {
+		if _, ok := option.(provisional); ok {
+			allowed = true
+		}
+	}
+	if !allowed {
+		return nil, ErrProvisional
+	}
+	sk, err := privKey.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	pkg = &akp.OneAsymmetricKey{
+		Version: akp.V1,
+		PrivateKeyAlgorithm: pkix.AlgorithmIdentifier{
+			Algorithm: algorithmOID[K](),
+		},
+	}
+	if pkg.PrivateKey, err = asn1.Marshal(sk); err != nil {
+		return nil, err
+	}
+	if pubKey != nil {
+		if pkg.PublicKey, err = packPublicKey(pubKey); err != nil {
+			return nil, err
+		}
+		pkg.Version = akp.V2
+	}
+	return pkg, nil
+}
+
+func packPublicKey[K bls.KeyGroup](
+	pubKey *bls.PublicKey[K],
+) (asn1.BitString, error) {
+	point, err := pubKey.MarshalBinary()
+	if err != nil {
+		return asn1.BitString{}, err
+	}
+	return asn1.BitString{Bytes: point, BitLength: 8 * len(point)}, nil
+}
@@ -0,0 +1,33 @@
+package blskp
+
+import (
+	"encoding/asn1"
+
+	"github.com/cloudflare/circl/sign/bls"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+)
+
+// DerivePublicKey returns the public portion of a BLS12-381 private key.
+func (packer packer) DerivePublicKey(priv interface{}) (
+	pub interface{}, err error,
+) {
+	switch privKey := priv.(type) {
+	case *bls.PrivateKey[bls.G1]:
+		return privKey.PublicKey(), nil
+	case *bls.PrivateKey[bls.G2]:
+		return privKey.PublicKey(), nil
+	}
+	return nil, akp.ErrSkip
+}
+
+// PackPublicKey packs a BLS12-381 public key as a compressed point.
+func (packer packer) PackPublicKey(pub interface{}) (asn1.BitString, error) {
+	switch pubKey := pub.(type) {
+	case *bls.PublicKey[bls.G1]:
+		return packPublicKey(pubKey)
+	case *bls.PublicKey[bls.G2]:
+		return packPublicKey(pubKey)
+	}
+	return asn1.BitString{}, akp.ErrSkip
+}
@@ -0,0 +1,51 @@
+package blskp
+
+import (
+	"crypto"
+	"errors"
+	"io"
+
+	"github.com/cloudflare/circl/sign/bls"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+)
+
+// Signer adapts a BLS12-381 private key with keys in the group K to
+// crypto.Signer.
+//
+// BLS signs messages, not digests, so like ed25519.PrivateKey,
+// Sign takes the message and requires crypto.Hash(0) as opts.
+// Signatures are in the basic scheme of the BLS signature draft, with its
+// BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_NUL_ (or G1) ciphersuite.
+type Signer[K bls.KeyGroup] struct {
+	*bls.PrivateKey[K]
+}
+
+// Sign signs the given message.
+// rand is ignored, as BLS signatures are deterministic.
+func (signer Signer[K]) Sign(
+	rand io.Reader, message []byte, opts crypto.SignerOpts,
+) ([]byte, error) {
+	if opts.HashFunc() != crypto.Hash(0) {
+		return nil, errors.New("BLS signs messages; opts must be crypto.Hash(0)")
+	}
+	return bls.Sign(signer.PrivateKey, message), nil
+}
+
+// Signer adapts a BLS12-381 private key to crypto.Signer.
+func (packer packer) Signer(priv interface{}) (crypto.Signer, error) {
+	switch privKey := priv.(type) {
+	case *bls.PrivateKey[bls.G1]:
+		return Signer[bls.G1]{privKey}, nil
+	case *bls.PrivateKey[bls.G2]:
+		return Signer[bls.G2]{privKey}, nil
+	}
+	return nil, akp.ErrSkip
+}
+
+// Capabilities reports that BLS12-381 keys can sign.
+func (unpacker unpacker) Capabilities(
+	pkg *akp.OneAsymmetricKey,
+) akp.Capability {
+	return akp.CanSign
+}
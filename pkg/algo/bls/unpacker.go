@@ -0,0 +1,98 @@
+package blskp
+
+import (
+	"encoding/asn1"
+	"errors"
+	"fmt"
+
+	"github.com/cloudflare/circl/sign/bls"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+)
+
+type unpacker struct{}
+
+// Unpacker is the singleton BLS12-381 unpacker instance.
+var Unpacker unpacker
+
+// Unpack unpacks a BLS12-381 private key.
+//
+// The public key, if present, must be a point in the prime-order subgroup
+// of G1 or G2, other than the identity,
+// and must match the private key.
+// The Provisional option is returned as an extra,
+// so that the key can be repacked.
+func (unpacker unpacker) Unpack(pkg *akp.OneAsymmetricKey) (
+	priv interface{}, pub interface{}, extras []interface{}, err error,
+) {
+	alg := pkg.PrivateKeyAlgorithm.Algorithm
+	switch {
+	case alg.Equal(algorithmOIDG1):
+		return unpackAny[bls.G1](pkg)
+	case alg.Equal(algorithmOIDG2):
+		return unpackAny[bls.G2](pkg)
+	}
+	return nil, nil, nil, akp.ErrSkip
+}
+
+func unpackAny[K bls.KeyGroup](pkg *akp.OneAsymmetricKey) (
+	priv interface{}, pub interface{}, extras []interface{}, err error,
+) {
+	if len(pkg.PrivateKeyAlgorithm.Parameters.FullBytes) > 0 {
+		return nil, nil, nil, errors.New("BLS12-381 key has parameters")
+	}
+	var sk []byte
+	rest, err := asn1.Unmarshal(pkg.PrivateKey, &sk)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf(
+			"cannot unmarshal private key: %v", err)
+	}
+	if len(rest) > 0 {
+		return nil, nil, nil, errors.New("extra data after BLS private key")
+	}
+	if len(sk) != secretKeyLen {
+		return nil, nil, nil, fmt.Errorf(
+			"BLS private key is %d bytes; expected %d", len(sk), secretKeyLen)
+	}
+	privKey := new(bls.PrivateKey[K])
+	if err = privKey.UnmarshalBinary(sk); err != nil {
+		return nil, nil, nil, fmt.Errorf(
+			"cannot unmarshal private key: %v", err)
+	}
+	if pkg.PublicKey.Bytes == nil {
+		return privKey, nil, []interface{}{Provisional}, nil
+	}
+	pubKey := new(bls.PublicKey[K])
+	if err = pubKey.UnmarshalBinary(pkg.PublicKey.RightAlign()); err != nil {
+		return nil, nil, nil, fmt.Errorf(
+			"cannot unmarshal public key: %v", err)
+	}
+	if !pubKey.Validate() {
+		return nil, nil, nil, errors.New(
+			"BLS public key is not in the prime-order subgroup")
+	}
+	if !pubKey.Equal(privKey.PublicKey()) {
+		return nil, nil, nil, errors.New(
+			"BLS public key does not match private key")
+	}
+	return privKey, pubKey, []interface{}{Provisional}, nil
+}
+
+// ErrNotBLS means the unpacked key is not a BLS12-381 key of the requested
+// group.
+var ErrNotBLS = errors.New("not a BLS12-381 key of the requested group")
+
+// Unpack unpacks a key package into a BLS12-381 key pair with keys in the
+// group K.
+func Unpack[K bls.KeyGroup](pkg *akp.OneAsymmetricKey) (
+	priv *bls.PrivateKey[K], pub *bls.PublicKey[K], extras []interface{},
+	err error,
+) {
+	priv, pub, extras, err = akp.UnpackWith[
+		*bls.PrivateKey[K], *bls.PublicKey[K]](Unpacker, pkg)
+	var mismatch *akp.TypeMismatchError
+	if errors.As(err, &mismatch) || err == akp.ErrSkip {
+		return nil, nil, nil, ErrNotBLS
+	}
+	return priv, pub, extras, err
+}
@@ -0,0 +1,87 @@
+// Package compositekp implements generic composite keys, as specified in
+// draft-ounsworth-pq-composite-keys of the IETF LAMPS working group,
+// which combine keys of several algorithms, e.g. a classical key and a
+// post-quantum key, into one logical key.
+//
+// Private keys are *PrivateKey values, whose components are key pairs of
+// any algorithm that the akp registries support;
+// public keys are *PublicKey values.
+//
+// The private key is a CompositePrivateKey,
+// i.e. a SEQUENCE SIZE (2..MAX) OF OneAsymmetricKey, one for each component,
+// which Pack and Unpack delegate to akp.Packers and akp.Unpackers.
+// The public key is a CompositePublicKey,
+// i.e. a SEQUENCE SIZE (2..MAX) OF SubjectPublicKeyInfo.
+//
+// Composite keys can sign if all their components can;
+// see Signer.
+package compositekp
+
+import (
+	"bytes"
+	"crypto"
+	"encoding/asn1"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+)
+
+// id-composite-key in the Entrust algorithm arc
+var algorithmOID = asn1.ObjectIdentifier{2, 16, 840, 1, 114027, 80, 4, 1}
+
+func init() {
+	akp.Packers.Register(Packer, &PrivateKey{})
+	akp.Unpackers.Register(Unpacker, algorithmOID)
+}
+
+// Component is a component key pair of a composite key.
+type Component struct {
+	// PrivateKey is the private key, of any type that akp.Packers handles.
+	PrivateKey interface{}
+
+	// PublicKey is the public key, if any, which is packed along with the
+	// private key in the component key package.
+	PublicKey interface{}
+
+	// Options are the options for packing the component;
+	// Unpack sets them to the extras of the component, if any.
+	Options []interface{}
+}
+
+// PrivateKey is a composite private key.
+type PrivateKey struct {
+	Components []Component
+}
+
+// PublicKey is a composite public key.
+type PublicKey struct {
+	// Components are the public keys of the components.
+	Components []akp.SubjectPublicKeyInfo
+}
+
+// Equal reports whether pub and x are the same public key.
+func (pub *PublicKey) Equal(x crypto.PublicKey) bool {
+	xx, ok := x.(*PublicKey)
+	if !ok || len(pub.Components) != len(xx.Components) {
+		return false
+	}
+	for i := range pub.Components {
+		if !equalInfo(&pub.Components[i], &xx.Components[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// equalInfo reports whether two SubjectPublicKeyInfo have the same
+// encoding.
+func equalInfo(a, b *akp.SubjectPublicKeyInfo) bool {
+	aDER, err := asn1.Marshal(*a)
+	if err != nil {
+		return false
+	}
+	bDER, err := asn1.Marshal(*b)
+	return err == nil && bytes.Equal(aDER, bDER)
+}
+
+// minComponents is the minimum number of components of a composite key.
+const minComponents = 2
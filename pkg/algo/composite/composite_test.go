@@ -0,0 +1,326 @@
+package compositekp
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/asn1"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/cloudflare/circl/kem/mlkem/mlkem768"
+	"github.com/cloudflare/circl/sign/mldsa/mldsa65"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+	"github.com/harmony-one/asym-key-pkgs/pkg/algo/lms"
+	"github.com/harmony-one/asym-key-pkgs/pkg/algo/mldsa"
+	"github.com/harmony-one/asym-key-pkgs/pkg/algo/mlkem"
+	"github.com/harmony-one/asym-key-pkgs/pkg/algo/rsa"
+	"github.com/harmony-one/asym-key-pkgs/pkg/algo/secp256k1"
+)
+
+// generateKey generates an ML-DSA-65 + secp256k1 ECDSA composite key.
+func generateKey(t *testing.T) *PrivateKey {
+	_, pqKey, err := mldsa65.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := secp256k1kp.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &PrivateKey{Components: []Component{
+		{PrivateKey: pqKey, Options: []interface{}{mldsakp.Both}},
+		{PrivateKey: ecKey, PublicKey: &ecKey.PublicKey},
+	}}
+}
+
+func TestRoundTrip(t *testing.T) {
+	priv := generateKey(t)
+	pub, err := Packer.DerivePublicKey(priv)
+	if err != nil {
+		t.Fatalf("cannot derive composite public key: %v", err)
+	}
+	for _, pub := range []*PublicKey{nil, pub.(*PublicKey)} {
+		pkg, err := Packer.Pack(priv, pub)
+		if err != nil {
+			t.Fatalf("cannot pack composite key pair: %v", err)
+		}
+		priv2, pub2, extras, err := Unpack(pkg)
+		if err != nil {
+			t.Fatalf("cannot unpack composite key pair: %v", err)
+		}
+		if len(priv2.Components) != len(priv.Components) {
+			t.Fatalf("composite key has %d components; expected %d",
+				len(priv2.Components), len(priv.Components))
+		}
+		for i, c := range priv.Components {
+			c2 := priv2.Components[i]
+			privKey := c.PrivateKey.(interface {
+				Equal(crypto.PrivateKey) bool
+			})
+			if !privKey.Equal(c2.PrivateKey) {
+				t.Errorf("component %d is different from the original", i)
+			}
+			if !reflect.DeepEqual(c2.Options, c.Options) {
+				t.Errorf("options of component %d are %+v; expected %+v",
+					i, c2.Options, c.Options)
+			}
+		}
+		if pub == nil && pub2 != nil || pub != nil && !pub.Equal(pub2) {
+			t.Errorf("expected public key %v but got %v", pub, pub2)
+		}
+		if len(extras) > 0 {
+			t.Errorf("no extras were expected, but got some: %+v", extras)
+		}
+		pkg2, err := Pack(priv2, pub2, extras...)
+		if err != nil {
+			t.Fatalf("cannot repack composite key pair: %v", err)
+		}
+		if !reflect.DeepEqual(pkg, pkg2) {
+			t.Errorf("repacked key package %+v is different from %+v",
+				pkg2, pkg)
+		}
+	}
+}
+
+func TestSigner(t *testing.T) {
+	priv := generateKey(t)
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	priv.Components = append(priv.Components, Component{PrivateKey: rsaKey})
+	encoded, err := akp.Encode(priv, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := akp.DecodeSigner(encoded)
+	if err != nil {
+		t.Fatalf("cannot decode signer: %v", err)
+	}
+	pub, err := Packer.DerivePublicKey(priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !pub.(*PublicKey).Equal(signer.Public()) {
+		t.Error("public key of signer is different from the derived one")
+	}
+	message := []byte("hello, world")
+	digest := sha256.Sum256(message)
+	pqPub := priv.Components[0].PrivateKey.(*mldsa65.PrivateKey).Public()
+	ecPub := &priv.Components[1].PrivateKey.(*ecdsa.PrivateKey).PublicKey
+	for _, test := range []struct {
+		name      string
+		opts      crypto.SignerOpts
+		verifyRSA func(sig []byte) error
+	}{
+		{"Default", crypto.Hash(0), func(sig []byte) error {
+			return rsa.VerifyPKCS1v15(
+				&rsaKey.PublicKey, crypto.SHA256, digest[:], sig)
+		}},
+		{"PSS", &SignerOpts{Components: []crypto.SignerOpts{
+			nil, nil, &rsa.PSSOptions{Hash: crypto.SHA256},
+		}}, func(sig []byte) error {
+			return rsa.VerifyPSS(
+				&rsaKey.PublicKey, crypto.SHA256, digest[:], sig, nil)
+		}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			sig, err := signer.Sign(rand.Reader, message, test.opts)
+			if err != nil {
+				t.Fatalf("cannot sign: %v", err)
+			}
+			sigs, err := ParseSignature(sig)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(sigs) != 3 {
+				t.Fatalf("signature has %d components; expected 3", len(sigs))
+			}
+			if !mldsa65.Verify(pqPub.(*mldsa65.PublicKey), message, nil,
+				sigs[0]) {
+				t.Error("cannot verify ML-DSA signature")
+			}
+			if !ecdsa.VerifyASN1(ecPub, digest[:], sigs[1]) {
+				t.Error("cannot verify ECDSA signature")
+			}
+			if err = test.verifyRSA(sigs[2]); err != nil {
+				t.Errorf("cannot verify RSA signature: %v", err)
+			}
+		})
+	}
+	if _, err = signer.Sign(rand.Reader, digest[:], crypto.SHA256); err == nil {
+		t.Error("Sign signed a digest")
+	}
+}
+
+func TestStatefulComponent(t *testing.T) {
+	priv := generateKey(t)
+	hssKey, err := lmskp.GenerateKey(rand.Reader, lmskp.Params{
+		LMS: lmskp.LMS_SHA256_M32_H5, OTS: lmskp.LMOTS_SHA256_N32_W4,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	priv.Components = append(priv.Components, Component{PrivateKey: hssKey})
+	if _, err = NewSigner(priv); !errors.Is(err, akp.ErrStatefulKey) {
+		t.Errorf("NewSigner returned %v; expected %v", err, akp.ErrStatefulKey)
+	}
+}
+
+func TestRestrictedComponent(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pssParams := rsakp.PSSParams{
+		Hash: crypto.SHA256, MGFHash: crypto.SHA256, SaltLength: 32,
+	}
+	restricted := func(restriction interface{}) *PrivateKey {
+		priv := generateKey(t)
+		priv.Components = append(priv.Components, Component{
+			PrivateKey: rsaKey, Options: []interface{}{restriction},
+		})
+		// The restriction comes back from the component key package.
+		pkg, err := Pack(priv, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		priv, _, _, err = Unpack(pkg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return priv
+	}
+
+	t.Run("PSS", func(t *testing.T) {
+		signer, err := NewSigner(restricted(pssParams))
+		if err != nil {
+			t.Fatal(err)
+		}
+		message := []byte("hello, world")
+		// The default options of RSA keys would sign with PKCS #1 v1.5.
+		_, err = signer.Sign(rand.Reader, message, crypto.Hash(0))
+		if err == nil {
+			t.Error("PSS component signed with PKCS #1 v1.5 options")
+		}
+		opts := &SignerOpts{Components: []crypto.SignerOpts{
+			nil, nil, pssParams.SignerOpts(),
+		}}
+		sig, err := signer.Sign(rand.Reader, message, opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sigs, err := ParseSignature(sig)
+		if err != nil {
+			t.Fatal(err)
+		}
+		digest := sha256.Sum256(message)
+		err = rsa.VerifyPSS(&rsaKey.PublicKey, crypto.SHA256, digest[:],
+			sigs[2], pssParams.SignerOpts())
+		if err != nil {
+			t.Errorf("cannot verify RSASSA-PSS component signature: %v", err)
+		}
+	})
+	t.Run("OAEP", func(t *testing.T) {
+		priv := restricted(rsakp.OAEPParams{Hash: crypto.SHA256})
+		if _, err := NewSigner(priv); !errors.Is(err, akp.ErrNotSigner) {
+			t.Errorf("NewSigner returned %v; expected %v",
+				err, akp.ErrNotSigner)
+		}
+		_, err := akp.Packers.Signer(priv)
+		if !errors.Is(err, akp.ErrNotSigner) {
+			t.Errorf("Signer returned %v; expected %v", err, akp.ErrNotSigner)
+		}
+	})
+}
+
+func TestUnpack(t *testing.T) {
+	priv := generateKey(t)
+	pkg, err := Pack(priv, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var components []akp.OneAsymmetricKey
+	if _, err = asn1.Unmarshal(pkg.PrivateKey, &components); err != nil {
+		t.Fatal(err)
+	}
+	t.Run("OneComponent", func(t *testing.T) {
+		pkg := *pkg
+		if pkg.PrivateKey, err = asn1.Marshal(components[:1]); err != nil {
+			t.Fatal(err)
+		}
+		if _, _, _, err = Unpack(&pkg); err == nil {
+			t.Error("Unpack accepted a single component")
+		}
+		priv := &PrivateKey{Components: priv.Components[:1]}
+		if _, err = Pack(priv, nil); err == nil {
+			t.Error("Pack packed a single component")
+		}
+	})
+	t.Run("BadComponent", func(t *testing.T) {
+		bad := append([]akp.OneAsymmetricKey(nil), components...)
+		bad[1].PrivateKey = bad[1].PrivateKey[1:]
+		pkg := *pkg
+		if pkg.PrivateKey, err = asn1.Marshal(bad); err != nil {
+			t.Fatal(err)
+		}
+		if _, _, _, err = Unpack(&pkg); err == nil {
+			t.Error("Unpack accepted a bad component")
+		}
+	})
+	t.Run("PublicKeyMismatch", func(t *testing.T) {
+		other, err := Packer.DerivePublicKey(generateKey(t))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = Pack(priv, other.(*PublicKey)); err == nil {
+			t.Error("Pack accepted a mismatching public key")
+		}
+		pkg := *pkg
+		pkg.PublicKey, _ = packPublicKey(other.(*PublicKey))
+		if _, _, _, err = Unpack(&pkg); err == nil {
+			t.Error("Unpack accepted a mismatching public key")
+		}
+	})
+	t.Run("WrongAlgorithm", func(t *testing.T) {
+		pkg := *pkg
+		pkg.PrivateKeyAlgorithm.Algorithm = asn1.ObjectIdentifier{1, 2, 3}
+		if _, _, _, err = Unpack(&pkg); err != ErrNotComposite {
+			t.Errorf("Unpack returned %v; expected %v", err, ErrNotComposite)
+		}
+	})
+}
+
+func TestCapabilities(t *testing.T) {
+	kemKey, err := mlkemkp.GenerateKey(mlkem768.Scheme())
+	if err != nil {
+		t.Fatal(err)
+	}
+	signing := generateKey(t)
+	mixed := &PrivateKey{Components: []Component{
+		signing.Components[0], {PrivateKey: kemKey},
+	}}
+	for _, test := range []struct {
+		name     string
+		priv     *PrivateKey
+		expected akp.Capability
+	}{
+		{"Signing", signing, akp.CanSign},
+		{"Mixed", mixed, 0},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			pkg, err := Pack(test.priv, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if c := pkg.Capabilities(); c != test.expected {
+				t.Errorf("capabilities are %v; expected %v", c, test.expected)
+			}
+		})
+	}
+}
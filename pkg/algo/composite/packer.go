@@ -0,0 +1,114 @@
+package compositekp
+
+import (
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+)
+
+type packer struct{}
+
+// Packer is the singleton packer instance.
+var Packer packer
+
+func (packer packer) Pack(
+	priv interface{}, pub interface{}, options ...interface{},
+) (pkg *akp.OneAsymmetricKey, err error) {
+	privKey, ok := priv.(*PrivateKey)
+	if !ok {
+		return nil, akp.ErrSkip
+	}
+	var pubKey *PublicKey
+	switch pub := pub.(type) {
+	case nil:
+	case *PublicKey:
+		pubKey = pub
+	default:
+		return nil, akp.ErrSkip
+	}
+	return Pack(privKey, pubKey, options...)
+}
+
+// Pack packs the given composite key pair into a key package,
+// packing each component with akp.Packers.
+//
+// The public key, if given, must match the private key.
+func Pack(
+	privKey *PrivateKey, pubKey *PublicKey, options ...interface{},
+) (pkg *akp.OneAsymmetricKey, err error) {
+	components, err := packComponents(privKey)
+	if err != nil {
+		return nil, err
+	}
+	pkg = &akp.OneAsymmetricKey{
+		Version:             akp.V1,
+		PrivateKeyAlgorithm: pkix.AlgorithmIdentifier{Algorithm: algorithmOID},
+	}
+	if pkg.PrivateKey, err = asn1.Marshal(components); err != nil {
+		return nil, err
+	}
+	if pubKey != nil {
+		expected, err := publicKey(components)
+		if err != nil {
+			return nil, err
+		}
+		if !pubKey.Equal(expected) {
+			return nil, errors.New(
+				"composite public key does not match private key")
+		}
+		if pkg.PublicKey, err = packPublicKey(pubKey); err != nil {
+			return nil, err
+		}
+		pkg.Version = akp.V2
+	}
+	return pkg, nil
+}
+
+// packComponents packs the components of a composite private key.
+func packComponents(privKey *PrivateKey) ([]akp.OneAsymmetricKey, error) {
+	if len(privKey.Components) < minComponents {
+		return nil, fmt.Errorf("composite key has %d components; "+
+			"expected at least %d", len(privKey.Components), minComponents)
+	}
+	components := make([]akp.OneAsymmetricKey, len(privKey.Components))
+	for i, c := range privKey.Components {
+		pkg, err := akp.Packers.Pack(c.PrivateKey, c.PublicKey, c.Options...)
+		if err != nil {
+			return nil, fmt.Errorf("cannot pack component %d: %v", i, err)
+		}
+		components[i] = *pkg
+	}
+	return components, nil
+}
+
+// publicKey returns the composite public key of the given components.
+func publicKey(components []akp.OneAsymmetricKey) (*PublicKey, error) {
+	pubKey := &PublicKey{
+		Components: make([]akp.SubjectPublicKeyInfo, len(components)),
+	}
+	for i := range components {
+		spki, err := components[i].PublicKeyInfo()
+		if err != nil {
+			return nil, fmt.Errorf(
+				"cannot get public key of component %d: %v", i, err)
+		}
+		pubKey.Components[i] = *spki
+	}
+	return pubKey, nil
+}
+
+func packPublicKey(pubKey *PublicKey) (asn1.BitString, error) {
+	if len(pubKey.Components) < minComponents {
+		return asn1.BitString{}, fmt.Errorf(
+			"composite public key has %d components; expected at least %d",
+			len(pubKey.Components), minComponents)
+	}
+	pubBytes, err := asn1.Marshal(pubKey.Components)
+	if err != nil {
+		return asn1.BitString{}, err
+	}
+	return asn1.BitString{Bytes: pubBytes, BitLength: 8 * len(pubBytes)}, nil
+}
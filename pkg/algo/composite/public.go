@@ -0,0 +1,32 @@
+package compositekp
+
+import (
+	"encoding/asn1"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+)
+
+// DerivePublicKey returns the public portion of a composite private key,
+// from the public keys of its components.
+func (packer packer) DerivePublicKey(priv interface{}) (
+	pub interface{}, err error,
+) {
+	privKey, ok := priv.(*PrivateKey)
+	if !ok {
+		return nil, akp.ErrSkip
+	}
+	components, err := packComponents(privKey)
+	if err != nil {
+		return nil, err
+	}
+	return publicKey(components)
+}
+
+// PackPublicKey packs a composite public key as a CompositePublicKey.
+func (packer packer) PackPublicKey(pub interface{}) (asn1.BitString, error) {
+	pubKey, ok := pub.(*PublicKey)
+	if !ok {
+		return asn1.BitString{}, akp.ErrSkip
+	}
+	return packPublicKey(pubKey)
+}
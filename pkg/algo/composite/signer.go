@@ -0,0 +1,174 @@
+package compositekp
+
+import (
+	"crypto"
+	"crypto/dsa"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+)
+
+// Signer signs with all the components of a composite private key.
+//
+// Like ed25519.PrivateKey, Sign takes the message, not a digest;
+// opts must be crypto.Hash(0) or *SignerOpts.
+// Signatures are CompositeSignatureValue structures
+// (draft-ounsworth-pq-composite-sigs),
+// i.e. a SEQUENCE OF BIT STRING with the signature of each component.
+type Signer struct {
+	signers []crypto.Signer
+	pub     *PublicKey
+}
+
+// NewSigner returns a signer for the given composite private key,
+// whose components must all be able to sign.
+//
+// Components whose key packages lack the akp.CanSign capability,
+// such as RSAES-OAEP keys, are rejected with akp.ErrNotSigner.
+// The signers of the other components honor the restrictions in their
+// Options, such as the parameters of RSASSA-PSS keys.
+// Stateful components, such as LMS or XMSS keys, are rejected with
+// akp.ErrStatefulKey: the composite key is saved as a whole,
+// so nothing would keep their indices from being used twice.
+func NewSigner(priv *PrivateKey) (*Signer, error) {
+	components, err := packComponents(priv)
+	if err != nil {
+		return nil, err
+	}
+	pub, err := publicKey(components)
+	if err != nil {
+		return nil, err
+	}
+	signer := &Signer{pub: pub}
+	for i, c := range priv.Components {
+		if _, ok := c.PrivateKey.(akp.StatefulKey); ok {
+			return nil, fmt.Errorf("component %d: %w", i, akp.ErrStatefulKey)
+		}
+		if components[i].Capabilities()&akp.CanSign == 0 {
+			return nil, fmt.Errorf("component %d: %w: %v key package", i,
+				akp.ErrNotSigner, components[i].PrivateKeyAlgorithm.Algorithm)
+		}
+		s, err := akp.Packers.RestrictedSigner(c.PrivateKey, c.Options)
+		if err != nil {
+			return nil, fmt.Errorf("component %d: %w", i, err)
+		}
+		signer.signers = append(signer.signers, s)
+	}
+	return signer, nil
+}
+
+// Public returns the composite public key.
+func (signer *Signer) Public() crypto.PublicKey {
+	return signer.pub
+}
+
+// SignerOpts are the options of a composite signature.
+type SignerOpts struct {
+	// Components are the options of the components, in order.
+	// A component signs the digest of the message with the hash function
+	// of its options, or the message itself if that is zero.
+	//
+	// Missing or nil options select the default of the component:
+	// SHA-256 for RSA (PKCS #1 v1.5) and DSA keys,
+	// SHA-256, SHA-384 or SHA-512 for ECDSA keys, depending on the curve
+	// size, and the message itself for other keys.
+	// RSA keys restricted to RSASSA-PSS have no default:
+	// their options must be *rsa.PSSOptions.
+	Components []crypto.SignerOpts
+}
+
+// HashFunc returns 0, as composite signers sign messages.
+func (opts *SignerOpts) HashFunc() crypto.Hash {
+	return 0
+}
+
+// Sign signs the message with each component.
+func (signer *Signer) Sign(
+	rand io.Reader, message []byte, opts crypto.SignerOpts,
+) ([]byte, error) {
+	if opts.HashFunc() != 0 {
+		return nil, errors.New("composite keys sign messages; " +
+			"opts must be crypto.Hash(0) or *SignerOpts")
+	}
+	var components []crypto.SignerOpts
+	if compositeOpts, ok := opts.(*SignerOpts); ok {
+		components = compositeOpts.Components
+	}
+	sigs := make([]asn1.BitString, len(signer.signers))
+	for i, s := range signer.signers {
+		var opts crypto.SignerOpts
+		if i < len(components) {
+			opts = components[i]
+		}
+		if opts == nil {
+			opts = defaultOpts(s.Public())
+		}
+		signed := message
+		if hash := opts.HashFunc(); hash != 0 {
+			if !hash.Available() {
+				return nil, fmt.Errorf("component %d: hash %v is unavailable",
+					i, hash)
+			}
+			h := hash.New()
+			h.Write(message)
+			signed = h.Sum(nil)
+		}
+		sig, err := s.Sign(rand, signed, opts)
+		if err != nil {
+			return nil, fmt.Errorf("component %d: %v", i, err)
+		}
+		sigs[i] = asn1.BitString{Bytes: sig, BitLength: 8 * len(sig)}
+	}
+	return asn1.Marshal(sigs)
+}
+
+// defaultOpts returns the default options of a component.
+func defaultOpts(pub crypto.PublicKey) crypto.SignerOpts {
+	switch pub := pub.(type) {
+	case *rsa.PublicKey, *dsa.PublicKey:
+		return crypto.SHA256
+	case *ecdsa.PublicKey:
+		switch size := pub.Curve.Params().BitSize; {
+		case size > 384:
+			return crypto.SHA512
+		case size > 256:
+			return crypto.SHA384
+		}
+		return crypto.SHA256
+	}
+	return crypto.Hash(0)
+}
+
+// ParseSignature returns the component signatures of a composite signature.
+func ParseSignature(sig []byte) ([][]byte, error) {
+	var sigs []asn1.BitString
+	rest, err := asn1.Unmarshal(sig, &sigs)
+	if err == nil && len(rest) > 0 {
+		err = errors.New("trailing data")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot unmarshal composite signature: %v", err)
+	}
+	components := make([][]byte, len(sigs))
+	for i, s := range sigs {
+		if s.BitLength%8 != 0 {
+			return nil, errors.New("component signature has partial bytes")
+		}
+		components[i] = s.Bytes
+	}
+	return components, nil
+}
+
+// Signer adapts a composite private key to crypto.Signer.
+func (packer packer) Signer(priv interface{}) (crypto.Signer, error) {
+	privKey, ok := priv.(*PrivateKey)
+	if !ok {
+		return nil, akp.ErrSkip
+	}
+	return NewSigner(privKey)
+}
@@ -0,0 +1,114 @@
+package compositekp
+
+import (
+	"encoding/asn1"
+	"errors"
+	"fmt"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+)
+
+type unpacker struct{}
+
+// Unpacker is the singleton composite key unpacker instance.
+var Unpacker unpacker
+
+// Unpack unpacks a composite private key, unpacking each component with
+// akp.Unpackers.
+//
+// The public key, if present, must match the public keys of the components.
+func (unpacker unpacker) Unpack(pkg *akp.OneAsymmetricKey) (
+	priv interface{}, pub interface{}, extras []interface{}, err error,
+) {
+	if !pkg.PrivateKeyAlgorithm.Algorithm.Equal(algorithmOID) {
+		return nil, nil, nil, akp.ErrSkip
+	}
+	if len(pkg.PrivateKeyAlgorithm.Parameters.FullBytes) > 0 {
+		return nil, nil, nil, errors.New("composite key has parameters")
+	}
+	components, err := unmarshalComponents(pkg.PrivateKey)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	privKey := &PrivateKey{Components: make([]Component, len(components))}
+	for i := range components {
+		c := &privKey.Components[i]
+		c.PrivateKey, c.PublicKey, c.Options, err = akp.Unpackers.Unpack(
+			&components[i])
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf(
+				"cannot unpack component %d: %v", i, err)
+		}
+	}
+	if pkg.PublicKey.Bytes == nil {
+		return privKey, nil, nil, nil
+	}
+	pubKey := &PublicKey{}
+	rest, err := asn1.Unmarshal(pkg.PublicKey.RightAlign(), &pubKey.Components)
+	if err == nil && len(rest) > 0 {
+		err = errors.New("trailing data")
+	}
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf(
+			"cannot unmarshal public key: %v", err)
+	}
+	expected, err := publicKey(components)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if !pubKey.Equal(expected) {
+		return nil, nil, nil, errors.New(
+			"composite public key does not match private key")
+	}
+	return privKey, pubKey, nil, nil
+}
+
+// unmarshalComponents unmarshals a CompositePrivateKey.
+func unmarshalComponents(der []byte) ([]akp.OneAsymmetricKey, error) {
+	var components []akp.OneAsymmetricKey
+	rest, err := asn1.Unmarshal(der, &components)
+	if err == nil && len(rest) > 0 {
+		err = errors.New("trailing data")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot unmarshal private key: %v", err)
+	}
+	if len(components) < minComponents {
+		return nil, fmt.Errorf("composite key has %d components; "+
+			"expected at least %d", len(components), minComponents)
+	}
+	return components, nil
+}
+
+// ErrNotComposite means the unpacked key is not a composite key.
+var ErrNotComposite = errors.New("not a composite key")
+
+// Unpack unpacks a key package into a composite key pair.
+func Unpack(pkg *akp.OneAsymmetricKey) (
+	priv *PrivateKey, pub *PublicKey, extras []interface{}, err error,
+) {
+	priv, pub, extras, err = akp.UnpackWith[*PrivateKey, *PublicKey](
+		Unpacker, pkg)
+	var mismatch *akp.TypeMismatchError
+	if errors.As(err, &mismatch) || err == akp.ErrSkip {
+		return nil, nil, nil, ErrNotComposite
+	}
+	return priv, pub, extras, err
+}
+
+// Capabilities reports that composite keys can sign if all their components
+// can.
+func (unpacker unpacker) Capabilities(
+	pkg *akp.OneAsymmetricKey,
+) akp.Capability {
+	components, err := unmarshalComponents(pkg.PrivateKey)
+	if err != nil {
+		return 0
+	}
+	for i := range components {
+		if components[i].Capabilities()&akp.CanSign == 0 {
+			return 0
+		}
+	}
+	return akp.CanSign
+}
@@ -0,0 +1,46 @@
+package dhkp
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+)
+
+// SharedSecret computes the Diffie-Hellman shared secret with a peer's
+// public key in the same group, as a big-endian octet string of the length
+// of P (RFC 7919, section 5.1).
+//
+// The peer's public value must be in the range [2, P - 2], and, if Q is
+// known, in the subgroup of order Q.
+func (priv *PrivateKey) SharedSecret(peer *PublicKey) ([]byte, error) {
+	p := priv.P
+	if p == nil || priv.X == nil {
+		return nil, errors.New("DH private key is incomplete")
+	}
+	if peer.P == nil || peer.G == nil ||
+		peer.P.Cmp(p) != 0 || peer.G.Cmp(priv.G) != 0 {
+		return nil, errors.New("DH public key is in a different group")
+	}
+	pMinus1 := new(big.Int).Sub(p, one)
+	if peer.Y == nil || peer.Y.Cmp(one) <= 0 || peer.Y.Cmp(pMinus1) >= 0 {
+		return nil, errors.New("DH public value out of range")
+	}
+	q := priv.Q
+	if q == nil {
+		q = peer.Q
+	}
+	if q != nil && new(big.Int).Exp(peer.Y, q, p).Cmp(one) != 0 {
+		return nil, errors.New("DH public value is not in the subgroup")
+	}
+	z := new(big.Int).Exp(peer.Y, priv.X, p)
+	return z.FillBytes(make([]byte, (p.BitLen()+7)/8)), nil
+}
+
+// Capabilities reports that Diffie-Hellman keys can agree on shared
+// secrets.
+func (unpacker unpacker) Capabilities(
+	pkg *akp.OneAsymmetricKey,
+) akp.Capability {
+	return akp.CanAgree
+}
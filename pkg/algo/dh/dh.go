@@ -0,0 +1,99 @@
+// Package dhkp implements finite field Diffie-Hellman key agreement keys,
+// both PKCS #3 (dhKeyAgreement) and ANSI X9.42 (dhpublicnumber, RFC 3279,
+// section 2.3.3) keys.
+//
+// As with DSA keys, the group parameters are in the AlgorithmIdentifier,
+// and the private and public values are INTEGERs.
+package dhkp
+
+import (
+	"encoding/asn1"
+	"math/big"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+)
+
+var (
+	// dhKeyAgreement in PKCS #3
+	pkcs3OID = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 3, 1}
+
+	// dhpublicnumber in RFC 3279
+	x942OID = asn1.ObjectIdentifier{1, 2, 840, 10046, 2, 1}
+)
+
+func init() {
+	akp.Packers.Register(Packer, &PrivateKey{})
+	akp.Unpackers.Register(Unpacker, pkcs3OID, x942OID)
+}
+
+// Parameters are the parameters of a Diffie-Hellman group.
+type Parameters struct {
+	P, G *big.Int
+
+	// Q is the order of the subgroup generated by G.
+	// X9.42 requires it; PKCS #3 does not carry it.
+	Q *big.Int
+
+	// J is the optional X9.42 subgroup factor, (P - 1) / Q.
+	J *big.Int
+
+	// Validation holds the optional X9.42 parameter generation seed.
+	Validation *ValidationParams
+
+	// PrivateValueLength is the optional PKCS #3 length in bits of the
+	// private value, or 0.
+	PrivateValueLength int
+}
+
+// ValidationParams are the X9.42 parameter validation parameters.
+type ValidationParams struct {
+	Seed        asn1.BitString
+	PgenCounter int
+}
+
+// PublicKey is a Diffie-Hellman public key.
+type PublicKey struct {
+	Parameters
+	Y *big.Int
+}
+
+// PrivateKey is a Diffie-Hellman private key.
+type PrivateKey struct {
+	PublicKey
+	X *big.Int
+}
+
+// Format is the key package encoding of a Diffie-Hellman key,
+// given as a Pack option.
+//
+// Without this option, keys with Q are packed as X9.42 keys,
+// and other keys as PKCS #3 keys.
+type Format int
+
+// Key package encodings
+const (
+	PKCS3 Format = iota + 1 // dhKeyAgreement
+	X942                    // dhpublicnumber
+)
+
+// DHParameter in PKCS #3.
+type asn1DHParameter struct {
+	Prime              *big.Int
+	Base               *big.Int
+	PrivateValueLength int `asn1:"optional"`
+}
+
+// DomainParameters in RFC 3279.  Keep the same field order as in RFC 3279.
+type asn1DomainParameters struct {
+	P               *big.Int
+	G               *big.Int
+	Q               *big.Int
+	J               *big.Int            `asn1:"optional"`
+	ValidationParms asn1ValidationParms `asn1:"optional"`
+}
+
+// ValidationParms in RFC 3279.
+type asn1ValidationParms struct {
+	Seed        asn1.BitString
+	PgenCounter int
+}
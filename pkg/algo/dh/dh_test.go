@@ -0,0 +1,163 @@
+package dhkp
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/asn1"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+)
+
+// The testdata files were generated by OpenSSL 3.0:
+//
+//	openssl genpkey -genparam -algorithm DH -pkeyopt group:ffdhe2048 \
+//		-out ffdhe2048-params.pem
+//	openssl genpkey -paramfile ffdhe2048-params.pem -out ffdhe2048.pem
+//	openssl genpkey -paramfile ffdhe2048-params.pem -out peer.pem
+//	openssl pkey -in peer.pem -pubout -out peer_pub.pem
+//	openssl pkey -in ffdhe2048.pem -pubout -out ffdhe2048_pub.pem
+//	openssl pkeyutl -derive -inkey ffdhe2048.pem -peerkey peer_pub.pem \
+//		-out shared.bin
+//	openssl genpkey -genparam -algorithm DHX \
+//		-pkeyopt dh_paramgen_prime_len:1024 \
+//		-pkeyopt dh_paramgen_subprime_len:160 -out x942-params.pem
+//	openssl genpkey -paramfile x942-params.pem -out x942.pem
+//	openssl pkey -in x942.pem -pubout -out x942_pub.pem
+
+func readPEM(t *testing.T, filename string) []byte {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		t.Fatalf("%s: no PEM block", filename)
+	}
+	return block.Bytes
+}
+
+func readPackage(t *testing.T, filename string) *akp.OneAsymmetricKey {
+	var pkg akp.OneAsymmetricKey
+	if _, err := asn1.Unmarshal(readPEM(t, filename), &pkg); err != nil {
+		t.Fatal(err)
+	}
+	return &pkg
+}
+
+func generateKey(t *testing.T, params *Parameters) *PrivateKey {
+	x, err := rand.Int(rand.Reader, new(big.Int).Sub(params.Q, one))
+	if err != nil {
+		t.Fatal(err)
+	}
+	priv := &PrivateKey{X: x.Add(x, one)}
+	priv.Parameters = *params
+	priv.Y = new(big.Int).Exp(params.G, priv.X, params.P)
+	return priv
+}
+
+func TestRoundTrip(t *testing.T) {
+	priv := generateKey(t, FFDHE2048)
+	subtest := func(t *testing.T, pub interface{}, options ...interface{}) {
+		pkg, err := Packer.Pack(priv, pub, options...)
+		if err != nil {
+			t.Fatalf("cannot pack DH key pair: %v", err)
+		}
+		priv2, pub2, extras, err := Unpack(pkg)
+		if err != nil {
+			t.Fatalf("cannot unpack DH key pair: %v", err)
+		}
+		expected := *priv
+		if len(options) > 0 && options[0] == PKCS3 {
+			expected.Q = nil
+		}
+		if !reflect.DeepEqual(&expected, priv2) {
+			t.Errorf("reconstructed key %+v is different from the original %+v",
+				priv2, &expected)
+		}
+		if pub == nil && pub2 != nil ||
+			pub != nil && !reflect.DeepEqual(&expected.PublicKey, pub2) {
+			t.Errorf("expected public key %+v but got %+v", pub, pub2)
+		}
+		if len(extras) > 0 {
+			t.Errorf("no extras were expected, but got some: %+v", extras)
+		}
+	}
+	t.Run("WithoutPublic", func(t *testing.T) { subtest(t, nil) })
+	t.Run("WithPublic", func(t *testing.T) { subtest(t, &priv.PublicKey) })
+	t.Run("PKCS3", func(t *testing.T) { subtest(t, &priv.PublicKey, PKCS3) })
+}
+
+func TestOpenSSL(t *testing.T) {
+	for _, name := range []string{"ffdhe2048", "x942"} {
+		t.Run(name, func(t *testing.T) {
+			der := readPEM(t, "testdata/"+name+".pem")
+			pkg := readPackage(t, "testdata/"+name+".pem")
+			priv, _, _, err := Unpack(pkg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if pkg.Capabilities() != akp.CanAgree {
+				t.Errorf("capabilities are %v", pkg.Capabilities())
+			}
+			if name == "ffdhe2048" && priv.GroupName() != name {
+				t.Errorf("group name is %q; expected %q",
+					priv.GroupName(), name)
+			}
+			// The key package must round-trip byte for byte.
+			encoded, err := akp.Encode(priv, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(encoded, der) {
+				t.Errorf("re-encoded key package is %x; expected %x",
+					encoded, der)
+			}
+			spki, err := pkg.MarshalPublicKeyInfo()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if expected := readPEM(t, "testdata/"+name+"_pub.pem"); !bytes.Equal(
+				spki, expected) {
+				t.Errorf("SubjectPublicKeyInfo is %x; expected %x",
+					spki, expected)
+			}
+		})
+	}
+}
+
+func TestSharedSecret(t *testing.T) {
+	priv, _, _, err := Unpack(readPackage(t, "testdata/ffdhe2048.pem"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var spki akp.SubjectPublicKeyInfo
+	if _, err = asn1.Unmarshal(readPEM(t, "testdata/peer_pub.pem"),
+		&spki); err != nil {
+		t.Fatal(err)
+	}
+	peer := &PublicKey{Parameters: priv.Parameters}
+	if _, err = asn1.Unmarshal(spki.PublicKey.Bytes, &peer.Y); err != nil {
+		t.Fatal(err)
+	}
+	secret, err := priv.SharedSecret(peer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected, err := os.ReadFile("testdata/shared.bin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(secret, expected) {
+		t.Errorf("shared secret is %x; expected %x", secret, expected)
+	}
+
+	peer.Y = new(big.Int).Sub(priv.P, one)
+	if _, err = priv.SharedSecret(peer); err == nil {
+		t.Error("public value P - 1 was accepted")
+	}
+}
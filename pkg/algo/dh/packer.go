@@ -0,0 +1,110 @@
+package dhkp
+
+import (
+	"encoding/asn1"
+	"errors"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+)
+
+type packer struct{}
+
+// Packer is the singleton packer instance.
+var Packer packer
+
+func (packer packer) Pack(
+	priv interface{}, pub interface{}, options ...interface{},
+) (pkg *akp.OneAsymmetricKey, err error) {
+	var (
+		privKey *PrivateKey
+		pubKey  *PublicKey
+		ok      bool
+	)
+	if privKey, ok = priv.(*PrivateKey); !ok {
+		return nil, akp.ErrSkip
+	}
+	if pub == nil {
+		return Pack(privKey, nil, options...)
+	}
+	if pubKey, ok = pub.(*PublicKey); !ok {
+		return nil, akp.ErrSkip
+	}
+	return Pack(privKey, pubKey, options...)
+}
+
+// Pack packs the given Diffie-Hellman key pair into a key package.
+//
+// A Format option selects the encoding; see Format for the default.
+func Pack(
+	privKey *PrivateKey, pubKey *PublicKey, options ...interface{},
+) (pkg *akp.OneAsymmetricKey, err error) {
+	params := &privKey.Parameters
+	if params.P == nil || params.G == nil {
+		return nil, errors.New("DH parameters are missing")
+	}
+	if privKey.X == nil {
+		return nil, errors.New("DH private value is missing")
+	}
+	format := PKCS3
+	if params.Q != nil {
+		format = X942
+	}
+	for _, option := range options {
+		if f, ok := option.(Format); ok {
+			format = f
+		}
+	}
+	pkg = &akp.OneAsymmetricKey{Version: akp.V1}
+	var paramsBytes []byte
+	switch format {
+	case PKCS3:
+		pkg.PrivateKeyAlgorithm.Algorithm = pkcs3OID
+		paramsBytes, err = asn1.Marshal(asn1DHParameter{
+			Prime: params.P, Base: params.G,
+			PrivateValueLength: params.PrivateValueLength,
+		})
+	case X942:
+		if params.Q == nil {
+			return nil, errors.New("X9.42 DH parameters require Q")
+		}
+		pkg.PrivateKeyAlgorithm.Algorithm = x942OID
+		domainParams := asn1DomainParameters{
+			P: params.P, G: params.G, Q: params.Q, J: params.J,
+		}
+		if v := params.Validation; v != nil {
+			domainParams.ValidationParms = asn1ValidationParms{
+				Seed: v.Seed, PgenCounter: v.PgenCounter,
+			}
+		}
+		paramsBytes, err = asn1.Marshal(domainParams)
+	default:
+		return nil, errors.New("unknown DH key package format")
+	}
+	if err != nil {
+		return nil, err
+	}
+	pkg.PrivateKeyAlgorithm.Parameters = asn1.RawValue{FullBytes: paramsBytes}
+	// The private value is an INTEGER, as for DSA.
+	if pkg.PrivateKey, err = asn1.Marshal(privKey.X); err != nil {
+		return nil, err
+	}
+	if pubKey != nil {
+		if pkg.PublicKey, err = packPublicKey(pubKey); err != nil {
+			return nil, err
+		}
+		pkg.Version = akp.V2
+	}
+	return pkg, nil
+}
+
+func packPublicKey(pubKey *PublicKey) (asn1.BitString, error) {
+	// RFC 3279, section 2.3.3: DHPublicKey ::= INTEGER
+	pubBytes, err := asn1.Marshal(pubKey.Y)
+	if err != nil {
+		return asn1.BitString{}, err
+	}
+	return asn1.BitString{
+		Bytes:     pubBytes,
+		BitLength: 8 * len(pubBytes),
+	}, nil
+}
@@ -0,0 +1,40 @@
+package dhkp
+
+import (
+	"encoding/asn1"
+	"errors"
+	"math/big"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+)
+
+// DerivePublicKey returns the public portion of a Diffie-Hellman private
+// key, computing the public value if necessary.
+func (packer packer) DerivePublicKey(priv interface{}) (
+	pub interface{}, err error,
+) {
+	privKey, ok := priv.(*PrivateKey)
+	if !ok {
+		return nil, akp.ErrSkip
+	}
+	if privKey.Y != nil {
+		return &privKey.PublicKey, nil
+	}
+	params := &privKey.Parameters
+	if params.P == nil || params.G == nil || privKey.X == nil {
+		return nil, errors.New("DH private key is incomplete")
+	}
+	pubKey := &PublicKey{Parameters: *params}
+	pubKey.Y = new(big.Int).Exp(params.G, privKey.X, params.P)
+	return pubKey, nil
+}
+
+// PackPublicKey packs a Diffie-Hellman public key as an INTEGER
+// (RFC 3279).
+func (packer packer) PackPublicKey(pub interface{}) (asn1.BitString, error) {
+	pubKey, ok := pub.(*PublicKey)
+	if !ok {
+		return asn1.BitString{}, akp.ErrSkip
+	}
+	return packPublicKey(pubKey)
+}
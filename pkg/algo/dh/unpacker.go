@@ -0,0 +1,124 @@
+package dhkp
+
+import (
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+)
+
+type unpacker struct{}
+
+// Unpacker is the singleton Diffie-Hellman unpacker instance.
+var Unpacker unpacker
+
+var one = big.NewInt(1)
+
+// Unpack unpacks a Diffie-Hellman private key,
+// and derives the public value from it.
+//
+// If the key package contains the public key, it must match.
+func (unpacker unpacker) Unpack(pkg *akp.OneAsymmetricKey) (
+	priv interface{}, pub interface{}, extras []interface{}, err error,
+) {
+	var privKey PrivateKey
+	if err = unmarshalParameters(pkg, &privKey.Parameters); err != nil {
+		return nil, nil, nil, err
+	}
+	params := &privKey.Parameters
+	rest, err := asn1.Unmarshal(pkg.PrivateKey, &privKey.X)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf(
+			"cannot unmarshal private key: %v", err)
+	}
+	if len(rest) > 0 {
+		return nil, nil, nil, errors.New("extra data after DH private key")
+	}
+	limit := new(big.Int).Sub(params.P, one)
+	if params.Q != nil {
+		limit = params.Q
+	}
+	if privKey.X.Sign() <= 0 || privKey.X.Cmp(limit) >= 0 {
+		return nil, nil, nil, errors.New("DH private value out of range")
+	}
+	privKey.Y = new(big.Int).Exp(params.G, privKey.X, params.P)
+	if pkg.PublicKey.Bytes == nil {
+		return &privKey, nil, nil, nil
+	}
+	pubKey := PublicKey{Parameters: privKey.Parameters}
+	rest, err = asn1.Unmarshal(pkg.PublicKey.Bytes, &pubKey.Y)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf(
+			"cannot unmarshal public key: %v", err)
+	}
+	if len(rest) > 0 {
+		return nil, nil, nil, errors.New("extra data after DH public key")
+	}
+	if pubKey.Y.Cmp(privKey.Y) != 0 {
+		return nil, nil, nil, errors.New(
+			"DH public key does not match private key")
+	}
+	return &privKey, &pubKey, nil, nil
+}
+
+func unmarshalParameters(pkg *akp.OneAsymmetricKey, params *Parameters) error {
+	paramsBytes := pkg.PrivateKeyAlgorithm.Parameters.FullBytes
+	var rest []byte
+	var err error
+	switch alg := pkg.PrivateKeyAlgorithm.Algorithm; {
+	case alg.Equal(pkcs3OID):
+		var dhParams asn1DHParameter
+		if rest, err = asn1.Unmarshal(paramsBytes, &dhParams); err != nil {
+			break
+		}
+		params.P, params.G = dhParams.Prime, dhParams.Base
+		params.PrivateValueLength = dhParams.PrivateValueLength
+	case alg.Equal(x942OID):
+		var domainParams asn1DomainParameters
+		if rest, err = asn1.Unmarshal(paramsBytes, &domainParams); err != nil {
+			break
+		}
+		params.P, params.G = domainParams.P, domainParams.G
+		params.Q, params.J = domainParams.Q, domainParams.J
+		if v := domainParams.ValidationParms; v.Seed.BitLength > 0 {
+			params.Validation = &ValidationParams{
+				Seed: v.Seed, PgenCounter: v.PgenCounter,
+			}
+		}
+	default:
+		return akp.ErrSkip
+	}
+	if err != nil {
+		return fmt.Errorf("cannot unmarshal DH parameters: %v", err)
+	}
+	if len(rest) > 0 {
+		return errors.New("extra data after DH parameters")
+	}
+	if params.P.Cmp(big.NewInt(3)) < 0 || params.G.Cmp(one) <= 0 ||
+		params.G.Cmp(params.P) >= 0 {
+		return errors.New("invalid DH parameters")
+	}
+	if params.Q != nil &&
+		(params.Q.Cmp(one) <= 0 || params.Q.Cmp(params.P) >= 0) {
+		return errors.New("invalid DH subgroup order")
+	}
+	return nil
+}
+
+// ErrNotDH means the unpacked key is not a Diffie-Hellman key.
+var ErrNotDH = errors.New("not a DH key")
+
+// Unpack unpacks a key package into a Diffie-Hellman key pair.
+func Unpack(pkg *akp.OneAsymmetricKey) (
+	priv *PrivateKey, pub *PublicKey, extras []interface{}, err error,
+) {
+	priv, pub, extras, err = akp.UnpackWith[*PrivateKey, *PublicKey](
+		Unpacker, pkg)
+	var mismatch *akp.TypeMismatchError
+	if errors.As(err, &mismatch) || err == akp.ErrSkip {
+		return nil, nil, nil, ErrNotDH
+	}
+	return priv, pub, extras, err
+}
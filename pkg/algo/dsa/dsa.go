@@ -5,6 +5,7 @@ package dsakp
 import (
 	"crypto/dsa"
 	"encoding/asn1"
+	"errors"
 	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
 	"math/big"
 )
@@ -24,3 +25,7 @@ type asn1DssParms struct {
 	Q *big.Int
 	G *big.Int
 }
+
+// errMissingParameters means a DSA key lacks its parameters (P, Q, G),
+// which the key package may omit.
+var errMissingParameters = errors.New("DSA parameters are missing")
@@ -1,10 +1,15 @@
 package dsakp
 
 import (
+	"crypto"
 	"crypto/dsa"
 	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
 	"encoding/asn1"
 	"encoding/base64"
+	"encoding/pem"
+	"errors"
 	"fmt"
 	"reflect"
 	"testing"
@@ -94,3 +99,91 @@ func TestPacker_Pack(t *testing.T) {
 		}
 	})
 }
+
+func TestFingerprint(t *testing.T) {
+	priv := generateKey(t)
+	withPub, err := Pack(priv, &priv.PublicKey)
+	if err != nil {
+		t.Fatalf("cannot pack DSA key pair: %v", err)
+	}
+	withoutPub, err := Pack(priv, nil)
+	if err != nil {
+		t.Fatalf("cannot pack DSA key pair: %v", err)
+	}
+	fp1, err := withPub.Fingerprint()
+	if err != nil {
+		t.Fatalf("cannot fingerprint key package: %v", err)
+	}
+	fp2, err := withoutPub.Fingerprint()
+	if err != nil {
+		t.Fatalf("cannot fingerprint key package: %v", err)
+	}
+	if !reflect.DeepEqual(fp1, fp2) {
+		t.Errorf("fingerprints differ: %+v vs. %+v", fp1, fp2)
+	}
+	if fp1.SSHSHA256 == "" {
+		t.Errorf("missing SSH fingerprint")
+	}
+	if fp1.JWKThumbprint != nil {
+		t.Errorf("DSA keys have no JWK thumbprint, but got %x", fp1.JWKThumbprint)
+	}
+}
+
+func TestPublicKeyInfo(t *testing.T) {
+	priv := generateKey(t)
+	pkg, err := Pack(priv, nil)
+	if err != nil {
+		t.Fatalf("cannot pack DSA key pair: %v", err)
+	}
+	encoded, err := pkg.MarshalPublicKeyInfoPEM()
+	if err != nil {
+		t.Fatalf("cannot marshal SubjectPublicKeyInfo: %v", err)
+	}
+	block, _ := pem.Decode(encoded)
+	if block == nil || block.Type != "PUBLIC KEY" {
+		t.Fatalf("unexpected PEM encoding %q", encoded)
+	}
+	pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		t.Fatalf("cannot parse SubjectPublicKeyInfo: %v", err)
+	}
+	if !reflect.DeepEqual(pub, &priv.PublicKey) {
+		t.Errorf("expected public key %+v but got %+v", &priv.PublicKey, pub)
+	}
+}
+
+func TestSigner(t *testing.T) {
+	priv := generateKey(t)
+	encoded, err := akp.Encode(priv, nil)
+	if err != nil {
+		t.Fatalf("cannot encode DSA key pair: %v", err)
+	}
+	signer, err := akp.DecodeSigner(encoded)
+	if err != nil {
+		t.Fatalf("cannot decode signer: %v", err)
+	}
+	digest := sha256.Sum256([]byte("hello"))
+	sig, err := signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+	if err != nil {
+		t.Fatalf("cannot sign: %v", err)
+	}
+	var value asn1DssSigValue
+	if _, err = asn1.Unmarshal(sig, &value); err != nil {
+		t.Fatalf("cannot unmarshal signature: %v", err)
+	}
+	if !dsa.Verify(&priv.PublicKey, digest[:20], value.R, value.S) {
+		t.Errorf("signature does not verify")
+	}
+	_, err = akp.DecodeDecrypter(encoded)
+	if !errors.Is(err, akp.ErrNotDecrypter) {
+		t.Errorf("DecodeDecrypter returned %+v; expected %+v",
+			err, akp.ErrNotDecrypter)
+	}
+	pkg, err := Pack(priv, nil)
+	if err != nil {
+		t.Fatalf("cannot pack DSA key pair: %v", err)
+	}
+	if c := pkg.Capabilities(); c != akp.CanSign {
+		t.Errorf("capabilities are %v; expected %v", c, akp.CanSign)
+	}
+}
@@ -24,12 +24,12 @@ func (packer packer) Pack(
 		return nil, akp.ErrSkip
 	}
 	if pub == nil {
-		return Pack(privKey, nil, options)
+		return Pack(privKey, nil, options...)
 	}
 	if pubKey, ok = pub.(*dsa.PublicKey); !ok {
 		return nil, akp.ErrSkip
 	}
-	return Pack(privKey, pubKey, options)
+	return Pack(privKey, pubKey, options...)
 }
 
 // Pack packs the given RSA key pair into a key package.
@@ -59,16 +59,22 @@ func Pack(
 		return nil, err
 	}
 	if pubKey != nil {
-		// RFC 5958, section 2: “a DSA key is an INTEGER” (public).
-		pubBytes, err := asn1.Marshal(pubKey.Y)
-		if err != nil {
+		if pkg.PublicKey, err = packPublicKey(pubKey); err != nil {
 			return nil, err
 		}
-		pkg.PublicKey = asn1.BitString{
-			Bytes:     pubBytes,
-			BitLength: 8 * len(pubBytes),
-		}
 		pkg.Version = akp.V2
 	}
 	return pkg, nil
 }
+
+func packPublicKey(pubKey *dsa.PublicKey) (asn1.BitString, error) {
+	// RFC 5958, section 2: “a DSA key is an INTEGER” (public).
+	pubBytes, err := asn1.Marshal(pubKey.Y)
+	if err != nil {
+		return asn1.BitString{}, err
+	}
+	return asn1.BitString{
+		Bytes:     pubBytes,
+		BitLength: 8 * len(pubBytes),
+	}, nil
+}
@@ -0,0 +1,35 @@
+package dsakp
+
+import (
+	"crypto/dsa"
+	"encoding/asn1"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+)
+
+// DerivePublicKey returns the public portion of a DSA private key.
+//
+// The public key is embedded in the private key;
+// DerivePublicKey fails if it has not been computed,
+// which is the case if the key package had no DSA parameters.
+func (packer packer) DerivePublicKey(priv interface{}) (
+	pub interface{}, err error,
+) {
+	privKey, ok := priv.(*dsa.PrivateKey)
+	if !ok {
+		return nil, akp.ErrSkip
+	}
+	if privKey.Y == nil {
+		return nil, errMissingParameters
+	}
+	return &privKey.PublicKey, nil
+}
+
+// PackPublicKey packs a DSA public key as an INTEGER (RFC 3279).
+func (packer packer) PackPublicKey(pub interface{}) (asn1.BitString, error) {
+	pubKey, ok := pub.(*dsa.PublicKey)
+	if !ok {
+		return asn1.BitString{}, akp.ErrSkip
+	}
+	return packPublicKey(pubKey)
+}
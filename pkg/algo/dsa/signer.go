@@ -0,0 +1,63 @@
+package dsakp
+
+import (
+	"crypto"
+	"crypto/dsa"
+	"encoding/asn1"
+	"io"
+	"math/big"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+)
+
+// Signer adapts a DSA private key to crypto.Signer.
+//
+// Signatures are Dss-Sig-Value (RFC 3279, section 2.2.2) structures.
+type Signer struct {
+	*dsa.PrivateKey
+}
+
+// Public returns the DSA public key.
+func (signer Signer) Public() crypto.PublicKey {
+	return &signer.PublicKey
+}
+
+// Sign signs the given digest, truncated to the bit length of Q.
+// opts is ignored.
+func (signer Signer) Sign(
+	rand io.Reader, digest []byte, opts crypto.SignerOpts,
+) ([]byte, error) {
+	if signer.Q == nil {
+		return nil, errMissingParameters
+	}
+	if n := (signer.Q.BitLen() + 7) / 8; len(digest) > n {
+		digest = digest[:n]
+	}
+	r, s, err := dsa.Sign(rand, signer.PrivateKey, digest)
+	if err != nil {
+		return nil, err
+	}
+	return asn1.Marshal(asn1DssSigValue{R: r, S: s})
+}
+
+// Dss-Sig-Value in RFC 3279.
+type asn1DssSigValue struct {
+	R *big.Int
+	S *big.Int
+}
+
+// Signer adapts a DSA private key to crypto.Signer.
+func (packer packer) Signer(priv interface{}) (crypto.Signer, error) {
+	privKey, ok := priv.(*dsa.PrivateKey)
+	if !ok {
+		return nil, akp.ErrSkip
+	}
+	return Signer{privKey}, nil
+}
+
+// Capabilities reports that DSA keys can sign.
+func (unpacker unpacker) Capabilities(
+	pkg *akp.OneAsymmetricKey,
+) akp.Capability {
+	return akp.CanSign
+}
@@ -0,0 +1,76 @@
+package dsakp
+
+import (
+	"crypto/dsa"
+	"errors"
+	"math/big"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+	"github.com/harmony-one/asym-key-pkgs/pkg/internal/sshwire"
+)
+
+const sshKeyType = "ssh-dss"
+
+// MarshalSSHPublicKey encodes a DSA public key in the "ssh-dss" format
+// (RFC 4253, section 6.6).
+func (packer packer) MarshalSSHPublicKey(pub interface{}) ([]byte, error) {
+	pubKey, ok := pub.(*dsa.PublicKey)
+	if !ok {
+		return nil, akp.ErrSkip
+	}
+	if pubKey.P == nil {
+		return nil, errMissingParameters
+	}
+	var b sshwire.Builder
+	b.String([]byte(sshKeyType))
+	b.MPInt(pubKey.P).MPInt(pubKey.Q).MPInt(pubKey.G).MPInt(pubKey.Y)
+	return b.Bytes(), nil
+}
+
+// SSHKeyTypes returns the SSH key type name of DSA keys.
+func (packer packer) SSHKeyTypes() []string {
+	return []string{sshKeyType}
+}
+
+// MarshalSSHPrivateKey encodes a DSA private key as the OpenSSH fields
+// p, q, g, y and x.
+func (packer packer) MarshalSSHPrivateKey(priv interface{}) (
+	keyType string, fields []byte, err error,
+) {
+	privKey, ok := priv.(*dsa.PrivateKey)
+	if !ok {
+		return "", nil, akp.ErrSkip
+	}
+	if privKey.P == nil || privKey.Y == nil {
+		return "", nil, errMissingParameters
+	}
+	var b sshwire.Builder
+	b.MPInt(privKey.P).MPInt(privKey.Q).MPInt(privKey.G)
+	b.MPInt(privKey.Y).MPInt(privKey.X)
+	return sshKeyType, b.Bytes(), nil
+}
+
+// UnmarshalSSHPrivateKey parses the OpenSSH fields of a DSA private key.
+func (packer packer) UnmarshalSSHPrivateKey(keyType string, data []byte) (
+	priv interface{}, pub interface{}, rest []byte, err error,
+) {
+	if keyType != sshKeyType {
+		return nil, nil, nil, akp.ErrSkip
+	}
+	p := sshwire.NewParser(data)
+	var ints [5]*big.Int
+	for i := range ints {
+		if ints[i], err = p.MPInt(); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+	var privKey dsa.PrivateKey
+	privKey.P, privKey.Q, privKey.G = ints[0], ints[1], ints[2]
+	privKey.Y, privKey.X = ints[3], ints[4]
+	if privKey.P.Sign() <= 0 ||
+		new(big.Int).Exp(privKey.G, privKey.X, privKey.P).Cmp(privKey.Y) != 0 {
+		return nil, nil, nil, errors.New(
+			"DSA public key does not match private key")
+	}
+	return &privKey, &privKey.PublicKey, p.Rest(), nil
+}
@@ -77,19 +77,11 @@ var ErrNotDSA = errors.New("not an DSA key")
 func Unpack(pkg *akp.OneAsymmetricKey) (
 	priv *dsa.PrivateKey, pub *dsa.PublicKey, extras []interface{}, err error,
 ) {
-	privKey, pubKey, extras, err := Unpacker.Unpack(pkg)
-	if err != nil {
-		return nil, nil, nil, err
-	}
-	var ok bool
-	if priv, ok = privKey.(*dsa.PrivateKey); !ok {
-		return nil, nil, nil, ErrNotDSA
-	}
-	if pubKey == nil {
-		return priv, nil, extras, nil
-	}
-	if pub, ok = pubKey.(*dsa.PublicKey); !ok {
+	priv, pub, extras, err = akp.UnpackWith[*dsa.PrivateKey, *dsa.PublicKey](
+		Unpacker, pkg)
+	var mismatch *akp.TypeMismatchError
+	if errors.As(err, &mismatch) {
 		return nil, nil, nil, ErrNotDSA
 	}
-	return priv, pub, extras, nil
+	return priv, pub, extras, err
 }
@@ -0,0 +1,103 @@
+package eckp
+
+import (
+	"crypto/elliptic"
+	"math/big"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/internal/ecurve"
+)
+
+func hexInt(s string) *big.Int {
+	n, ok := new(big.Int).SetString(s, 16)
+	if !ok {
+		panic("bad hex integer " + s)
+	}
+	return n
+}
+
+// The brainpool curves of RFC 5639, section 3, which crypto/elliptic does
+// not implement, in pure Go with constant-time scalar multiplication.
+// crypto/ecdsa signs and verifies with them through its generic code for
+// custom curves; crypto/ecdh does not support them.
+var (
+	brainpoolP256r1 = ecurve.New(&elliptic.CurveParams{
+		Name:    "brainpoolP256r1",
+		BitSize: 256,
+		P: hexInt("A9FB57DBA1EEA9BC3E660A909D838D72" +
+			"6E3BF623D52620282013481D1F6E5377"),
+		N: hexInt("A9FB57DBA1EEA9BC3E660A909D838D71" +
+			"8C397AA3B561A6F7901E0E82974856A7"),
+		B: hexInt("26DC5C6CE94A4B44F330B5D9BBD77CBF" +
+			"958416295CF7E1CE6BCCDC18FF8C07B6"),
+		Gx: hexInt("8BD2AEB9CB7E57CB2C4B482FFC81B7AF" +
+			"B9DE27E1E3BD23C23A4453BD9ACE3262"),
+		Gy: hexInt("547EF835C3DAC4FD97F8461A14611DC9" +
+			"C27745132DED8E545C1D54C72F046997"),
+	}, hexInt("7D5A0975FC2C3057EEF67530417AFFE7"+
+		"FB8055C126DC5C6CE94A4B44F330B5D9"))
+	brainpoolP384r1 = ecurve.New(&elliptic.CurveParams{
+		Name:    "brainpoolP384r1",
+		BitSize: 384,
+		P: hexInt("8CB91E82A3386D280F5D6F7E50E641DF152F7109ED5456B4" +
+			"12B1DA197FB71123ACD3A729901D1A71874700133107EC53"),
+		N: hexInt("8CB91E82A3386D280F5D6F7E50E641DF152F7109ED5456B3" +
+			"1F166E6CAC0425A7CF3AB6AF6B7FC3103B883202E9046565"),
+		B: hexInt("04A8C7DD22CE28268B39B55416F0447C2FB77DE107DCD2A6" +
+			"2E880EA53EEB62D57CB4390295DBC9943AB78696FA504C11"),
+		Gx: hexInt("1D1C64F068CF45FFA2A63A81B7C13F6B8847A3E77EF14FE3" +
+			"DB7FCAFE0CBD10E8E826E03436D646AAEF87B2E247D4AF1E"),
+		Gy: hexInt("8ABE1D7520F9C2A45CB1EB8E95CFD55262B70B29FEEC5864" +
+			"E19C054FF99129280E4646217791811142820341263C5315"),
+	}, hexInt("7BC382C63D8C150C3C72080ACE05AFA0C2BEA28E4FB22787"+
+		"139165EFBA91F90F8AA5814A503AD4EB04A8C7DD22CE2826"))
+	brainpoolP512r1 = ecurve.New(&elliptic.CurveParams{
+		Name:    "brainpoolP512r1",
+		BitSize: 512,
+		P: hexInt("AADD9DB8DBE9C48B3FD4E6AE33C9FC07" +
+			"CB308DB3B3C9D20ED6639CCA70330871" +
+			"7D4D9B009BC66842AECDA12AE6A380E6" +
+			"2881FF2F2D82C68528AA6056583A48F3"),
+		N: hexInt("AADD9DB8DBE9C48B3FD4E6AE33C9FC07" +
+			"CB308DB3B3C9D20ED6639CCA70330870" +
+			"553E5C414CA92619418661197FAC1047" +
+			"1DB1D381085DDADDB58796829CA90069"),
+		B: hexInt("3DF91610A83441CAEA9863BC2DED5D5A" +
+			"A8253AA10A2EF1C98B9AC8B57F1117A7" +
+			"2BF2C7B9E7C1AC4D77FC94CADC083E67" +
+			"984050B75EBAE5DD2809BD638016F723"),
+		Gx: hexInt("81AEE4BDD82ED9645A21322E9C4C6A93" +
+			"85ED9F70B5D916C1B43B62EEF4D0098E" +
+			"FF3B1F78E2D0D48D50D1687B93B97D5F" +
+			"7C6D5047406A5E688B352209BCB9F822"),
+		Gy: hexInt("7DDE385D566332ECC0EABFA9CF7822FD" +
+			"F209F70024A57B1AA000C55B881F8111" +
+			"B2DCDE494A5F485E5BCA4BD88A2763AE" +
+			"D1CA2B2FA8F0540678CD1E0F3AD80892"),
+	}, hexInt("7830A3318B603B89E2327145AC234CC5"+
+		"94CBDD8D3DF91610A83441CAEA9863BC"+
+		"2DED5D5AA8253AA10A2EF1C98B9AC8B5"+
+		"7F1117A72BF2C7B9E7C1AC4D77FC94CA"))
+)
+
+// BrainpoolP256r1 returns the brainpoolP256r1 curve.
+func BrainpoolP256r1() elliptic.Curve {
+	return brainpoolP256r1
+}
+
+// BrainpoolP384r1 returns the brainpoolP384r1 curve.
+func BrainpoolP384r1() elliptic.Curve {
+	return brainpoolP384r1
+}
+
+// BrainpoolP512r1 returns the brainpoolP512r1 curve.
+func BrainpoolP512r1() elliptic.Curve {
+	return brainpoolP512r1
+}
+
+// isCustom reports whether the curve is implemented here rather than by
+// crypto/elliptic, so that the raw key functions of crypto/ecdsa do not
+// support it.
+func isCustom(curve elliptic.Curve) bool {
+	_, ok := curve.(*ecurve.Curve)
+	return ok
+}
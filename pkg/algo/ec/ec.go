@@ -0,0 +1,112 @@
+// Package eckp implements elliptic curve keys on the NIST P-224, P-256,
+// P-384 and P-521 curves and on the brainpoolP256r1, brainpoolP384r1 and
+// brainpoolP512r1 curves, as id-ecPublicKey keys (RFC 5480) with an
+// RFC 5915 ECPrivateKey.
+//
+// Keys are *ecdsa.PrivateKey and *ecdsa.PublicKey values;
+// see BrainpoolP256r1 and its siblings for the brainpool curves.
+// Pack also takes *ecdh.PrivateKey and *ecdh.PublicKey values,
+// and UnpackECDH returns them for key agreement on P-256, P-384 and P-521.
+//
+// Unpack accepts both named curves and explicit (specifiedCurve)
+// parameters, as some HSMs export; explicit parameters must be exactly
+// those of a known curve.
+// Pack always names the curve.
+// Public keys may be compressed or uncompressed points; see PointFormat.
+package eckp
+
+import (
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"encoding/asn1"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+	"github.com/harmony-one/asym-key-pkgs/pkg/internal/ecurve"
+)
+
+// id-ecPublicKey in RFC 5480
+var algorithmOID = asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}
+
+// curves are the supported curves, with their OIDs in RFC 5480 and
+// RFC 5639.
+// crypto/ecdh does not implement P-224 and the brainpool curves.
+var curves = []struct {
+	oid   asn1.ObjectIdentifier
+	curve elliptic.Curve
+	ecdh  ecdh.Curve
+}{
+	{asn1.ObjectIdentifier{1, 3, 132, 0, 33}, elliptic.P224(), nil},
+	{asn1.ObjectIdentifier{1, 2, 840, 10045, 3, 1, 7}, elliptic.P256(),
+		ecdh.P256()},
+	{asn1.ObjectIdentifier{1, 3, 132, 0, 34}, elliptic.P384(), ecdh.P384()},
+	{asn1.ObjectIdentifier{1, 3, 132, 0, 35}, elliptic.P521(), ecdh.P521()},
+	{asn1.ObjectIdentifier{1, 3, 36, 3, 3, 2, 8, 1, 1, 7}, brainpoolP256r1,
+		nil},
+	{asn1.ObjectIdentifier{1, 3, 36, 3, 3, 2, 8, 1, 1, 11}, brainpoolP384r1,
+		nil},
+	{asn1.ObjectIdentifier{1, 3, 36, 3, 3, 2, 8, 1, 1, 13}, brainpoolP512r1,
+		nil},
+}
+
+func init() {
+	akp.Packers.Register(Packer, &ecdsa.PrivateKey{}, &ecdh.PrivateKey{})
+	akp.Unpackers.Register(Unpacker, algorithmOID)
+	for _, c := range curves {
+		ecurve.Register(c.oid, c.curve)
+	}
+}
+
+// oidOf returns the OID of the given curve, or nil if unsupported.
+func oidOf(curve elliptic.Curve) asn1.ObjectIdentifier {
+	for _, c := range curves {
+		if curve == c.curve {
+			return c.oid
+		}
+	}
+	return nil
+}
+
+// curveOf returns the curve with the given OID, or nil if unsupported.
+func curveOf(oid asn1.ObjectIdentifier) elliptic.Curve {
+	for _, c := range curves {
+		if oid.Equal(c.oid) {
+			return c.curve
+		}
+	}
+	return nil
+}
+
+// canAgree reports whether crypto/ecdh implements the given curve.
+func canAgree(curve elliptic.Curve) bool {
+	for _, c := range curves {
+		if curve == c.curve {
+			return c.ecdh != nil
+		}
+	}
+	return false
+}
+
+// curveOfECDH returns the curve of the given crypto/ecdh curve,
+// or nil if unsupported.
+func curveOfECDH(curve ecdh.Curve) elliptic.Curve {
+	for _, c := range curves {
+		if c.ecdh != nil && curve == c.ecdh {
+			return c.curve
+		}
+	}
+	return nil
+}
+
+// PointFormat is the encoding of public keys, given as a Pack option.
+type PointFormat = ecurve.PointFormat
+
+// Point formats
+const (
+	Uncompressed = ecurve.Uncompressed // the default
+	Compressed   = ecurve.Compressed
+)
+
+// ErrUnknownCurve means a key package has explicit curve parameters that
+// do not match any known curve.
+var ErrUnknownCurve = ecurve.ErrUnknownCurve
@@ -0,0 +1,597 @@
+package eckp
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/asn1"
+	"encoding/pem"
+	"os"
+	"reflect"
+	"testing"
+
+	"golang.org/x/crypto/ssh"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+	"github.com/harmony-one/asym-key-pkgs/pkg/internal/ecurve"
+	"github.com/harmony-one/asym-key-pkgs/pkg/openssh"
+)
+
+// The testdata files were generated by OpenSSL 3.0:
+//
+//	openssl genpkey -algorithm EC -pkeyopt ec_paramgen_curve:P-256 \
+//		-pkeyopt ec_param_enc:named_curve -out p256.pem
+//	openssl pkey -in p256.pem -pubout -out p256_pub.pem
+//	openssl ec -in p256.pem -conv_form compressed -pubout \
+//		-out p256_compressed_pub.pem
+//	ssh-keygen -i -m PKCS8 -f p256_pub.pem > p256.pub
+//	printf 'hello, world' |
+//		openssl dgst -sha256 -sign p256.pem -out message.sig
+//	openssl genpkey -algorithm EC -pkeyopt ec_paramgen_curve:P-384 \
+//		-pkeyopt ec_param_enc:named_curve -out p384.pem
+//	openssl ec -in p384.pem -param_enc explicit -conv_form compressed |
+//		openssl pkcs8 -topk8 -nocrypt -out p384_explicit.pem
+//	openssl genpkey -algorithm EC -pkeyopt ec_paramgen_curve:prime239v1 \
+//		-pkeyopt ec_param_enc:explicit -out prime239v1.pem
+//	openssl genpkey -algorithm EC -pkeyopt ec_paramgen_curve:brainpoolP256r1 \
+//		-pkeyopt ec_param_enc:named_curve -out brainpoolP256r1.pem
+//	openssl pkey -in brainpoolP256r1.pem -pubout \
+//		-out brainpoolP256r1_pub.pem
+//	printf 'hello, world' | openssl dgst -sha256 \
+//		-sign brainpoolP256r1.pem -out brainpoolP256r1.sig
+//	openssl genpkey -algorithm EC -pkeyopt ec_paramgen_curve:brainpoolP512r1 \
+//		-pkeyopt ec_param_enc:explicit -out brainpoolP512r1_explicit.pem
+
+func readPEM(t *testing.T, filename string) []byte {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		t.Fatalf("%s: no PEM block", filename)
+	}
+	return block.Bytes
+}
+
+func readPackage(t *testing.T, filename string) *akp.OneAsymmetricKey {
+	var pkg akp.OneAsymmetricKey
+	if _, err := asn1.Unmarshal(readPEM(t, filename), &pkg); err != nil {
+		t.Fatal(err)
+	}
+	return &pkg
+}
+
+func generateKey(t *testing.T, curve elliptic.Curve) *ecdsa.PrivateKey {
+	priv, err := ecdsa.GenerateKey(curve, rand.Reader)
+	if err != nil {
+		t.Fatalf("cannot generate %s key pair: %v", curve.Params().Name, err)
+	}
+	return priv
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, c := range curves {
+		priv := generateKey(t, c.curve)
+		subtest := func(
+			t *testing.T, pub *ecdsa.PublicKey, options ...interface{},
+		) {
+			pkg, err := Packer.Pack(priv, pub, options...)
+			if err != nil {
+				t.Fatalf("cannot pack EC key pair: %v", err)
+			}
+			priv2, pub2, extras, err := Unpack(pkg)
+			if err != nil {
+				t.Fatalf("cannot unpack EC key pair: %v", err)
+			}
+			if !priv.Equal(priv2) {
+				t.Error("reconstructed key is different from the original")
+			}
+			if pub == nil && pub2 != nil || pub != nil && !pub.Equal(pub2) {
+				t.Errorf("expected public key %v but got %v", pub, pub2)
+			}
+			// A compressed public key comes back as the Compressed extra.
+			if !reflect.DeepEqual(extras, options) {
+				t.Errorf("extras are %+v; expected %+v", extras, options)
+			}
+			pkg2, err := Pack(priv2, pub2, extras...)
+			if err != nil {
+				t.Fatalf("cannot repack EC key pair: %v", err)
+			}
+			if !reflect.DeepEqual(pkg, pkg2) {
+				t.Errorf("repacked key package %+v is different from %+v",
+					pkg2, pkg)
+			}
+		}
+		name := c.curve.Params().Name
+		t.Run(name+"/WithoutPublic", func(t *testing.T) { subtest(t, nil) })
+		t.Run(name+"/WithPublic", func(t *testing.T) {
+			subtest(t, &priv.PublicKey)
+		})
+		t.Run(name+"/Compressed", func(t *testing.T) {
+			subtest(t, &priv.PublicKey, Compressed)
+		})
+	}
+}
+
+func TestECDH(t *testing.T) {
+	priv, err := ecdh.P384().GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	encoded, err := akp.Encode(priv, priv.PublicKey())
+	if err != nil {
+		t.Fatalf("cannot encode ECDH key pair: %v", err)
+	}
+	var pkg akp.OneAsymmetricKey
+	if _, err = asn1.Unmarshal(encoded, &pkg); err != nil {
+		t.Fatal(err)
+	}
+	priv2, pub2, _, err := UnpackECDH(&pkg)
+	if err != nil {
+		t.Fatalf("cannot unpack ECDH key pair: %v", err)
+	}
+	if !priv.Equal(priv2) || !priv.PublicKey().Equal(pub2) {
+		t.Error("unpacked ECDH key pair is different from the original")
+	}
+	if c := pkg.Capabilities(); c != akp.CanSign|akp.CanAgree {
+		t.Errorf("capabilities are %v; expected %v",
+			c, akp.CanSign|akp.CanAgree)
+	}
+	pkg224, err := Pack(generateKey(t, elliptic.P224()), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, _, err = UnpackECDH(pkg224); err == nil {
+		t.Error("UnpackECDH unpacked a P-224 key")
+	}
+	if c := pkg224.Capabilities(); c != akp.CanSign {
+		t.Errorf("capabilities of a P-224 key are %v; expected %v",
+			c, akp.CanSign)
+	}
+}
+
+func TestOpenSSL(t *testing.T) {
+	priv, pub, _, err := Unpack(readPackage(t, "testdata/p256.pem"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !priv.PublicKey.Equal(pub) {
+		t.Error("public key does not match private key")
+	}
+	for _, test := range []struct {
+		format   PointFormat
+		filename string
+	}{
+		{Uncompressed, "testdata/p256_pub.pem"},
+		{Compressed, "testdata/p256_compressed_pub.pem"},
+	} {
+		pkg, err := Pack(priv, pub, test.format)
+		if err != nil {
+			t.Fatal(err)
+		}
+		spki, err := pkg.MarshalPublicKeyInfo()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if expected := readPEM(t, test.filename); !bytes.Equal(
+			spki, expected) {
+			t.Errorf("SubjectPublicKeyInfo is %x; expected %x", spki, expected)
+		}
+	}
+	sig, err := os.ReadFile("testdata/message.sig")
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest := sha256.Sum256([]byte("hello, world"))
+	if !ecdsa.VerifyASN1(pub, digest[:], sig) {
+		t.Error("cannot verify OpenSSL signature")
+	}
+}
+
+func TestExplicitParameters(t *testing.T) {
+	named, _, _, err := Unpack(readPackage(t, "testdata/p384.pem"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkg := readPackage(t, "testdata/p384_explicit.pem")
+	explicit, pub, _, err := Unpack(pkg)
+	if err != nil {
+		t.Fatalf("cannot unpack key with explicit parameters: %v", err)
+	}
+	if !named.Equal(explicit) || !named.PublicKey.Equal(pub) {
+		t.Error("key with explicit parameters is different from the original")
+	}
+	if c := pkg.Capabilities(); c != akp.CanSign|akp.CanAgree {
+		t.Errorf("capabilities are %v; expected %v",
+			c, akp.CanSign|akp.CanAgree)
+	}
+	repacked, err := Pack(explicit, pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	oid, err := ecurve.ParseParameters(
+		repacked.PrivateKeyAlgorithm.Parameters.FullBytes)
+	if err != nil || !oid.Equal(oidOf(elliptic.P384())) {
+		t.Errorf("repacked key has curve %v, %v; expected a named curve",
+			oid, err)
+	}
+
+	t.Run("UnknownCurve", func(t *testing.T) {
+		pkg := readPackage(t, "testdata/prime239v1.pem")
+		if _, _, _, err := akp.Unpack(pkg); err != ErrUnknownCurve {
+			t.Errorf("Unpack returned %v; expected %v", err, ErrUnknownCurve)
+		}
+		if c := pkg.Capabilities(); c != 0 {
+			t.Errorf("capabilities are %v; expected none", c)
+		}
+	})
+	t.Run("WrongCoefficient", func(t *testing.T) {
+		pkg := *pkg
+		params := bytes.Clone(pkg.PrivateKeyAlgorithm.Parameters.FullBytes)
+		b := named.Params().B.Bytes()
+		i := bytes.Index(params, b)
+		if i < 0 {
+			t.Fatal("coefficient b not found in explicit parameters")
+		}
+		params[i+len(b)-1] ^= 1
+		pkg.PrivateKeyAlgorithm.Parameters.FullBytes = params
+		if _, _, _, err := Unpack(&pkg); err != ErrUnknownCurve {
+			t.Errorf("Unpack returned %v; expected %v", err, ErrUnknownCurve)
+		}
+	})
+}
+
+func TestBrainpool(t *testing.T) {
+	priv, pub, _, err := Unpack(readPackage(t, "testdata/brainpoolP256r1.pem"))
+	if err != nil {
+		t.Fatalf("cannot unpack brainpoolP256r1 key: %v", err)
+	}
+	if priv.Curve != BrainpoolP256r1() || !priv.PublicKey.Equal(pub) {
+		t.Error("unpacked key is not a brainpoolP256r1 key pair")
+	}
+	pkg, err := Pack(priv, pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	spki, err := pkg.MarshalPublicKeyInfo()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := readPEM(t, "testdata/brainpoolP256r1_pub.pem"); !bytes.Equal(
+		spki, expected) {
+		t.Errorf("SubjectPublicKeyInfo is %x; expected %x", spki, expected)
+	}
+	if c := pkg.Capabilities(); c != akp.CanSign {
+		t.Errorf("capabilities are %v; expected %v", c, akp.CanSign)
+	}
+	if _, _, _, err = UnpackECDH(pkg); err == nil {
+		t.Error("UnpackECDH unpacked a brainpool key")
+	}
+	sig, err := os.ReadFile("testdata/brainpoolP256r1.sig")
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest := sha256.Sum256([]byte("hello, world"))
+	if !ecdsa.VerifyASN1(pub, digest[:], sig) {
+		t.Error("cannot verify OpenSSL signature")
+	}
+
+	encoded, err := akp.Encode(priv, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := akp.DecodeSigner(encoded)
+	if err != nil {
+		t.Fatalf("cannot decode signer: %v", err)
+	}
+	sig, err = signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+	if err != nil {
+		t.Fatalf("cannot sign: %v", err)
+	}
+	if !ecdsa.VerifyASN1(pub, digest[:], sig) {
+		t.Error("cannot verify signature")
+	}
+
+	t.Run("ExplicitParameters", func(t *testing.T) {
+		pkg := readPackage(t, "testdata/brainpoolP512r1_explicit.pem")
+		priv, pub, _, err := Unpack(pkg)
+		if err != nil {
+			t.Fatalf("cannot unpack key with explicit parameters: %v", err)
+		}
+		if priv.Curve != BrainpoolP512r1() || !priv.PublicKey.Equal(pub) {
+			t.Error("unpacked key is not a brainpoolP512r1 key pair")
+		}
+		repacked, err := Pack(priv, pub)
+		if err != nil {
+			t.Fatal(err)
+		}
+		oid, err := ecurve.ParseParameters(
+			repacked.PrivateKeyAlgorithm.Parameters.FullBytes)
+		if err != nil || !oid.Equal(asn1.ObjectIdentifier{
+			1, 3, 36, 3, 3, 2, 8, 1, 1, 13,
+		}) {
+			t.Errorf("repacked key has curve %v, %v; expected %v",
+				oid, err, "brainpoolP512r1")
+		}
+	})
+}
+
+func TestUnpack(t *testing.T) {
+	priv := generateKey(t, elliptic.P256())
+	pkg, err := Pack(priv, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d, err := priv.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	p384Params, _ := asn1.Marshal(oidOf(elliptic.P384()))
+	for _, test := range []struct {
+		name   string
+		modify func(key *ecurve.ECPrivateKey, pkg *akp.OneAsymmetricKey)
+	}{
+		{"LongKey", func(key *ecurve.ECPrivateKey, pkg *akp.OneAsymmetricKey) {
+			key.PrivateKey = append([]byte{0}, d...)
+		}},
+		{"ZeroKey", func(key *ecurve.ECPrivateKey, pkg *akp.OneAsymmetricKey) {
+			key.PrivateKey = make([]byte, len(d))
+		}},
+		{"BadVersion", func(
+			key *ecurve.ECPrivateKey, pkg *akp.OneAsymmetricKey,
+		) {
+			key.Version = 2
+		}},
+		{"ParametersMismatch", func(
+			key *ecurve.ECPrivateKey, pkg *akp.OneAsymmetricKey,
+		) {
+			key.Parameters = asn1.RawValue{
+				Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true,
+				Bytes: p384Params,
+			}
+		}},
+		{"PublicKeyMismatch", func(
+			key *ecurve.ECPrivateKey, pkg *akp.OneAsymmetricKey,
+		) {
+			other := generateKey(t, elliptic.P256())
+			pkg.PublicKey, _ = packPublicKey(&other.PublicKey, Compressed)
+		}},
+		{"BadPoint", func(
+			key *ecurve.ECPrivateKey, pkg *akp.OneAsymmetricKey,
+		) {
+			pkg.PublicKey, _ = packPublicKey(&priv.PublicKey, Uncompressed)
+			pkg.PublicKey.Bytes[len(pkg.PublicKey.Bytes)-1] ^= 1
+		}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			key := ecurve.ECPrivateKey{
+				Version: ecurve.ECPrivateKeyVersion, PrivateKey: d,
+			}
+			pkg := *pkg
+			test.modify(&key, &pkg)
+			if pkg.PrivateKey, err = asn1.Marshal(key); err != nil {
+				t.Fatal(err)
+			}
+			if _, _, _, err = Unpack(&pkg); err == nil {
+				t.Error("Unpack succeeded")
+			}
+		})
+	}
+	t.Run("ShortKey", func(t *testing.T) {
+		// Keys with leading zeros stripped are accepted.
+		priv := generateKey(t, elliptic.P256())
+		for d, _ = priv.Bytes(); d[0] != 0; d, _ = priv.Bytes() {
+			priv = generateKey(t, elliptic.P256())
+		}
+		pkg := *pkg
+		if pkg.PrivateKey, err = asn1.Marshal(ecurve.ECPrivateKey{
+			Version: ecurve.ECPrivateKeyVersion, PrivateKey: d[1:],
+		}); err != nil {
+			t.Fatal(err)
+		}
+		priv2, _, _, err := Unpack(&pkg)
+		if err != nil || !priv.Equal(priv2) {
+			t.Errorf("Unpack returned %v, %v; expected the original key",
+				priv2, err)
+		}
+	})
+	t.Run("OtherCurve", func(t *testing.T) {
+		pkg := *pkg
+		// secp256k1
+		params, _ := asn1.Marshal(asn1.ObjectIdentifier{1, 3, 132, 0, 10})
+		pkg.PrivateKeyAlgorithm.Parameters.FullBytes = params
+		if _, _, _, err = Unpacker.Unpack(&pkg); err != akp.ErrSkip {
+			t.Errorf("Unpack returned %v; expected %v", err, akp.ErrSkip)
+		}
+		if _, _, _, err = Unpack(&pkg); err != ErrNotEC {
+			t.Errorf("Unpack returned %v; expected %v", err, ErrNotEC)
+		}
+	})
+	t.Run("WrongAlgorithm", func(t *testing.T) {
+		pkg := *pkg
+		pkg.PrivateKeyAlgorithm.Algorithm = asn1.ObjectIdentifier{1, 2, 3}
+		if _, _, _, err = Unpack(&pkg); err != ErrNotEC {
+			t.Errorf("Unpack returned %v; expected %v", err, ErrNotEC)
+		}
+	})
+}
+
+func TestSigner(t *testing.T) {
+	encoded, err := akp.Encode(generateKey(t, elliptic.P521()), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := akp.DecodeSigner(encoded)
+	if err != nil {
+		t.Fatalf("cannot decode signer: %v", err)
+	}
+	digest := sha256.Sum256([]byte("hello, world"))
+	sig, err := signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+	if err != nil {
+		t.Fatalf("cannot sign: %v", err)
+	}
+	if !ecdsa.VerifyASN1(signer.Public().(*ecdsa.PublicKey), digest[:], sig) {
+		t.Error("cannot verify signature")
+	}
+}
+
+func TestSSH(t *testing.T) {
+	for _, curve := range []elliptic.Curve{
+		elliptic.P256(), elliptic.P384(), elliptic.P521(),
+	} {
+		t.Run(curve.Params().Name, func(t *testing.T) {
+			priv := generateKey(t, curve)
+			keyType, fields, err := akp.Packers.MarshalSSHPrivateKey(priv)
+			if err != nil {
+				t.Fatalf("cannot marshal SSH private key: %v", err)
+			}
+			codec := akp.Packers.SSHPrivateKeyCodec(keyType)
+			if codec == nil {
+				t.Fatalf("no codec for %q", keyType)
+			}
+			priv2, pub2, rest, err := codec.UnmarshalSSHPrivateKey(keyType,
+				fields)
+			if err != nil {
+				t.Fatalf("cannot unmarshal SSH private key: %v", err)
+			}
+			if !priv.Equal(priv2) || !priv.PublicKey.Equal(pub2) {
+				t.Error("SSH round trip returned a different key pair")
+			}
+			if len(rest) > 0 {
+				t.Errorf("unexpected rest %x", rest)
+			}
+			pkg, err := Pack(priv, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			encoded, err := openssh.Encode(pkg, nil, 0)
+			if err != nil {
+				t.Fatalf("cannot encode OpenSSH private key: %v", err)
+			}
+			priv3, err := ssh.ParseRawPrivateKey(encoded)
+			if err != nil {
+				t.Fatalf("x/crypto/ssh cannot parse key: %v", err)
+			}
+			if !priv.Equal(priv3) {
+				t.Error("x/crypto/ssh parsed a different key")
+			}
+			block, err := ssh.MarshalPrivateKey(priv, "")
+			if err != nil {
+				t.Fatalf("x/crypto/ssh cannot marshal key: %v", err)
+			}
+			pkg, err = openssh.Unmarshal(block.Bytes, nil)
+			if err != nil {
+				t.Fatalf("cannot unmarshal OpenSSH private key: %v", err)
+			}
+			if priv4, _, _, err := Unpack(pkg); err != nil ||
+				!priv.Equal(priv4) {
+				t.Errorf("unmarshaled a different key: %v", err)
+			}
+		})
+	}
+	for _, curve := range []elliptic.Curve{elliptic.P224(), brainpoolP256r1} {
+		priv := generateKey(t, curve)
+		_, _, err := akp.Packers.MarshalSSHPrivateKey(priv)
+		if err != akp.ErrSkip {
+			t.Errorf("%s: MarshalSSHPrivateKey returned %v; expected %v",
+				curve.Params().Name, err, akp.ErrSkip)
+		}
+	}
+
+	// The SSH public key and fingerprint are those of ssh-keygen.
+	data, err := os.ReadFile("testdata/p256.pub")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected, _, _, _, err := ssh.ParseAuthorizedKey(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkg := readPackage(t, "testdata/p256.pem")
+	priv, pub, _, err := Unpack(pkg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	blob, err := akp.Packers.MarshalSSHPublicKey(priv, pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(blob, expected.Marshal()) {
+		t.Errorf("SSH public key is %x; expected %x", blob, expected.Marshal())
+	}
+	fp, err := pkg.Fingerprint()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fp.SSHSHA256 != ssh.FingerprintSHA256(expected) {
+		t.Errorf("SSH fingerprint is %q; expected %q",
+			fp.SSHSHA256, ssh.FingerprintSHA256(expected))
+	}
+}
+
+// jwkExample is the example P-256 private key of RFC 7517, appendix A.2.
+var jwkExample = map[string]interface{}{
+	"kty": "EC",
+	"crv": "P-256",
+	"x":   "MKBCTNIcKUSDii11ySs3526iDZ8AiTo7Tu6KPAqv7D4",
+	"y":   "4Etl6SRW2YiLUrN5vfvVHuhp7x8PxltmWWlbbM4IFyM",
+	"d":   "870MB6gfuTJ4HtUnUvYMyJpr5eUZNP4Bk43bVdj3eAE",
+}
+
+func TestJWK(t *testing.T) {
+	priv, pub, err := akp.Packers.UnmarshalJWK(jwkExample)
+	if err != nil {
+		t.Fatalf("cannot unmarshal RFC 7517 JWK: %v", err)
+	}
+	members, err := akp.Packers.MarshalJWK(priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(members, jwkExample) {
+		t.Errorf("JWK is %+v; expected %+v", members, jwkExample)
+	}
+	thumbprint, err := Packer.JWKThumbprintMembers(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, value := range thumbprint {
+		if value != jwkExample[name] {
+			t.Errorf("thumbprint member %q is %q; expected %q",
+				name, value, jwkExample[name])
+		}
+	}
+	if len(thumbprint) != 4 {
+		t.Errorf("thumbprint members are %+v", thumbprint)
+	}
+
+	for _, curve := range []elliptic.Curve{elliptic.P384(), elliptic.P521()} {
+		priv := generateKey(t, curve)
+		members, err := akp.Packers.MarshalJWK(priv)
+		if err != nil {
+			t.Fatal(err)
+		}
+		priv2, _, err := akp.Packers.UnmarshalJWK(members)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !priv.Equal(priv2) {
+			t.Errorf("%s: JWK round trip returned a different key",
+				curve.Params().Name)
+		}
+		members["x"] = members["y"]
+		if _, _, err = akp.Packers.UnmarshalJWK(members); err == nil {
+			t.Errorf("%s: mismatching public key was accepted",
+				curve.Params().Name)
+		}
+	}
+	_, err = akp.Packers.MarshalJWK(generateKey(t, elliptic.P224()))
+	if err != akp.ErrSkip {
+		t.Errorf("MarshalJWK returned %v for P-224; expected %v",
+			err, akp.ErrSkip)
+	}
+}
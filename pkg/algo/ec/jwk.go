@@ -0,0 +1,147 @@
+package eckp
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"errors"
+	"math/big"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+	"github.com/harmony-one/asym-key-pkgs/pkg/internal/jwkfield"
+)
+
+const jwkKeyType = "EC"
+
+// jwkCurves are the curves of EC JWKs, with their "crv" values
+// (RFC 7518, section 6.2.1.1).
+var jwkCurves = []struct {
+	name  string
+	curve elliptic.Curve
+}{
+	{"P-256", elliptic.P256()},
+	{"P-384", elliptic.P384()},
+	{"P-521", elliptic.P521()},
+}
+
+// jwkCurveName returns the "crv" value of the given curve,
+// or "" if JWK does not support it.
+func jwkCurveName(curve elliptic.Curve) string {
+	for _, c := range jwkCurves {
+		if curve == c.curve {
+			return c.name
+		}
+	}
+	return ""
+}
+
+// jwkCurve returns the curve with the given "crv" value,
+// or nil if unsupported.
+func jwkCurve(name string) elliptic.Curve {
+	for _, c := range jwkCurves {
+		if name == c.name {
+			return c.curve
+		}
+	}
+	return nil
+}
+
+// coordinateSize returns the length of the coordinates and of the private
+// key of JWKs on the given curve (RFC 7518, sections 6.2.1.2 and 6.2.2.1).
+func coordinateSize(curve elliptic.Curve) int {
+	return (curve.Params().BitSize + 7) / 8
+}
+
+// JWKThumbprintMembers returns the required members of an EC JWK on the
+// NIST P-256, P-384 or P-521 curve (RFC 7518, section 6.2.1).
+func (packer packer) JWKThumbprintMembers(pub interface{}) (
+	map[string]string, error,
+) {
+	pubKey, ok := pub.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, akp.ErrSkip
+	}
+	name := jwkCurveName(pubKey.Curve)
+	if name == "" {
+		return nil, akp.ErrSkip
+	}
+	size := coordinateSize(pubKey.Curve)
+	return map[string]string{
+		"kty": jwkKeyType,
+		"crv": name,
+		"x":   jwkfield.FixedInt(pubKey.X, size),
+		"y":   jwkfield.FixedInt(pubKey.Y, size),
+	}, nil
+}
+
+// JWKKeyTypes returns the JWK key type of EC keys.
+func (packer packer) JWKKeyTypes() []string {
+	return []string{jwkKeyType}
+}
+
+// MarshalJWK returns the members of an EC private JWK on the NIST P-256,
+// P-384 or P-521 curve (RFC 7518, section 6.2.2).
+func (packer packer) MarshalJWK(priv interface{}) (
+	map[string]interface{}, error,
+) {
+	privKey, ok := priv.(*ecdsa.PrivateKey)
+	if !ok {
+		return nil, akp.ErrSkip
+	}
+	name := jwkCurveName(privKey.Curve)
+	if name == "" {
+		return nil, akp.ErrSkip
+	}
+	size := coordinateSize(privKey.Curve)
+	return map[string]interface{}{
+		"kty": jwkKeyType,
+		"crv": name,
+		"x":   jwkfield.FixedInt(privKey.X, size),
+		"y":   jwkfield.FixedInt(privKey.Y, size),
+		"d":   jwkfield.FixedInt(privKey.D, size),
+	}, nil
+}
+
+// UnmarshalJWK converts the members of an EC private JWK into an ECDSA key
+// pair.
+//
+// It skips JWKs for other curves, such as secp256k1.
+// The public key is recomputed and must match the "x" and "y" members.
+func (packer packer) UnmarshalJWK(members map[string]interface{}) (
+	priv interface{}, pub interface{}, err error,
+) {
+	crv, _ := members["crv"].(string)
+	curve := jwkCurve(crv)
+	if curve == nil {
+		return nil, nil, akp.ErrSkip
+	}
+	size := coordinateSize(curve)
+	var coords [2]*big.Int
+	for i, name := range []string{"x", "y"} {
+		b, err := jwkfield.GetBytes(members, name)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(b) != size {
+			return nil, nil, errors.New("bad EC coordinate length")
+		}
+		coords[i] = new(big.Int).SetBytes(b)
+	}
+	if _, ok := members["d"]; !ok {
+		return nil, nil, errors.New("JWK has no private key")
+	}
+	d, err := jwkfield.GetBytes(members, "d")
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(d) != size {
+		return nil, nil, errors.New("bad EC private key length")
+	}
+	privKey, err := parsePrivateKey(curve, d)
+	if err != nil {
+		return nil, nil, err
+	}
+	if privKey.X.Cmp(coords[0]) != 0 || privKey.Y.Cmp(coords[1]) != 0 {
+		return nil, nil, errors.New("public key does not match private key")
+	}
+	return privKey, &privKey.PublicKey, nil
+}
@@ -0,0 +1,148 @@
+package eckp
+
+import (
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+	"github.com/harmony-one/asym-key-pkgs/pkg/internal/ecurve"
+)
+
+type packer struct{}
+
+// Packer is the singleton packer instance.
+var Packer packer
+
+func (packer packer) Pack(
+	priv interface{}, pub interface{}, options ...interface{},
+) (pkg *akp.OneAsymmetricKey, err error) {
+	var privKey *ecdsa.PrivateKey
+	switch priv := priv.(type) {
+	case *ecdsa.PrivateKey:
+		if oidOf(priv.Curve) == nil {
+			return nil, akp.ErrSkip
+		}
+		privKey = priv
+	case *ecdh.PrivateKey:
+		curve := curveOfECDH(priv.Curve())
+		if curve == nil {
+			return nil, akp.ErrSkip
+		}
+		if privKey, err = ecdsa.ParseRawPrivateKey(
+			curve, priv.Bytes()); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, akp.ErrSkip
+	}
+	var pubKey *ecdsa.PublicKey
+	switch pub := pub.(type) {
+	case nil:
+	case *ecdsa.PublicKey:
+		pubKey = pub
+	case *ecdh.PublicKey:
+		curve := curveOfECDH(pub.Curve())
+		if curve == nil {
+			return nil, akp.ErrSkip
+		}
+		if pubKey, err = ecdsa.ParseUncompressedPublicKey(
+			curve, pub.Bytes()); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, akp.ErrSkip
+	}
+	return Pack(privKey, pubKey, options...)
+}
+
+// Pack packs the given EC key pair into a key package.
+//
+// A PointFormat option selects the encoding of the public key.
+func Pack(
+	privKey *ecdsa.PrivateKey, pubKey *ecdsa.PublicKey, options ...interface{},
+) (pkg *akp.OneAsymmetricKey, err error) {
+	oid := oidOf(privKey.Curve)
+	if oid == nil {
+		return nil, errors.New("unsupported elliptic curve")
+	}
+	format := Uncompressed
+	for _, option := range options {
+		if f, ok := option.(PointFormat); ok {
+			format = f
+		}
+	}
+	params, err := asn1.Marshal(oid)
+	if err != nil {
+		return nil, err
+	}
+	pkg = &akp.OneAsymmetricKey{
+		Version: akp.V1,
+		PrivateKeyAlgorithm: pkix.AlgorithmIdentifier{
+			Algorithm:  algorithmOID,
+			Parameters: asn1.RawValue{FullBytes: params},
+		},
+	}
+	d, err := privateKeyBytes(privKey)
+	if err != nil {
+		return nil, err
+	}
+	pkg.PrivateKey, err = asn1.Marshal(ecurve.ECPrivateKey{
+		Version:    ecurve.ECPrivateKeyVersion,
+		PrivateKey: d,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if pubKey != nil {
+		if pubKey.Curve != privKey.Curve {
+			return nil, errors.New("EC public key is on a different curve")
+		}
+		if pkg.PublicKey, err = packPublicKey(pubKey, format); err != nil {
+			return nil, err
+		}
+		pkg.Version = akp.V2
+	}
+	return pkg, nil
+}
+
+func packPublicKey(
+	pubKey *ecdsa.PublicKey, format PointFormat,
+) (asn1.BitString, error) {
+	point, err := publicKeyBytes(pubKey)
+	if err != nil {
+		return asn1.BitString{}, err
+	}
+	if format == Compressed {
+		point = ecurve.CompressPoint(point)
+	}
+	return asn1.BitString{Bytes: point, BitLength: 8 * len(point)}, nil
+}
+
+// privateKeyBytes returns the private key as the fixed-length octet string
+// of RFC 5915, section 3.
+func privateKeyBytes(privKey *ecdsa.PrivateKey) ([]byte, error) {
+	if !isCustom(privKey.Curve) {
+		return privKey.Bytes()
+	}
+	n := privKey.Curve.Params().N
+	if privKey.D == nil || privKey.D.Sign() <= 0 || privKey.D.Cmp(n) >= 0 {
+		return nil, errors.New("EC private key out of range")
+	}
+	return privKey.D.FillBytes(make([]byte, (n.BitLen()+7)/8)), nil
+}
+
+// publicKeyBytes returns the public key as an uncompressed point.
+func publicKeyBytes(pubKey *ecdsa.PublicKey) ([]byte, error) {
+	if !isCustom(pubKey.Curve) {
+		return pubKey.Bytes()
+	}
+	if pubKey.X == nil || pubKey.Y == nil ||
+		!pubKey.Curve.IsOnCurve(pubKey.X, pubKey.Y) {
+		return nil, errors.New("EC public key not on curve")
+	}
+	return ecurve.MarshalPoint(pubKey.Curve, pubKey.X, pubKey.Y,
+		Uncompressed), nil
+}
@@ -0,0 +1,43 @@
+package eckp
+
+import (
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"encoding/asn1"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+)
+
+// DerivePublicKey returns the public portion of an EC private key.
+func (packer packer) DerivePublicKey(priv interface{}) (
+	pub interface{}, err error,
+) {
+	switch privKey := priv.(type) {
+	case *ecdsa.PrivateKey:
+		if oidOf(privKey.Curve) != nil {
+			return &privKey.PublicKey, nil
+		}
+	case *ecdh.PrivateKey:
+		if curveOfECDH(privKey.Curve()) != nil {
+			return privKey.PublicKey(), nil
+		}
+	}
+	return nil, akp.ErrSkip
+}
+
+// PackPublicKey packs an EC public key as an uncompressed point
+// (RFC 5480, section 2.2).
+func (packer packer) PackPublicKey(pub interface{}) (asn1.BitString, error) {
+	switch pubKey := pub.(type) {
+	case *ecdsa.PublicKey:
+		if oidOf(pubKey.Curve) != nil {
+			return packPublicKey(pubKey, Uncompressed)
+		}
+	case *ecdh.PublicKey:
+		if curveOfECDH(pubKey.Curve()) != nil {
+			bytes := pubKey.Bytes()
+			return asn1.BitString{Bytes: bytes, BitLength: 8 * len(bytes)}, nil
+		}
+	}
+	return asn1.BitString{}, akp.ErrSkip
+}
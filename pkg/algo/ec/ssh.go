@@ -0,0 +1,153 @@
+package eckp
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"errors"
+	"math/big"
+	"strings"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+	"github.com/harmony-one/asym-key-pkgs/pkg/internal/sshwire"
+)
+
+// sshCurves are the curves of ECDSA keys in SSH, with their identifiers
+// (RFC 5656, section 10.1).
+var sshCurves = []struct {
+	name  string
+	curve elliptic.Curve
+}{
+	{"nistp256", elliptic.P256()},
+	{"nistp384", elliptic.P384()},
+	{"nistp521", elliptic.P521()},
+}
+
+const sshKeyTypePrefix = "ecdsa-sha2-"
+
+// sshCurveName returns the SSH identifier of the given curve,
+// or "" if SSH does not support it.
+func sshCurveName(curve elliptic.Curve) string {
+	for _, c := range sshCurves {
+		if curve == c.curve {
+			return c.name
+		}
+	}
+	return ""
+}
+
+// sshCurve returns the curve of the given SSH key type name,
+// or nil if it is not an ECDSA key type.
+func sshCurve(keyType string) elliptic.Curve {
+	name, ok := strings.CutPrefix(keyType, sshKeyTypePrefix)
+	if !ok {
+		return nil
+	}
+	for _, c := range sshCurves {
+		if name == c.name {
+			return c.curve
+		}
+	}
+	return nil
+}
+
+// MarshalSSHPublicKey encodes an ECDSA public key on the NIST P-256, P-384
+// or P-521 curve in the "ecdsa-sha2-*" format (RFC 5656, section 3.1).
+func (packer packer) MarshalSSHPublicKey(pub interface{}) ([]byte, error) {
+	pubKey, ok := pub.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, akp.ErrSkip
+	}
+	name := sshCurveName(pubKey.Curve)
+	if name == "" {
+		return nil, akp.ErrSkip
+	}
+	point, err := pubKey.Bytes()
+	if err != nil {
+		return nil, err
+	}
+	var b sshwire.Builder
+	b.String([]byte(sshKeyTypePrefix + name))
+	b.String([]byte(name)).String(point)
+	return b.Bytes(), nil
+}
+
+// SSHKeyTypes returns the SSH key type names of ECDSA keys.
+func (packer packer) SSHKeyTypes() []string {
+	keyTypes := make([]string, len(sshCurves))
+	for i, c := range sshCurves {
+		keyTypes[i] = sshKeyTypePrefix + c.name
+	}
+	return keyTypes
+}
+
+// MarshalSSHPrivateKey encodes an ECDSA private key on the NIST P-256,
+// P-384 or P-521 curve as the OpenSSH fields curve, Q and d.
+func (packer packer) MarshalSSHPrivateKey(priv interface{}) (
+	keyType string, fields []byte, err error,
+) {
+	privKey, ok := priv.(*ecdsa.PrivateKey)
+	if !ok {
+		return "", nil, akp.ErrSkip
+	}
+	name := sshCurveName(privKey.Curve)
+	if name == "" {
+		return "", nil, akp.ErrSkip
+	}
+	point, err := privKey.PublicKey.Bytes()
+	if err != nil {
+		return "", nil, err
+	}
+	d, err := privKey.Bytes()
+	if err != nil {
+		return "", nil, err
+	}
+	var b sshwire.Builder
+	b.String([]byte(name)).String(point).MPInt(new(big.Int).SetBytes(d))
+	return sshKeyTypePrefix + name, b.Bytes(), nil
+}
+
+// UnmarshalSSHPrivateKey parses the OpenSSH fields of an ECDSA private key.
+//
+// The public key Q must match the private key.
+func (packer packer) UnmarshalSSHPrivateKey(keyType string, data []byte) (
+	priv interface{}, pub interface{}, rest []byte, err error,
+) {
+	curve := sshCurve(keyType)
+	if curve == nil {
+		return nil, nil, nil, akp.ErrSkip
+	}
+	p := sshwire.NewParser(data)
+	name, err := p.String()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if string(name) != sshCurveName(curve) {
+		return nil, nil, nil, errors.New(
+			"ECDSA curve does not match the SSH key type")
+	}
+	point, err := p.String()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	d, err := p.MPInt()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	size := (curve.Params().N.BitLen() + 7) / 8
+	if d.Sign() <= 0 || d.BitLen() > 8*size {
+		return nil, nil, nil, errors.New("EC private key out of range")
+	}
+	privKey, err := parsePrivateKey(curve, d.FillBytes(make([]byte, size)))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	pubKey, _, err := parsePublicKey(curve, point)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if !pubKey.Equal(&privKey.PublicKey) {
+		return nil, nil, nil, errors.New(
+			"EC public key does not match private key")
+	}
+	return privKey, pubKey, p.Rest(), nil
+}
@@ -0,0 +1,194 @@
+package eckp
+
+import (
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+	"github.com/harmony-one/asym-key-pkgs/pkg/internal/ecurve"
+)
+
+type unpacker struct{}
+
+// Unpacker is the singleton EC unpacker instance.
+var Unpacker unpacker
+
+// packageCurve returns the curve of the key package,
+// or nil if it is not an id-ecPublicKey key on a supported curve.
+func packageCurve(pkg *akp.OneAsymmetricKey) (elliptic.Curve, error) {
+	if !pkg.PrivateKeyAlgorithm.Algorithm.Equal(algorithmOID) {
+		return nil, nil
+	}
+	oid, err := ecurve.ParseParameters(
+		pkg.PrivateKeyAlgorithm.Parameters.FullBytes)
+	if err != nil {
+		return nil, err
+	}
+	return curveOf(oid), nil
+}
+
+// Unpack unpacks an EC private key on a supported curve.
+//
+// It skips key packages for other named curves, so that other
+// id-ecPublicKey unpackers may handle them,
+// and fails for explicit parameters of an unknown curve.
+// The public key is taken from the PublicKey field of the key package, or
+// else from the ECPrivateKey, in either point format;
+// it must match the private key.
+// If it is a compressed point, the Compressed point format is returned as an
+// extra, so that repacking keeps the format.
+func (unpacker unpacker) Unpack(pkg *akp.OneAsymmetricKey) (
+	priv interface{}, pub interface{}, extras []interface{}, err error,
+) {
+	curve, err := packageCurve(pkg)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if curve == nil {
+		return nil, nil, nil, akp.ErrSkip
+	}
+	var ecPrivKey ecurve.ECPrivateKey
+	rest, err := asn1.Unmarshal(pkg.PrivateKey, &ecPrivKey)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf(
+			"cannot unmarshal private key: %v", err)
+	}
+	if len(rest) > 0 {
+		return nil, nil, nil, errors.New("extra data after ECPrivateKey")
+	}
+	if ecPrivKey.Version != ecurve.ECPrivateKeyVersion {
+		return nil, nil, nil, fmt.Errorf(
+			"unsupported ECPrivateKey version %d", ecPrivKey.Version)
+	}
+	if params := ecPrivKey.Parameters.Bytes; params != nil {
+		oid, err := ecurve.ParseParameters(params)
+		if err != nil || curveOf(oid) != curve {
+			return nil, nil, nil, errors.New(
+				"ECPrivateKey parameters do not match the algorithm")
+		}
+	}
+	// Some encoders strip the leading zeros of the private key.
+	size := (curve.Params().N.BitLen() + 7) / 8
+	if len(ecPrivKey.PrivateKey) > size {
+		return nil, nil, nil, errors.New("EC private key too long")
+	}
+	d := make([]byte, size)
+	copy(d[size-len(ecPrivKey.PrivateKey):], ecPrivKey.PrivateKey)
+	privKey, err := parsePrivateKey(curve, d)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	bits := pkg.PublicKey
+	if bits.Bytes == nil {
+		bits = ecPrivKey.PublicKey
+	}
+	if bits.Bytes == nil {
+		return privKey, nil, nil, nil
+	}
+	pubKey, format, err := parsePublicKey(curve, bits.RightAlign())
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("cannot unmarshal public key: %v", err)
+	}
+	if !pubKey.Equal(&privKey.PublicKey) {
+		return nil, nil, nil, errors.New(
+			"EC public key does not match private key")
+	}
+	if format == Compressed {
+		extras = append(extras, Compressed)
+	}
+	return privKey, pubKey, extras, nil
+}
+
+// parsePublicKey parses a point of the curve in either format,
+// and returns its format.
+func parsePublicKey(curve elliptic.Curve, data []byte) (
+	*ecdsa.PublicKey, PointFormat, error,
+) {
+	x, y, format, err := ecurve.UnmarshalPoint(curve, data)
+	if err != nil {
+		return nil, 0, err
+	}
+	if isCustom(curve) {
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, format, nil
+	}
+	pub, err := ecdsa.ParseUncompressedPublicKey(curve,
+		ecurve.MarshalPoint(curve, x, y, Uncompressed))
+	return pub, format, err
+}
+
+// parsePrivateKey parses the fixed-length private key d of the curve,
+// and computes its public key.
+func parsePrivateKey(curve elliptic.Curve, d []byte) (
+	*ecdsa.PrivateKey, error,
+) {
+	if !isCustom(curve) {
+		return ecdsa.ParseRawPrivateKey(curve, d)
+	}
+	k := new(big.Int).SetBytes(d)
+	if k.Sign() == 0 || k.Cmp(curve.Params().N) >= 0 {
+		return nil, errors.New("EC private key out of range")
+	}
+	priv := &ecdsa.PrivateKey{PublicKey: ecdsa.PublicKey{Curve: curve}, D: k}
+	priv.X, priv.Y = curve.ScalarBaseMult(d)
+	return priv, nil
+}
+
+// ErrNotEC means the unpacked key is not an EC key on a supported curve.
+var ErrNotEC = errors.New("not an EC key on a supported curve")
+
+// Unpack unpacks a key package into an EC key pair.
+func Unpack(pkg *akp.OneAsymmetricKey) (
+	priv *ecdsa.PrivateKey, pub *ecdsa.PublicKey, extras []interface{},
+	err error,
+) {
+	priv, pub, extras, err = akp.UnpackWith[
+		*ecdsa.PrivateKey, *ecdsa.PublicKey](Unpacker, pkg)
+	var mismatch *akp.TypeMismatchError
+	if errors.As(err, &mismatch) || err == akp.ErrSkip {
+		return nil, nil, nil, ErrNotEC
+	}
+	return priv, pub, extras, err
+}
+
+// UnpackECDH unpacks a key package into an ECDH key pair.
+//
+// Only keys on the NIST P-256, P-384 and P-521 curves can be unpacked for
+// ECDH.
+func UnpackECDH(pkg *akp.OneAsymmetricKey) (
+	priv *ecdh.PrivateKey, pub *ecdh.PublicKey, extras []interface{},
+	err error,
+) {
+	privKey, pubKey, extras, err := Unpack(pkg)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if priv, err = privKey.ECDH(); err != nil {
+		return nil, nil, nil, err
+	}
+	if pubKey != nil {
+		if pub, err = pubKey.ECDH(); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+	return priv, pub, extras, nil
+}
+
+// Capabilities reports that EC keys can sign,
+// and agree on shared secrets if crypto/ecdh implements their curve.
+func (unpacker unpacker) Capabilities(
+	pkg *akp.OneAsymmetricKey,
+) akp.Capability {
+	curve, err := packageCurve(pkg)
+	if err != nil || curve == nil {
+		return 0
+	}
+	if !canAgree(curve) {
+		return akp.CanSign
+	}
+	return akp.CanSign | akp.CanAgree
+}
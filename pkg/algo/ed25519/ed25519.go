@@ -0,0 +1,25 @@
+// Package ed25519kp implements Ed25519 signature keys (RFC 8032),
+// as specified in RFC 8410.
+//
+// Keys are crypto/ed25519 ed25519.PrivateKey and ed25519.PublicKey values.
+//
+// The private key is a CurvePrivateKey,
+// i.e. an OCTET STRING holding the 32-byte RFC 8032 private key (the seed);
+// the public key is the 32-byte encoded point.
+// The AlgorithmIdentifier has no parameters.
+package ed25519kp
+
+import (
+	"crypto/ed25519"
+	"encoding/asn1"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+)
+
+// id-Ed25519 in RFC 8410
+var algorithmOID = asn1.ObjectIdentifier{1, 3, 101, 112}
+
+func init() {
+	akp.Packers.Register(Packer, ed25519.PrivateKey(nil))
+	akp.Unpackers.Register(Unpacker, algorithmOID)
+}
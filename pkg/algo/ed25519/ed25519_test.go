@@ -0,0 +1,316 @@
+package ed25519kp
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/asn1"
+	"encoding/hex"
+	"encoding/pem"
+	"os"
+	"reflect"
+	"testing"
+
+	"golang.org/x/crypto/ssh"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+	"github.com/harmony-one/asym-key-pkgs/pkg/openssh"
+)
+
+func generateKey(t *testing.T) (ed25519.PublicKey, ed25519.PrivateKey) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("cannot generate Ed25519 key pair: %v", err)
+	}
+	return pub, priv
+}
+
+func TestRoundTrip(t *testing.T) {
+	pub, priv := generateKey(t)
+	subtest := func(t *testing.T, pub ed25519.PublicKey) {
+		pkg, err := Packer.Pack(priv, pub)
+		if err != nil {
+			t.Fatalf("cannot pack Ed25519 key pair: %v", err)
+		}
+		priv2, pub2, extras, err := Unpack(pkg)
+		if err != nil {
+			t.Fatalf("cannot unpack Ed25519 key pair: %v", err)
+		}
+		if !priv.Equal(priv2) {
+			t.Error("reconstructed key is different from the original")
+		}
+		if pub == nil && pub2 != nil || pub != nil && !pub.Equal(pub2) {
+			t.Errorf("expected public key %x but got %x", pub, pub2)
+		}
+		if len(extras) > 0 {
+			t.Errorf("no extras were expected, but got some: %+v", extras)
+		}
+		pkg2, err := Pack(priv2, pub2, extras...)
+		if err != nil {
+			t.Fatalf("cannot repack Ed25519 key pair: %v", err)
+		}
+		if !reflect.DeepEqual(pkg, pkg2) {
+			t.Errorf("repacked key package %+v is different from %+v",
+				pkg2, pkg)
+		}
+	}
+	t.Run("WithoutPublic", func(t *testing.T) { subtest(t, nil) })
+	t.Run("WithPublic", func(t *testing.T) { subtest(t, pub) })
+}
+
+// The testdata files hold the first Ed25519 test vector of RFC 8032,
+// section 7.1 ("TEST 1"), in the RFC 8410 encoding.
+// They were generated by OpenSSL 3.0:
+//
+//	echo 302e020100300506032b657004220420<secret key> | xxd -r -p |
+//		openssl pkey -inform DER -out rfc8032.pem
+//	openssl pkey -in rfc8032.pem -pubout -out rfc8032_pub.pem
+//
+// OpenSSL derives the public key of the test vector from the secret key.
+
+const (
+	vectorPublicKey = "d75a980182b10ab7d54bfed3c964073a" +
+		"0ee172f3daa62325af021a68f707511a"
+	vectorSignature = "e5564300c360ac729086e2cc806e828a" +
+		"84877f1eb8e5d974d873e06522490155" +
+		"5fb8821590a33bacc61e39701cf9b46b" +
+		"d25bf5f0595bbe24655141438e7a100b"
+)
+
+func readPEM(t *testing.T, filename string) []byte {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		t.Fatalf("%s: no PEM block", filename)
+	}
+	return block.Bytes
+}
+
+func mustDecodeHex(t *testing.T, s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestVectors(t *testing.T) {
+	der := readPEM(t, "testdata/rfc8032.pem")
+	priv, pub, extras, err := akp.Decode(der)
+	if err != nil {
+		t.Fatalf("cannot decode Ed25519 key: %v", err)
+	}
+	if pub != nil || len(extras) > 0 {
+		t.Errorf("unexpected public key %v or extras %+v", pub, extras)
+	}
+	privKey := priv.(ed25519.PrivateKey)
+	expectedPub := ed25519.PublicKey(mustDecodeHex(t, vectorPublicKey))
+	if !expectedPub.Equal(privKey.Public()) {
+		t.Errorf("derived public key is %x; expected %x",
+			privKey.Public(), expectedPub)
+	}
+	encoded, err := akp.Encode(priv, nil)
+	if err != nil {
+		t.Fatalf("cannot encode Ed25519 key: %v", err)
+	}
+	if !bytes.Equal(encoded, der) {
+		t.Errorf("re-encoded key is %x; expected %x", encoded, der)
+	}
+	pkg, err := Pack(privKey, expectedPub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	spki, err := pkg.MarshalPublicKeyInfo()
+	if err != nil {
+		t.Fatalf("cannot marshal SubjectPublicKeyInfo: %v", err)
+	}
+	if expected := readPEM(t, "testdata/rfc8032_pub.pem"); !bytes.Equal(
+		spki, expected) {
+		t.Errorf("SubjectPublicKeyInfo is %x; expected %x", spki, expected)
+	}
+	sig, err := privKey.Sign(rand.Reader, nil, crypto.Hash(0))
+	if err != nil {
+		t.Fatalf("cannot sign: %v", err)
+	}
+	if expected := mustDecodeHex(t, vectorSignature); !bytes.Equal(
+		sig, expected) {
+		t.Errorf("signature is %x; expected %x", sig, expected)
+	}
+}
+
+func TestUnpack(t *testing.T) {
+	pub, priv := generateKey(t)
+	pkg, err := Pack(priv, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherPub, _ := generateKey(t)
+	for _, test := range []struct {
+		name   string
+		modify func(pkg *akp.OneAsymmetricKey)
+	}{
+		{"ShortKey", func(pkg *akp.OneAsymmetricKey) {
+			pkg.PrivateKey, _ = asn1.Marshal(priv.Seed()[1:])
+		}},
+		{"RawKey", func(pkg *akp.OneAsymmetricKey) {
+			pkg.PrivateKey = priv.Seed()
+		}},
+		{"TrailingData", func(pkg *akp.OneAsymmetricKey) {
+			pkg.PrivateKey = append(bytes.Clone(pkg.PrivateKey), 0)
+		}},
+		{"Parameters", func(pkg *akp.OneAsymmetricKey) {
+			pkg.PrivateKeyAlgorithm.Parameters.FullBytes = asn1.NullBytes
+		}},
+		{"ShortPublicKey", func(pkg *akp.OneAsymmetricKey) {
+			pkg.PublicKey = packPublicKey(pub[1:])
+		}},
+		{"PublicKeyMismatch", func(pkg *akp.OneAsymmetricKey) {
+			pkg.PublicKey = packPublicKey(otherPub)
+		}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			pkg := *pkg
+			test.modify(&pkg)
+			if _, _, _, err = Unpack(&pkg); err == nil {
+				t.Error("Unpack succeeded")
+			}
+		})
+	}
+	t.Run("WrongAlgorithm", func(t *testing.T) {
+		pkg := *pkg
+		pkg.PrivateKeyAlgorithm.Algorithm = asn1.ObjectIdentifier{1, 2, 3}
+		if _, _, _, err = Unpack(&pkg); err != ErrNotEd25519 {
+			t.Errorf("Unpack returned %v; expected %v", err, ErrNotEd25519)
+		}
+	})
+}
+
+func TestSigner(t *testing.T) {
+	pub, priv := generateKey(t)
+	encoded, err := akp.Encode(priv, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := akp.DecodeSigner(encoded)
+	if err != nil {
+		t.Fatalf("cannot decode signer: %v", err)
+	}
+	message := []byte("hello, world")
+	sig, err := signer.Sign(rand.Reader, message, crypto.Hash(0))
+	if err != nil {
+		t.Fatalf("cannot sign: %v", err)
+	}
+	if !ed25519.Verify(pub, message, sig) {
+		t.Error("cannot verify signature")
+	}
+	var pkg akp.OneAsymmetricKey
+	if _, err = asn1.Unmarshal(encoded, &pkg); err != nil {
+		t.Fatal(err)
+	}
+	if c := pkg.Capabilities(); c != akp.CanSign {
+		t.Errorf("capabilities are %v; expected %v", c, akp.CanSign)
+	}
+}
+
+func TestSSH(t *testing.T) {
+	_, priv := generateKey(t)
+	keyType, fields, err := akp.Packers.MarshalSSHPrivateKey(priv)
+	if err != nil {
+		t.Fatalf("cannot marshal SSH private key: %v", err)
+	}
+	codec := akp.Packers.SSHPrivateKeyCodec(keyType)
+	if codec == nil {
+		t.Fatalf("no codec for %q", keyType)
+	}
+	priv2, _, rest, err := codec.UnmarshalSSHPrivateKey(keyType, fields)
+	if err != nil {
+		t.Fatalf("cannot unmarshal SSH private key: %v", err)
+	}
+	if !priv.Equal(priv2) || len(rest) > 0 {
+		t.Errorf("SSH round trip returned %x, rest %x", priv2, rest)
+	}
+
+	pkg, err := Pack(priv, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	encoded, err := openssh.Encode(pkg, nil, 0)
+	if err != nil {
+		t.Fatalf("cannot encode OpenSSH private key: %v", err)
+	}
+	priv3, err := ssh.ParseRawPrivateKey(encoded)
+	if err != nil {
+		t.Fatalf("x/crypto/ssh cannot parse key: %v", err)
+	}
+	if !priv.Equal(*priv3.(*ed25519.PrivateKey)) {
+		t.Error("x/crypto/ssh parsed a different key")
+	}
+	block, err := ssh.MarshalPrivateKey(priv, "")
+	if err != nil {
+		t.Fatalf("x/crypto/ssh cannot marshal key: %v", err)
+	}
+	if pkg, err = openssh.Unmarshal(block.Bytes, nil); err != nil {
+		t.Fatalf("cannot unmarshal OpenSSH private key: %v", err)
+	}
+	if priv4, _, _, err := Unpack(pkg); err != nil || !priv.Equal(priv4) {
+		t.Errorf("unmarshaled a different key: %v", err)
+	}
+
+	sshPub, err := ssh.NewPublicKey(priv.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+	blob, err := akp.Packers.MarshalSSHPublicKey(priv, priv.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(blob, sshPub.Marshal()) {
+		t.Errorf("SSH public key is %x; expected %x", blob, sshPub.Marshal())
+	}
+}
+
+func TestJWK(t *testing.T) {
+	// The RFC 8032 test vector is also the example of RFC 8037,
+	// appendices A.1 to A.3.
+	priv, _, _, err := akp.Decode(readPEM(t, "testdata/rfc8032.pem"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]interface{}{
+		"kty": "OKP",
+		"crv": "Ed25519",
+		"d":   "nWGxne_9WmC6hEr0kuwsxERJxWl7MmkZcDusAxyuf2A",
+		"x":   "11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo",
+	}
+	members, err := akp.Packers.MarshalJWK(priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(members, expected) {
+		t.Errorf("JWK is %+v; expected %+v", members, expected)
+	}
+	priv2, _, err := akp.Packers.UnmarshalJWK(members)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !priv.(ed25519.PrivateKey).Equal(priv2) {
+		t.Error("JWK round trip returned a different key")
+	}
+	fp, err := akp.KeyFingerprint(priv, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	thumbprint := "kPrK_qmxVWaYVA9wwBF6Iuo3vVzz7TxHCTwXBygrS4k"
+	if fp.JWKThumbprintString() != thumbprint {
+		t.Errorf("JWK thumbprint is %q; expected %q",
+			fp.JWKThumbprintString(), thumbprint)
+	}
+	members["x"] = members["d"]
+	if _, _, err = akp.Packers.UnmarshalJWK(members); err == nil {
+		t.Error("mismatching public key was accepted")
+	}
+}
@@ -0,0 +1,91 @@
+package ed25519kp
+
+import (
+	"crypto/ed25519"
+	"errors"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+	"github.com/harmony-one/asym-key-pkgs/pkg/internal/jwkfield"
+)
+
+const (
+	jwkKeyType = "OKP"
+	jwkCurve   = "Ed25519" // RFC 8037, section 2
+)
+
+// JWKThumbprintMembers returns the required members of an Ed25519 JWK
+// (RFC 8037, section 2).
+func (packer packer) JWKThumbprintMembers(pub interface{}) (
+	map[string]string, error,
+) {
+	pubKey, ok := pub.(ed25519.PublicKey)
+	if !ok {
+		return nil, akp.ErrSkip
+	}
+	if len(pubKey) != ed25519.PublicKeySize {
+		return nil, errors.New("Ed25519 public key has a wrong size")
+	}
+	return map[string]string{
+		"kty": jwkKeyType,
+		"crv": jwkCurve,
+		"x":   jwkfield.Bytes(pubKey),
+	}, nil
+}
+
+// JWKKeyTypes returns the JWK key type of Ed25519 keys.
+func (packer packer) JWKKeyTypes() []string {
+	return []string{jwkKeyType}
+}
+
+// MarshalJWK returns the members of an Ed25519 private JWK
+// (RFC 8037, section 2), whose "d" is the seed.
+func (packer packer) MarshalJWK(priv interface{}) (
+	map[string]interface{}, error,
+) {
+	privKey, ok := priv.(ed25519.PrivateKey)
+	if !ok {
+		return nil, akp.ErrSkip
+	}
+	if len(privKey) != ed25519.PrivateKeySize {
+		return nil, errors.New("Ed25519 private key has a wrong size")
+	}
+	return map[string]interface{}{
+		"kty": jwkKeyType,
+		"crv": jwkCurve,
+		"x":   jwkfield.Bytes(privKey.Public().(ed25519.PublicKey)),
+		"d":   jwkfield.Bytes(privKey.Seed()),
+	}, nil
+}
+
+// UnmarshalJWK converts the members of an Ed25519 private JWK into an
+// Ed25519 key pair.
+//
+// It skips JWKs for other curves, such as X25519.
+// The public key is recomputed and must match the "x" member.
+func (packer packer) UnmarshalJWK(members map[string]interface{}) (
+	priv interface{}, pub interface{}, err error,
+) {
+	if crv, _ := members["crv"].(string); crv != jwkCurve {
+		return nil, nil, akp.ErrSkip
+	}
+	x, err := jwkfield.GetBytes(members, "x")
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, ok := members["d"]; !ok {
+		return nil, nil, errors.New("JWK has no private key")
+	}
+	d, err := jwkfield.GetBytes(members, "d")
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(d) != ed25519.SeedSize {
+		return nil, nil, errors.New("bad Ed25519 private key length")
+	}
+	privKey := ed25519.NewKeyFromSeed(d)
+	pubKey := privKey.Public().(ed25519.PublicKey)
+	if !pubKey.Equal(ed25519.PublicKey(x)) {
+		return nil, nil, errors.New("public key does not match private key")
+	}
+	return privKey, pubKey, nil
+}
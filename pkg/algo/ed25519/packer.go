@@ -0,0 +1,64 @@
+package ed25519kp
+
+import (
+	"crypto/ed25519"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+)
+
+type packer struct{}
+
+// Packer is the singleton packer instance.
+var Packer packer
+
+func (packer packer) Pack(
+	priv interface{}, pub interface{}, options ...interface{},
+) (pkg *akp.OneAsymmetricKey, err error) {
+	privKey, ok := priv.(ed25519.PrivateKey)
+	if !ok {
+		return nil, akp.ErrSkip
+	}
+	if pub == nil {
+		return Pack(privKey, nil, options...)
+	}
+	pubKey, ok := pub.(ed25519.PublicKey)
+	if !ok {
+		return nil, akp.ErrSkip
+	}
+	return Pack(privKey, pubKey, options...)
+}
+
+// Pack packs the given Ed25519 key pair into a key package.
+func Pack(
+	privKey ed25519.PrivateKey, pubKey ed25519.PublicKey, options ...interface{},
+) (pkg *akp.OneAsymmetricKey, err error) {
+	if len(privKey) != ed25519.PrivateKeySize {
+		return nil, errors.New("Ed25519 private key has a wrong size")
+	}
+	pkg = &akp.OneAsymmetricKey{
+		Version:             akp.V1,
+		PrivateKeyAlgorithm: pkix.AlgorithmIdentifier{Algorithm: algorithmOID},
+	}
+	// RFC 8410, section 7: CurvePrivateKey ::= OCTET STRING
+	if pkg.PrivateKey, err = asn1.Marshal(privKey.Seed()); err != nil {
+		return nil, err
+	}
+	if pubKey != nil {
+		if len(pubKey) != ed25519.PublicKeySize {
+			return nil, errors.New("Ed25519 public key has a wrong size")
+		}
+		pkg.PublicKey = packPublicKey(pubKey)
+		pkg.Version = akp.V2
+	}
+	return pkg, nil
+}
+
+func packPublicKey(pubKey ed25519.PublicKey) asn1.BitString {
+	return asn1.BitString{
+		Bytes:     append([]byte(nil), pubKey...),
+		BitLength: 8 * len(pubKey),
+	}
+}
@@ -0,0 +1,35 @@
+package ed25519kp
+
+import (
+	"crypto/ed25519"
+	"encoding/asn1"
+	"errors"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+)
+
+// DerivePublicKey returns the public portion of an Ed25519 private key.
+func (packer packer) DerivePublicKey(priv interface{}) (
+	pub interface{}, err error,
+) {
+	privKey, ok := priv.(ed25519.PrivateKey)
+	if !ok {
+		return nil, akp.ErrSkip
+	}
+	if len(privKey) != ed25519.PrivateKeySize {
+		return nil, errors.New("Ed25519 private key has a wrong size")
+	}
+	return privKey.Public(), nil
+}
+
+// PackPublicKey packs an Ed25519 public key as its encoded point.
+func (packer packer) PackPublicKey(pub interface{}) (asn1.BitString, error) {
+	pubKey, ok := pub.(ed25519.PublicKey)
+	if !ok {
+		return asn1.BitString{}, akp.ErrSkip
+	}
+	if len(pubKey) != ed25519.PublicKeySize {
+		return asn1.BitString{}, errors.New("Ed25519 public key has a wrong size")
+	}
+	return packPublicKey(pubKey), nil
+}
@@ -0,0 +1,84 @@
+package ed25519kp
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"errors"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+	"github.com/harmony-one/asym-key-pkgs/pkg/internal/sshwire"
+)
+
+const sshKeyType = "ssh-ed25519"
+
+// MarshalSSHPublicKey encodes an Ed25519 public key in the "ssh-ed25519"
+// format (RFC 8709, section 4).
+func (packer packer) MarshalSSHPublicKey(pub interface{}) ([]byte, error) {
+	pubKey, ok := pub.(ed25519.PublicKey)
+	if !ok {
+		return nil, akp.ErrSkip
+	}
+	if len(pubKey) != ed25519.PublicKeySize {
+		return nil, errors.New("Ed25519 public key has a wrong size")
+	}
+	var b sshwire.Builder
+	b.String([]byte(sshKeyType)).String(pubKey)
+	return b.Bytes(), nil
+}
+
+// SSHKeyTypes returns the SSH key type name of Ed25519 keys.
+func (packer packer) SSHKeyTypes() []string {
+	return []string{sshKeyType}
+}
+
+// MarshalSSHPrivateKey encodes an Ed25519 private key as the OpenSSH fields
+// public key and private key, the latter being the seed followed by the
+// public key, as in crypto/ed25519.
+func (packer packer) MarshalSSHPrivateKey(priv interface{}) (
+	keyType string, fields []byte, err error,
+) {
+	privKey, ok := priv.(ed25519.PrivateKey)
+	if !ok {
+		return "", nil, akp.ErrSkip
+	}
+	if len(privKey) != ed25519.PrivateKeySize {
+		return "", nil, errors.New("Ed25519 private key has a wrong size")
+	}
+	var b sshwire.Builder
+	b.String(privKey.Public().(ed25519.PublicKey)).String(privKey)
+	return sshKeyType, b.Bytes(), nil
+}
+
+// UnmarshalSSHPrivateKey parses the OpenSSH fields of an Ed25519 private
+// key.
+//
+// The public key is recomputed from the seed and must match both copies
+// of it.
+func (packer packer) UnmarshalSSHPrivateKey(keyType string, data []byte) (
+	priv interface{}, pub interface{}, rest []byte, err error,
+) {
+	if keyType != sshKeyType {
+		return nil, nil, nil, akp.ErrSkip
+	}
+	p := sshwire.NewParser(data)
+	pubBytes, err := p.String()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	privBytes, err := p.String()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if len(pubBytes) != ed25519.PublicKeySize ||
+		len(privBytes) != ed25519.PrivateKeySize {
+		return nil, nil, nil, errors.New("Ed25519 key has a wrong size")
+	}
+	privKey := ed25519.NewKeyFromSeed(privBytes[:ed25519.SeedSize])
+	pubKey := privKey.Public().(ed25519.PublicKey)
+	if !bytes.Equal(pubKey, pubBytes) ||
+		!bytes.Equal(pubKey, privBytes[ed25519.SeedSize:]) {
+		return nil, nil, nil, errors.New(
+			"Ed25519 public key does not match private key")
+	}
+	return privKey, pubKey, p.Rest(), nil
+}
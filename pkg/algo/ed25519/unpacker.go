@@ -0,0 +1,84 @@
+package ed25519kp
+
+import (
+	"crypto/ed25519"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+)
+
+type unpacker struct{}
+
+// Unpacker is the singleton Ed25519 unpacker instance.
+var Unpacker unpacker
+
+// Unpack unpacks an Ed25519 private key,
+// and derives the public key from it.
+//
+// If the key package contains the public key, it must match.
+func (unpacker unpacker) Unpack(pkg *akp.OneAsymmetricKey) (
+	priv interface{}, pub interface{}, extras []interface{}, err error,
+) {
+	if !pkg.PrivateKeyAlgorithm.Algorithm.Equal(algorithmOID) {
+		return nil, nil, nil, akp.ErrSkip
+	}
+	if len(pkg.PrivateKeyAlgorithm.Parameters.FullBytes) > 0 {
+		return nil, nil, nil, errors.New("Ed25519 key has parameters")
+	}
+	var seed []byte
+	rest, err := asn1.Unmarshal(pkg.PrivateKey, &seed)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf(
+			"cannot unmarshal private key: %v", err)
+	}
+	if len(rest) > 0 {
+		return nil, nil, nil, errors.New("extra data after Ed25519 private key")
+	}
+	if len(seed) != ed25519.SeedSize {
+		return nil, nil, nil, fmt.Errorf(
+			"Ed25519 private key is %d bytes; expected %d",
+			len(seed), ed25519.SeedSize)
+	}
+	privKey := ed25519.NewKeyFromSeed(seed)
+	if pkg.PublicKey.Bytes == nil {
+		return privKey, nil, nil, nil
+	}
+	pubBytes := pkg.PublicKey.RightAlign()
+	if len(pubBytes) != ed25519.PublicKeySize {
+		return nil, nil, nil, fmt.Errorf(
+			"Ed25519 public key is %d bytes; expected %d",
+			len(pubBytes), ed25519.PublicKeySize)
+	}
+	pubKey := ed25519.PublicKey(pubBytes)
+	if !pubKey.Equal(privKey.Public()) {
+		return nil, nil, nil, errors.New(
+			"Ed25519 public key does not match private key")
+	}
+	return privKey, pubKey, nil, nil
+}
+
+// ErrNotEd25519 means the unpacked key is not an Ed25519 key.
+var ErrNotEd25519 = errors.New("not an Ed25519 key")
+
+// Unpack unpacks a key package into an Ed25519 key pair.
+func Unpack(pkg *akp.OneAsymmetricKey) (
+	priv ed25519.PrivateKey, pub ed25519.PublicKey, extras []interface{},
+	err error,
+) {
+	priv, pub, extras, err = akp.UnpackWith[
+		ed25519.PrivateKey, ed25519.PublicKey](Unpacker, pkg)
+	var mismatch *akp.TypeMismatchError
+	if errors.As(err, &mismatch) || err == akp.ErrSkip {
+		return nil, nil, nil, ErrNotEd25519
+	}
+	return priv, pub, extras, err
+}
+
+// Capabilities reports that Ed25519 keys can sign.
+func (unpacker unpacker) Capabilities(
+	pkg *akp.OneAsymmetricKey,
+) akp.Capability {
+	return akp.CanSign
+}
@@ -0,0 +1,27 @@
+// Package ed448kp implements Ed448 signature keys (RFC 8032),
+// as specified in RFC 8410.
+//
+// Keys are the ed448.PrivateKey and ed448.PublicKey values of
+// github.com/cloudflare/circl/sign/ed448.
+//
+// The private key is a CurvePrivateKey,
+// i.e. an OCTET STRING holding the 57-byte RFC 8032 private key;
+// the public key is the 57-byte encoded point.
+// The AlgorithmIdentifier has no parameters.
+package ed448kp
+
+import (
+	"encoding/asn1"
+
+	"github.com/cloudflare/circl/sign/ed448"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+)
+
+// id-Ed448 in RFC 8410
+var algorithmOID = asn1.ObjectIdentifier{1, 3, 101, 113}
+
+func init() {
+	akp.Packers.Register(Packer, ed448.PrivateKey(nil))
+	akp.Unpackers.Register(Unpacker, algorithmOID)
+}
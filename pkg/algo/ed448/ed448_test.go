@@ -0,0 +1,276 @@
+package ed448kp
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"encoding/asn1"
+	"encoding/hex"
+	"encoding/pem"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/cloudflare/circl/sign/ed448"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+)
+
+func generateKey(t *testing.T) (ed448.PublicKey, ed448.PrivateKey) {
+	pub, priv, err := ed448.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("cannot generate Ed448 key pair: %v", err)
+	}
+	return pub, priv
+}
+
+func TestRoundTrip(t *testing.T) {
+	pub, priv := generateKey(t)
+	subtest := func(t *testing.T, pub ed448.PublicKey) {
+		pkg, err := Packer.Pack(priv, pub)
+		if err != nil {
+			t.Fatalf("cannot pack Ed448 key pair: %v", err)
+		}
+		priv2, pub2, extras, err := Unpack(pkg)
+		if err != nil {
+			t.Fatalf("cannot unpack Ed448 key pair: %v", err)
+		}
+		if !priv.Equal(priv2) {
+			t.Error("reconstructed key is different from the original")
+		}
+		if pub == nil && pub2 != nil || pub != nil && !pub.Equal(pub2) {
+			t.Errorf("expected public key %x but got %x", pub, pub2)
+		}
+		if len(extras) > 0 {
+			t.Errorf("no extras were expected, but got some: %+v", extras)
+		}
+		pkg2, err := Pack(priv2, pub2, extras...)
+		if err != nil {
+			t.Fatalf("cannot repack Ed448 key pair: %v", err)
+		}
+		if !reflect.DeepEqual(pkg, pkg2) {
+			t.Errorf("repacked key package %+v is different from %+v",
+				pkg2, pkg)
+		}
+	}
+	t.Run("WithoutPublic", func(t *testing.T) { subtest(t, nil) })
+	t.Run("WithPublic", func(t *testing.T) { subtest(t, pub) })
+}
+
+// RFC 8410 has no Ed448 example, so the testdata files hold the first
+// Ed448 test vector of RFC 8032, section 7.4 ("Blank"),
+// in the RFC 8410 encoding. They were generated by OpenSSL 3.0:
+//
+//	echo 3047020100300506032b6571043b0439<secret key> | xxd -r -p |
+//		openssl pkey -inform DER -out rfc8032.pem
+//	openssl pkey -in rfc8032.pem -pubout -out rfc8032_pub.pem
+//
+// OpenSSL derives the public key of the test vector from the secret key.
+
+const (
+	vectorPublicKey = "5fd7449b59b461fd2ce787ec616ad46a1da1342485a70e1f8a0e" +
+		"a75d80e96778edf124769b46c7061bd6783df1e50f6cd1fa1abeafe8256180"
+	vectorSignature = "533a37f6bbe457251f023c0d88f976ae2dfb504a843e34d2074f" +
+		"d823d41a591f2b233f034f628281f2fd7a22ddd47d7828c59bd0a21bfd3980ff0d" +
+		"2028d4b18a9df63e006c5d1c2d345b925d8dc00b4104852db99ac5c7cdda8530a1" +
+		"13a0f4dbb61149f05a7363268c71d95808ff2e652600"
+)
+
+func readPEM(t *testing.T, filename string) []byte {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		t.Fatalf("%s: no PEM block", filename)
+	}
+	return block.Bytes
+}
+
+func mustDecodeHex(t *testing.T, s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestVectors(t *testing.T) {
+	der := readPEM(t, "testdata/rfc8032.pem")
+	priv, pub, extras, err := akp.Decode(der)
+	if err != nil {
+		t.Fatalf("cannot decode Ed448 key: %v", err)
+	}
+	if pub != nil || len(extras) > 0 {
+		t.Errorf("unexpected public key %v or extras %+v", pub, extras)
+	}
+	privKey := priv.(ed448.PrivateKey)
+	expectedPub := ed448.PublicKey(mustDecodeHex(t, vectorPublicKey))
+	if !expectedPub.Equal(privKey.Public()) {
+		t.Errorf("derived public key is %x; expected %x",
+			privKey.Public(), expectedPub)
+	}
+	encoded, err := akp.Encode(priv, nil)
+	if err != nil {
+		t.Fatalf("cannot encode Ed448 key: %v", err)
+	}
+	if !bytes.Equal(encoded, der) {
+		t.Errorf("re-encoded key is %x; expected %x", encoded, der)
+	}
+	pkg, err := Pack(privKey, expectedPub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	spki, err := pkg.MarshalPublicKeyInfo()
+	if err != nil {
+		t.Fatalf("cannot marshal SubjectPublicKeyInfo: %v", err)
+	}
+	if expected := readPEM(t, "testdata/rfc8032_pub.pem"); !bytes.Equal(
+		spki, expected) {
+		t.Errorf("SubjectPublicKeyInfo is %x; expected %x", spki, expected)
+	}
+	sig, err := privKey.Sign(rand.Reader, nil, crypto.Hash(0))
+	if err != nil {
+		t.Fatalf("cannot sign: %v", err)
+	}
+	if expected := mustDecodeHex(t, vectorSignature); !bytes.Equal(
+		sig, expected) {
+		t.Errorf("signature is %x; expected %x", sig, expected)
+	}
+}
+
+func TestUnpack(t *testing.T) {
+	pub, priv := generateKey(t)
+	pkg, err := Pack(priv, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherPub, _ := generateKey(t)
+	for _, test := range []struct {
+		name   string
+		modify func(pkg *akp.OneAsymmetricKey)
+	}{
+		{"ShortKey", func(pkg *akp.OneAsymmetricKey) {
+			pkg.PrivateKey, _ = asn1.Marshal(priv.Seed()[1:])
+		}},
+		{"RawKey", func(pkg *akp.OneAsymmetricKey) {
+			pkg.PrivateKey = priv.Seed()
+		}},
+		{"TrailingData", func(pkg *akp.OneAsymmetricKey) {
+			pkg.PrivateKey = append(bytes.Clone(pkg.PrivateKey), 0)
+		}},
+		{"Parameters", func(pkg *akp.OneAsymmetricKey) {
+			pkg.PrivateKeyAlgorithm.Parameters.FullBytes = asn1.NullBytes
+		}},
+		{"ShortPublicKey", func(pkg *akp.OneAsymmetricKey) {
+			pkg.PublicKey = packPublicKey(pub[1:])
+		}},
+		{"PublicKeyMismatch", func(pkg *akp.OneAsymmetricKey) {
+			pkg.PublicKey = packPublicKey(otherPub)
+		}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			pkg := *pkg
+			test.modify(&pkg)
+			if _, _, _, err = Unpack(&pkg); err == nil {
+				t.Error("Unpack succeeded")
+			}
+		})
+	}
+	t.Run("WrongAlgorithm", func(t *testing.T) {
+		pkg := *pkg
+		pkg.PrivateKeyAlgorithm.Algorithm = asn1.ObjectIdentifier{1, 2, 3}
+		if _, _, _, err = Unpack(&pkg); err != ErrNotEd448 {
+			t.Errorf("Unpack returned %v; expected %v", err, ErrNotEd448)
+		}
+	})
+}
+
+func TestSigner(t *testing.T) {
+	pub, priv := generateKey(t)
+	encoded, err := akp.Encode(priv, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := akp.DecodeSigner(encoded)
+	if err != nil {
+		t.Fatalf("cannot decode signer: %v", err)
+	}
+	message := []byte("hello, world")
+	sig, err := signer.Sign(rand.Reader, message, crypto.Hash(0))
+	if err != nil {
+		t.Fatalf("cannot sign: %v", err)
+	}
+	if !ed448.Verify(pub, message, sig, "") {
+		t.Error("cannot verify signature")
+	}
+	var pkg akp.OneAsymmetricKey
+	if _, err = asn1.Unmarshal(encoded, &pkg); err != nil {
+		t.Fatal(err)
+	}
+	if c := pkg.Capabilities(); c != akp.CanSign {
+		t.Errorf("capabilities are %v; expected %v", c, akp.CanSign)
+	}
+}
+
+func TestJWK(t *testing.T) {
+	priv, _, _, err := akp.Decode(readPEM(t, "testdata/rfc8032.pem"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]interface{}{
+		"kty": "OKP",
+		"crv": "Ed448",
+		"d": "bIKlYsuAjRDWMr6JyFE-v2ySnzTd-oyfY8mWDvbjSKNSjIo_zC8ETjmj_FuUSS-" +
+			"PAy51SaIAmPlb",
+		"x": "X9dEm1m0Yf0s54fsYWrUah2hNCSFpw4fig6nXYDpZ3jt8SR2m0bHBhvWeD3x5Q9" +
+			"s0foavq_oJWGA",
+	}
+	members, err := akp.Packers.MarshalJWK(priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(members, expected) {
+		t.Errorf("JWK is %+v; expected %+v", members, expected)
+	}
+	priv2, _, err := akp.Packers.UnmarshalJWK(members)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !priv.(ed448.PrivateKey).Equal(priv2) {
+		t.Error("JWK round trip returned a different key")
+	}
+	fp, err := akp.KeyFingerprint(priv, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// SHA-256 of {"crv":"Ed448","kty":"OKP","x":"..."} (RFC 7638).
+	thumbprint := "zQstisLFDWZb-FiVsZl6490ATVgxw_63L-xYldKyuUY"
+	if fp.JWKThumbprintString() != thumbprint {
+		t.Errorf("JWK thumbprint is %q; expected %q",
+			fp.JWKThumbprintString(), thumbprint)
+	}
+	members["x"] = members["d"]
+	if _, _, err = akp.Packers.UnmarshalJWK(members); err == nil {
+		t.Error("mismatching public key was accepted")
+	}
+}
+
+func TestSSH(t *testing.T) {
+	priv, _, _, err := akp.Decode(readPEM(t, "testdata/rfc8032.pem"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub := priv.(ed448.PrivateKey).Public()
+	blob, err := akp.Packers.MarshalSSHPublicKey(priv, pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// string "ssh-ed448" followed by string of the 57-byte key.
+	expected := append([]byte("\x00\x00\x00\x09ssh-ed448\x00\x00\x00\x39"),
+		pub.(ed448.PublicKey)...)
+	if !bytes.Equal(blob, expected) {
+		t.Errorf("SSH public key is %x; expected %x", blob, expected)
+	}
+}
@@ -0,0 +1,65 @@
+package ed448kp
+
+import (
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+
+	"github.com/cloudflare/circl/sign/ed448"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+)
+
+type packer struct{}
+
+// Packer is the singleton packer instance.
+var Packer packer
+
+func (packer packer) Pack(
+	priv interface{}, pub interface{}, options ...interface{},
+) (pkg *akp.OneAsymmetricKey, err error) {
+	privKey, ok := priv.(ed448.PrivateKey)
+	if !ok {
+		return nil, akp.ErrSkip
+	}
+	if pub == nil {
+		return Pack(privKey, nil, options...)
+	}
+	pubKey, ok := pub.(ed448.PublicKey)
+	if !ok {
+		return nil, akp.ErrSkip
+	}
+	return Pack(privKey, pubKey, options...)
+}
+
+// Pack packs the given Ed448 key pair into a key package.
+func Pack(
+	privKey ed448.PrivateKey, pubKey ed448.PublicKey, options ...interface{},
+) (pkg *akp.OneAsymmetricKey, err error) {
+	if len(privKey) != ed448.PrivateKeySize {
+		return nil, errors.New("Ed448 private key has a wrong size")
+	}
+	pkg = &akp.OneAsymmetricKey{
+		Version:             akp.V1,
+		PrivateKeyAlgorithm: pkix.AlgorithmIdentifier{Algorithm: algorithmOID},
+	}
+	// RFC 8410, section 7: CurvePrivateKey ::= OCTET STRING
+	if pkg.PrivateKey, err = asn1.Marshal(privKey.Seed()); err != nil {
+		return nil, err
+	}
+	if pubKey != nil {
+		if len(pubKey) != ed448.PublicKeySize {
+			return nil, errors.New("Ed448 public key has a wrong size")
+		}
+		pkg.PublicKey = packPublicKey(pubKey)
+		pkg.Version = akp.V2
+	}
+	return pkg, nil
+}
+
+func packPublicKey(pubKey ed448.PublicKey) asn1.BitString {
+	return asn1.BitString{
+		Bytes:     append([]byte(nil), pubKey...),
+		BitLength: 8 * len(pubKey),
+	}
+}
@@ -0,0 +1,36 @@
+package ed448kp
+
+import (
+	"encoding/asn1"
+	"errors"
+
+	"github.com/cloudflare/circl/sign/ed448"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+)
+
+// DerivePublicKey returns the public portion of an Ed448 private key.
+func (packer packer) DerivePublicKey(priv interface{}) (
+	pub interface{}, err error,
+) {
+	privKey, ok := priv.(ed448.PrivateKey)
+	if !ok {
+		return nil, akp.ErrSkip
+	}
+	if len(privKey) != ed448.PrivateKeySize {
+		return nil, errors.New("Ed448 private key has a wrong size")
+	}
+	return privKey.Public(), nil
+}
+
+// PackPublicKey packs an Ed448 public key as its encoded point.
+func (packer packer) PackPublicKey(pub interface{}) (asn1.BitString, error) {
+	pubKey, ok := pub.(ed448.PublicKey)
+	if !ok {
+		return asn1.BitString{}, akp.ErrSkip
+	}
+	if len(pubKey) != ed448.PublicKeySize {
+		return asn1.BitString{}, errors.New("Ed448 public key has a wrong size")
+	}
+	return packPublicKey(pubKey), nil
+}
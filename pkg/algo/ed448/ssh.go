@@ -0,0 +1,30 @@
+package ed448kp
+
+import (
+	"errors"
+
+	"github.com/cloudflare/circl/sign/ed448"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+	"github.com/harmony-one/asym-key-pkgs/pkg/internal/sshwire"
+)
+
+const sshKeyType = "ssh-ed448"
+
+// MarshalSSHPublicKey encodes an Ed448 public key in the "ssh-ed448"
+// format (RFC 8709, section 4).
+//
+// OpenSSH does not support Ed448 keys, so there is no SSHPrivateKeyCodec
+// for them.
+func (packer packer) MarshalSSHPublicKey(pub interface{}) ([]byte, error) {
+	pubKey, ok := pub.(ed448.PublicKey)
+	if !ok {
+		return nil, akp.ErrSkip
+	}
+	if len(pubKey) != ed448.PublicKeySize {
+		return nil, errors.New("Ed448 public key has a wrong size")
+	}
+	var b sshwire.Builder
+	b.String([]byte(sshKeyType)).String(pubKey)
+	return b.Bytes(), nil
+}
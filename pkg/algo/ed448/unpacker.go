@@ -0,0 +1,85 @@
+package ed448kp
+
+import (
+	"encoding/asn1"
+	"errors"
+	"fmt"
+
+	"github.com/cloudflare/circl/sign/ed448"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+)
+
+type unpacker struct{}
+
+// Unpacker is the singleton Ed448 unpacker instance.
+var Unpacker unpacker
+
+// Unpack unpacks an Ed448 private key,
+// and derives the public key from it.
+//
+// If the key package contains the public key, it must match.
+func (unpacker unpacker) Unpack(pkg *akp.OneAsymmetricKey) (
+	priv interface{}, pub interface{}, extras []interface{}, err error,
+) {
+	if !pkg.PrivateKeyAlgorithm.Algorithm.Equal(algorithmOID) {
+		return nil, nil, nil, akp.ErrSkip
+	}
+	if len(pkg.PrivateKeyAlgorithm.Parameters.FullBytes) > 0 {
+		return nil, nil, nil, errors.New("Ed448 key has parameters")
+	}
+	var seed []byte
+	rest, err := asn1.Unmarshal(pkg.PrivateKey, &seed)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf(
+			"cannot unmarshal private key: %v", err)
+	}
+	if len(rest) > 0 {
+		return nil, nil, nil, errors.New("extra data after Ed448 private key")
+	}
+	if len(seed) != ed448.SeedSize {
+		return nil, nil, nil, fmt.Errorf(
+			"Ed448 private key is %d bytes; expected %d",
+			len(seed), ed448.SeedSize)
+	}
+	privKey := ed448.NewKeyFromSeed(seed)
+	if pkg.PublicKey.Bytes == nil {
+		return privKey, nil, nil, nil
+	}
+	pubBytes := pkg.PublicKey.RightAlign()
+	if len(pubBytes) != ed448.PublicKeySize {
+		return nil, nil, nil, fmt.Errorf(
+			"Ed448 public key is %d bytes; expected %d",
+			len(pubBytes), ed448.PublicKeySize)
+	}
+	pubKey := ed448.PublicKey(pubBytes)
+	if !pubKey.Equal(privKey.Public()) {
+		return nil, nil, nil, errors.New(
+			"Ed448 public key does not match private key")
+	}
+	return privKey, pubKey, nil, nil
+}
+
+// ErrNotEd448 means the unpacked key is not an Ed448 key.
+var ErrNotEd448 = errors.New("not an Ed448 key")
+
+// Unpack unpacks a key package into an Ed448 key pair.
+func Unpack(pkg *akp.OneAsymmetricKey) (
+	priv ed448.PrivateKey, pub ed448.PublicKey, extras []interface{},
+	err error,
+) {
+	priv, pub, extras, err = akp.UnpackWith[
+		ed448.PrivateKey, ed448.PublicKey](Unpacker, pkg)
+	var mismatch *akp.TypeMismatchError
+	if errors.As(err, &mismatch) || err == akp.ErrSkip {
+		return nil, nil, nil, ErrNotEd448
+	}
+	return priv, pub, extras, err
+}
+
+// Capabilities reports that Ed448 keys can sign.
+func (unpacker unpacker) Capabilities(
+	pkg *akp.OneAsymmetricKey,
+) akp.Capability {
+	return akp.CanSign
+}
@@ -0,0 +1,246 @@
+// Package gostkp implements GOST R 34.10-2012 signature keys, as specified
+// in RFC 7091, with the PKCS#8 encoding of RFC 9215 and
+// R 1323565.1.024-2019.
+//
+// Keys are *PrivateKey and *PublicKey values on the curve of one of the
+// parameter sets below, a pure-Go implementation whose scalar
+// multiplication is constant-time; the arithmetic modulo q that completes
+// a signature uses math/big.
+// The parameters of the AlgorithmIdentifier are a
+// GostR3410-2012-PublicKeyParameters, i.e. the OID of the parameter set and,
+// for the 256-bit parameter sets of CryptoPro, the OID of the Streebog-256
+// digest.
+// The private key is the OCTET STRING of its little-endian bytes,
+// and the public key is the OCTET STRING of the little-endian coordinates
+// X || Y of its point.
+package gostkp
+
+import (
+	"crypto/elliptic"
+	"encoding/asn1"
+	"math/big"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+	"github.com/harmony-one/asym-key-pkgs/pkg/internal/ecurve"
+)
+
+// id-tc26-gost3410-12-256 and id-tc26-gost3410-12-512 in RFC 9215
+var (
+	algorithm256OID = asn1.ObjectIdentifier{1, 2, 643, 7, 1, 1, 1, 1}
+	algorithm512OID = asn1.ObjectIdentifier{1, 2, 643, 7, 1, 1, 1, 2}
+)
+
+// id-tc26-gost3411-12-256 and id-tc26-gost3411-12-512 in RFC 9215
+var (
+	digest256OID = asn1.ObjectIdentifier{1, 2, 643, 7, 1, 1, 2, 2}
+	digest512OID = asn1.ObjectIdentifier{1, 2, 643, 7, 1, 1, 2, 3}
+)
+
+func init() {
+	akp.Packers.Register(Packer, &PrivateKey{})
+	akp.Unpackers.Register(Unpacker, algorithm256OID, algorithm512OID)
+}
+
+// ParamSet is a GOST R 34.10-2012 parameter set: an elliptic curve and
+// its base point.
+type ParamSet struct {
+	// Name is the name of the OID of the parameter set.
+	Name string
+
+	// OID identifies the parameter set in public key parameters.
+	OID asn1.ObjectIdentifier
+
+	curve *ecurve.Curve
+}
+
+// String returns the name of the parameter set.
+func (ps *ParamSet) String() string {
+	return ps.Name
+}
+
+// Curve returns the elliptic curve of the parameter set.
+func (ps *ParamSet) Curve() elliptic.Curve {
+	return ps.curve
+}
+
+// size returns the size in bytes of the keys and digests of the parameter
+// set: 32 or 64.
+func (ps *ParamSet) size() int {
+	return ps.curve.Params().BitSize / 8
+}
+
+// algorithmOID returns the OID of the signature algorithm of the keys.
+func (ps *ParamSet) algorithmOID() asn1.ObjectIdentifier {
+	if ps.size() == 64 {
+		return algorithm512OID
+	}
+	return algorithm256OID
+}
+
+// digestOID returns the OID of the digest that the public key parameters
+// name, or nil if they omit it (R 1323565.1.024-2019, section 3).
+func (ps *ParamSet) digestOID() asn1.ObjectIdentifier {
+	if ps.size() == 64 || ps.curve == curve256A {
+		return nil
+	}
+	return digest256OID
+}
+
+func hexInt(s string) *big.Int {
+	n, ok := new(big.Int).SetString(s, 16)
+	if !ok {
+		panic("bad hex integer " + s)
+	}
+	return n
+}
+
+func newCurve(
+	name string, bitSize int, p, a, b, q, x, y string,
+) *ecurve.Curve {
+	return ecurve.New(&elliptic.CurveParams{
+		Name:    name,
+		BitSize: bitSize,
+		P:       hexInt(p),
+		N:       hexInt(q),
+		B:       hexInt(b),
+		Gx:      hexInt(x),
+		Gy:      hexInt(y),
+	}, hexInt(a))
+}
+
+// The curves of RFC 7091, RFC 4357 and RFC 7836.
+var (
+	curve256Test = newCurve("GOST-256-Test", 256,
+		"8000000000000000000000000000000000000000000000000000000000000431",
+		"7",
+		"5FBFF498AA938CE739B8E022FBAFEF40563F6E6A3472FC2A514C0CE9DAE23B7E",
+		"8000000000000000000000000000000150FE8A1892976154C59CFC193ACCF5B3",
+		"2",
+		"08E2A8A0E65147D4BD6316030E16D19C85C97F0A9CA267122B96ABBCEA7E8FC8")
+	curve256A = newCurve("GOST-256-A", 256,
+		"FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFD97",
+		"C2173F1513981673AF4892C23035A27CE25E2013BF95AA33B22C656F277E7335",
+		"295F9BAE7428ED9CCC20E7C359A9D41A22FCCD9108E17BF7BA9337A6F8AE9513",
+		"400000000000000000000000000000000FD8CDDFC87B6635C115AF556C360C67",
+		"91E38443A5E82C0D880923425712B2BB658B9196932E02C78B2582FE742DAA28",
+		"32879423AB1A0375895786C4BB46E9565FDE0B5344766740AF268ADB32322E5C")
+	curve256B = newCurve("GOST-256-B", 256,
+		"FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFD97",
+		"-3",
+		"A6",
+		"FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF6C611070995AD10045841B09B761B893",
+		"1",
+		"8D91E471E0989CDA27DF505A453F2B7635294F2DDF23E3B122ACC99C9E9F1E14")
+	curve256C = newCurve("GOST-256-C", 256,
+		"8000000000000000000000000000000000000000000000000000000000000C99",
+		"-3",
+		"3E1AF419A269A5F866A7D3C25C3DF80AE979259373FF2B182F49D4CE7E1BBC8B",
+		"800000000000000000000000000000015F700CFFF1A624E5E497161BCC8A198F",
+		"1",
+		"3FA8124359F96680B83D1C3EB2C070E5C545C9858D03ECFB744BF8D717717EFC")
+	curve256D = newCurve("GOST-256-D", 256,
+		"9B9F605F5A858107AB1EC85E6B41C8AACF846E86789051D37998F7B9022D759B",
+		"-3",
+		"805A",
+		"9B9F605F5A858107AB1EC85E6B41C8AA582CA3511EDDFB74F02F3A6598980BB9",
+		"0",
+		"41ECE55743711A8C3CBF3783CD08C0EE4D4DC440D4641A8F366E550DFDB3BB67")
+	curve512Test = newCurve("GOST-512-Test", 512,
+		"4531ACD1FE0023C7550D267B6B2FEE80922B14B2FFB90F04D4EB7C09B5D2D15D"+
+			"F1D852741AF4704A0458047E80E4546D35B8336FAC224DD81664BBF528BE6373",
+		"7",
+		"1CFF0806A31116DA29D8CFA54E57EB748BC5F377E49400FDD788B649ECA1AC43"+
+			"61834013B2AD7322480A89CA58E0CF74BC9E540C2ADD6897FAD0A3084F302ADC",
+		"4531ACD1FE0023C7550D267B6B2FEE80922B14B2FFB90F04D4EB7C09B5D2D15D"+
+			"A82F2D7ECB1DBAC719905C5EECC423F1D86E25EDBE23C595D644AAF187E6E6DF",
+		"24D19CC64572EE30F396BF6EBBFD7A6C5213B3B3D7057CC825F91093A68CD762"+
+			"FD60611262CD838DC6B60AA7EEE804E28BC849977FAC33B4B530F1B120248A9A",
+		"2BB312A43BD2CE6E0D020613C857ACDDCFBF061E91E5F2C3F32447C259F39B2C"+
+			"83AB156D77F1496BF7EB3351E1EE4E43DC1A18B91B24640B6DBB92CB1ADD371E")
+	curve512A = newCurve("GOST-512-A", 512,
+		"FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF"+
+			"FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFDC7",
+		"-3",
+		"E8C2505DEDFC86DDC1BD0B2B6667F1DA34B82574761CB0E879BD081CFD0B6265"+
+			"EE3CB090F30D27614CB4574010DA90DD862EF9D4EBEE4761503190785A71C760",
+		"FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF"+
+			"27E69532F48D89116FF22B8D4E0560609B4B38ABFAD2B85DCACDB1411F10B275",
+		"3",
+		"7503CFE87A836AE3A61B8816E25450E6CE5E1C93ACF1ABC1778064FDCBEFA921"+
+			"DF1626BE4FD036E93D75E6A50E3A41E98028FE5FC235F5B889A589CB5215F2A4")
+	curve512B = newCurve("GOST-512-B", 512,
+		"8000000000000000000000000000000000000000000000000000000000000000"+
+			"000000000000000000000000000000000000000000000000000000000000006F",
+		"-3",
+		"687D1B459DC841457E3E06CF6F5E2517B97C7D614AF138BCBF85DC806C4B289F"+
+			"3E965D2DB1416D217F8B276FAD1AB69C50F78BEE1FA3106EFB8CCBC7C5140116",
+		"8000000000000000000000000000000000000000000000000000000000000001"+
+			"49A1EC142565A545ACFDB77BD9D40CFA8B996712101BEA0EC6346C54374F25BD",
+		"2",
+		"1A8F7EDA389B094C2C071E3647A8940F3C123B697578C213BE6DD9E6C8EC7335"+
+			"DCB228FD1EDF4A39152CBCAAF8C0398828041055F94CEEEC7E21340780FE41BD")
+	curve512C = newCurve("GOST-512-C", 512,
+		"FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF"+
+			"FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFDC7",
+		"DC9203E514A721875485A529D2C722FB187BC8980EB866644DE41C68E1430645"+
+			"46E861C0E2C9EDD92ADE71F46FCF50FF2AD97F951FDA9F2A2EB6546F39689BD3",
+		"B4C4EE28CEBC6C2C8AC12952CF37F16AC7EFB6A9F69F4B57FFDA2E4F0DE5ADE0"+
+			"38CBC2FFF719D2C18DE0284B8BFEF3B52B8CC7A5F5BF0A3C8D2319A5312557E1",
+		"3FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF"+
+			"C98CDBA46506AB004C33A9FF5147502CC8EDA9E7A769A12694623CEF47F023ED",
+		"E2E31EDFC23DE7BDEBE241CE593EF5DE2295B7A9CBAEF021D385F7074CEA043A"+
+			"A27272A7AE602BF2A7B9033DB9ED3610C6FB85487EAE97AAC5BC7928C1950148",
+		"F5CE40D95B5EB899ABBCCFF5911CB8577939804D6527378B8C108C3D2090FF9B"+
+			"E18E2D33E3021ED2EF32D85822423B6304F726AA854BAE07D0396E9A9ADDC40F")
+)
+
+// Parameter sets of RFC 7836 (id-tc26-gost-3410-12-*) and of RFC 4357
+// (id-GostR3410-2001-*), which name the same curves under other OIDs.
+var (
+	TC26_256_A = &ParamSet{"id-tc26-gost-3410-12-256-paramSetA",
+		asn1.ObjectIdentifier{1, 2, 643, 7, 1, 2, 1, 1, 1}, curve256A}
+	TC26_256_B = &ParamSet{"id-tc26-gost-3410-12-256-paramSetB",
+		asn1.ObjectIdentifier{1, 2, 643, 7, 1, 2, 1, 1, 2}, curve256B}
+	TC26_256_C = &ParamSet{"id-tc26-gost-3410-12-256-paramSetC",
+		asn1.ObjectIdentifier{1, 2, 643, 7, 1, 2, 1, 1, 3}, curve256C}
+	TC26_256_D = &ParamSet{"id-tc26-gost-3410-12-256-paramSetD",
+		asn1.ObjectIdentifier{1, 2, 643, 7, 1, 2, 1, 1, 4}, curve256D}
+	TC26_512_Test = &ParamSet{"id-tc26-gost-3410-12-512-paramSetTest",
+		asn1.ObjectIdentifier{1, 2, 643, 7, 1, 2, 1, 2, 0}, curve512Test}
+	TC26_512_A = &ParamSet{"id-tc26-gost-3410-12-512-paramSetA",
+		asn1.ObjectIdentifier{1, 2, 643, 7, 1, 2, 1, 2, 1}, curve512A}
+	TC26_512_B = &ParamSet{"id-tc26-gost-3410-12-512-paramSetB",
+		asn1.ObjectIdentifier{1, 2, 643, 7, 1, 2, 1, 2, 2}, curve512B}
+	TC26_512_C = &ParamSet{"id-tc26-gost-3410-12-512-paramSetC",
+		asn1.ObjectIdentifier{1, 2, 643, 7, 1, 2, 1, 2, 3}, curve512C}
+
+	CryptoPro_Test = &ParamSet{"id-GostR3410-2001-TestParamSet",
+		asn1.ObjectIdentifier{1, 2, 643, 2, 2, 35, 0}, curve256Test}
+	CryptoPro_A = &ParamSet{"id-GostR3410-2001-CryptoPro-A-ParamSet",
+		asn1.ObjectIdentifier{1, 2, 643, 2, 2, 35, 1}, curve256B}
+	CryptoPro_B = &ParamSet{"id-GostR3410-2001-CryptoPro-B-ParamSet",
+		asn1.ObjectIdentifier{1, 2, 643, 2, 2, 35, 2}, curve256C}
+	CryptoPro_C = &ParamSet{"id-GostR3410-2001-CryptoPro-C-ParamSet",
+		asn1.ObjectIdentifier{1, 2, 643, 2, 2, 35, 3}, curve256D}
+	CryptoPro_XchA = &ParamSet{"id-GostR3410-2001-CryptoPro-XchA-ParamSet",
+		asn1.ObjectIdentifier{1, 2, 643, 2, 2, 36, 0}, curve256B}
+	CryptoPro_XchB = &ParamSet{"id-GostR3410-2001-CryptoPro-XchB-ParamSet",
+		asn1.ObjectIdentifier{1, 2, 643, 2, 2, 36, 1}, curve256D}
+)
+
+var paramSets = []*ParamSet{
+	TC26_256_A, TC26_256_B, TC26_256_C, TC26_256_D,
+	TC26_512_Test, TC26_512_A, TC26_512_B, TC26_512_C,
+	CryptoPro_Test, CryptoPro_A, CryptoPro_B, CryptoPro_C,
+	CryptoPro_XchA, CryptoPro_XchB,
+}
+
+// paramSetOf returns the parameter set with the given OID, or nil.
+func paramSetOf(oid asn1.ObjectIdentifier) *ParamSet {
+	for _, ps := range paramSets {
+		if ps.OID.Equal(oid) {
+			return ps
+		}
+	}
+	return nil
+}
@@ -0,0 +1,318 @@
+package gostkp
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"encoding/asn1"
+	"encoding/hex"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+)
+
+// The testdata files hold the keys of the examples of RFC 7091, appendix A,
+// encoded by hand as RFC 9215 specifies, since OpenSSL 3.0 has no GOST
+// support: rfc7091_256.pem without its public key, and rfc7091_512.pem
+// with it.
+
+func readPEM(t *testing.T, filename string) []byte {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		t.Fatalf("%s: no PEM block", filename)
+	}
+	return block.Bytes
+}
+
+func readPackage(t *testing.T, filename string) *akp.OneAsymmetricKey {
+	var pkg akp.OneAsymmetricKey
+	if _, err := asn1.Unmarshal(readPEM(t, filename), &pkg); err != nil {
+		t.Fatal(err)
+	}
+	return &pkg
+}
+
+func generateKey(t *testing.T, paramSet *ParamSet) *PrivateKey {
+	priv, err := GenerateKey(rand.Reader, paramSet)
+	if err != nil {
+		t.Fatalf("cannot generate %v key pair: %v", paramSet, err)
+	}
+	return priv
+}
+
+func fromHex(t *testing.T, s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, ps := range paramSets {
+		priv := generateKey(t, ps)
+		subtest := func(t *testing.T, pub *PublicKey) {
+			pkg, err := Packer.Pack(priv, pub)
+			if err != nil {
+				t.Fatalf("cannot pack GOST key pair: %v", err)
+			}
+			priv2, pub2, extras, err := Unpack(pkg)
+			if err != nil {
+				t.Fatalf("cannot unpack GOST key pair: %v", err)
+			}
+			if !priv.Equal(priv2) || priv2.ParamSet != ps {
+				t.Error("reconstructed key is different from the original")
+			}
+			if pub == nil && pub2 != nil || pub != nil && !pub.Equal(pub2) {
+				t.Errorf("expected public key %v but got %v", pub, pub2)
+			}
+			if len(extras) > 0 {
+				t.Errorf("no extras were expected, but got some: %+v", extras)
+			}
+			pkg2, err := Pack(priv2, pub2)
+			if err != nil {
+				t.Fatalf("cannot repack GOST key pair: %v", err)
+			}
+			if !reflect.DeepEqual(pkg, pkg2) {
+				t.Errorf("repacked key package %+v is different from %+v",
+					pkg2, pkg)
+			}
+		}
+		t.Run(ps.Name+"/WithoutPublic", func(t *testing.T) { subtest(t, nil) })
+		t.Run(ps.Name+"/WithPublic", func(t *testing.T) {
+			subtest(t, &priv.PublicKey)
+		})
+	}
+}
+
+func TestVectors(t *testing.T) {
+	// RFC 7091, appendix A
+	for _, test := range []struct {
+		filename, x, y, e, k, r, s string
+	}{
+		{
+			filename: "testdata/rfc7091_256",
+			x: "7F2B49E270DB6D90D8595BEC458B50C5" +
+				"8585BA1D4E9B788F6689DBD8E56FD80B",
+			y: "26F1B489D6701DD185C8413A977B3CBB" +
+				"AF64D1C593D26627DFFB101A87FF77DA",
+			e: "2DFBC1B372D89A1188C09C52E0EEC61F" +
+				"CE52032AB1022E8E67ECE6672B043EE5",
+			k: "77105C9B20BCD3122823C8CF6FCC7B95" +
+				"6DE33814E95B7FE64FED924594DCEAB3",
+			r: "41AA28D2F1AB148280CD9ED56FEDA419" +
+				"74053554A42767B83AD043FD39DC0493",
+			s: "01456C64BA4642A1653C235A98A60249" +
+				"BCD6D3F746B631DF928014F6C5BF9C40",
+		},
+		{
+			filename: "testdata/rfc7091_512",
+			x: "115DC5BC96760C7B48598D8AB9E740D4C4A85A65BE33C1815B5C320C854621DD" +
+				"5A515856D13314AF69BC5B924C8B4DDFF75C45415C1D9DD9DD33612CD530EFE1",
+			y: "37C7C90CD40B0F5621DC3AC1B751CFA0E2634FA0503B3D52639F5D7FB72AFD61" +
+				"EA199441D943FFE7F0C70A2759A3CDB84C114E1F9339FDF27F35ECA93677BEEC",
+			e: "3754F3CFACC9E0615C4F4A7C4D8DAB531B09B6F9C170C533A71D147035B0C591" +
+				"7184EE536593F4414339976C647C5D5A407ADEDB1D560C4FC6777D2972075B8C",
+			k: "0359E7F4B1410FEACC570456C6801496946312120B39D019D455986E364F3658" +
+				"86748ED7A44B3E794434006011842286212273A6D14CF70EA3AF71BB1AE679F1",
+			r: "2F86FA60A081091A23DD795E1E3C689EE512A3C82EE0DCC2643C78EEA8FCACD3" +
+				"5492558486B20F1C9EC197C90699850260C93BCBCD9C5C3317E19344E173AE36",
+			s: "1081B394696FFE8E6585E7A9362D26B6325F56778AADBC081C0BFBE933D52FF5" +
+				"823CE288E8C4F362526080DF7F70CE406A6EEB1F56919CB92A9853BDE73E5B4A",
+		},
+	} {
+		t.Run(test.filename, func(t *testing.T) {
+			der := readPEM(t, test.filename+".pem")
+			priv, pub, _, err := akp.Decode(der)
+			if err != nil {
+				t.Fatalf("cannot decode GOST key: %v", err)
+			}
+			privKey, ok := priv.(*PrivateKey)
+			if !ok {
+				t.Fatalf("decoded key is a %T; expected *PrivateKey", priv)
+			}
+			expected := &PublicKey{
+				ParamSet: privKey.ParamSet,
+				X:        new(big.Int).SetBytes(fromHex(t, test.x)),
+				Y:        new(big.Int).SetBytes(fromHex(t, test.y)),
+			}
+			if !expected.Equal(privKey.Public()) {
+				t.Errorf("public key is %v; expected %v",
+					privKey.Public(), expected)
+			}
+			if pub != nil && !expected.Equal(pub) {
+				t.Errorf("decoded public key is %v; expected %v", pub, expected)
+			}
+			encoded, err := akp.Encode(priv, pub)
+			if err != nil {
+				t.Fatalf("cannot encode GOST key: %v", err)
+			}
+			if !bytes.Equal(encoded, der) {
+				t.Errorf("encoded key is %x; expected %x", encoded, der)
+			}
+			pkg, err := Pack(privKey, expected)
+			if err != nil {
+				t.Fatal(err)
+			}
+			spki, err := pkg.MarshalPublicKeyInfo()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if pubDER := readPEM(t, test.filename+"_pub.pem"); !bytes.Equal(
+				spki, pubDER) {
+				t.Errorf("SubjectPublicKeyInfo is %x; expected %x",
+					spki, pubDER)
+			}
+
+			// The digest is the little-endian encoding of e.
+			digest := reversed(fromHex(t, test.e))
+			sig, err := privKey.Sign(bytes.NewReader(fromHex(t, test.k)),
+				digest, crypto.Hash(0))
+			if err != nil {
+				t.Fatalf("cannot sign: %v", err)
+			}
+			expectedSig := append(fromHex(t, test.s), fromHex(t, test.r)...)
+			if !bytes.Equal(sig, expectedSig) {
+				t.Errorf("signature is %x; expected %x", sig, expectedSig)
+			}
+			if !Verify(expected, digest, sig) {
+				t.Error("cannot verify signature")
+			}
+		})
+	}
+}
+
+func TestSigner(t *testing.T) {
+	for _, ps := range []*ParamSet{TC26_256_A, TC26_512_C} {
+		encoded, err := akp.Encode(generateKey(t, ps), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		signer, err := akp.DecodeSigner(encoded)
+		if err != nil {
+			t.Fatalf("cannot decode signer: %v", err)
+		}
+		pub := signer.Public().(*PublicKey)
+		digest := make([]byte, ps.size())
+		if _, err = rand.Read(digest); err != nil {
+			t.Fatal(err)
+		}
+		sig, err := signer.Sign(rand.Reader, digest, crypto.Hash(0))
+		if err != nil {
+			t.Fatalf("cannot sign: %v", err)
+		}
+		if !Verify(pub, digest, sig) {
+			t.Errorf("cannot verify %v signature", ps)
+		}
+		digest[0] ^= 1
+		if Verify(pub, digest, sig) {
+			t.Errorf("%v signature of another digest verified", ps)
+		}
+		if _, err = signer.Sign(rand.Reader, digest[1:], crypto.Hash(0)); err ==
+			nil {
+			t.Errorf("Sign signed a %v digest of a wrong size", ps)
+		}
+		if _, err = signer.Sign(rand.Reader, digest, crypto.SHA256); err == nil {
+			t.Error("Sign signed a SHA-256 digest")
+		}
+	}
+}
+
+func TestUnpack(t *testing.T) {
+	priv := generateKey(t, CryptoPro_A)
+	pkg, err := Pack(priv, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	paramsOf := func(oids ...asn1.ObjectIdentifier) []byte {
+		params, err := asn1.Marshal(oids)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return params
+	}
+	for _, test := range []struct {
+		name   string
+		modify func(pkg *akp.OneAsymmetricKey)
+	}{
+		{"UnknownParamSet", func(pkg *akp.OneAsymmetricKey) {
+			pkg.PrivateKeyAlgorithm.Parameters.FullBytes = paramsOf(
+				asn1.ObjectIdentifier{1, 2, 643, 2, 2, 35, 4})
+		}},
+		{"ParamSetOfOtherSize", func(pkg *akp.OneAsymmetricKey) {
+			pkg.PrivateKeyAlgorithm.Parameters.FullBytes = paramsOf(
+				TC26_512_A.OID)
+		}},
+		{"WrongDigest", func(pkg *akp.OneAsymmetricKey) {
+			pkg.PrivateKeyAlgorithm.Parameters.FullBytes = paramsOf(
+				CryptoPro_A.OID, digest512OID)
+		}},
+		{"NoParameters", func(pkg *akp.OneAsymmetricKey) {
+			pkg.PrivateKeyAlgorithm.Parameters = asn1.RawValue{}
+		}},
+		{"ShortKey", func(pkg *akp.OneAsymmetricKey) {
+			pkg.PrivateKey, _ = asn1.Marshal(make([]byte, 31))
+		}},
+		{"ZeroKey", func(pkg *akp.OneAsymmetricKey) {
+			pkg.PrivateKey, _ = asn1.Marshal(make([]byte, 32))
+		}},
+		{"KeyOutOfRange", func(pkg *akp.OneAsymmetricKey) {
+			pkg.PrivateKey, _ = asn1.Marshal(CryptoPro_A.curve.Params().N)
+		}},
+		{"PublicKeyMismatch", func(pkg *akp.OneAsymmetricKey) {
+			other := generateKey(t, CryptoPro_A)
+			pkg.PublicKey, _ = packPublicKey(&other.PublicKey)
+		}},
+		{"BadPoint", func(pkg *akp.OneAsymmetricKey) {
+			pkg.PublicKey, _ = packPublicKey(&priv.PublicKey)
+			pkg.PublicKey.Bytes[len(pkg.PublicKey.Bytes)-1] ^= 1
+		}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			pkg := *pkg
+			test.modify(&pkg)
+			if _, _, _, err = Unpack(&pkg); err == nil {
+				t.Error("Unpack succeeded")
+			}
+		})
+	}
+	t.Run("Variants", func(t *testing.T) {
+		// The digest parameter set may be omitted,
+		// and the private key may be an INTEGER.
+		for _, modify := range []func(pkg *akp.OneAsymmetricKey){
+			func(pkg *akp.OneAsymmetricKey) {
+				pkg.PrivateKeyAlgorithm.Parameters.FullBytes = paramsOf(
+					CryptoPro_A.OID)
+			},
+			func(pkg *akp.OneAsymmetricKey) {
+				pkg.PrivateKey, _ = asn1.Marshal(priv.D)
+			},
+		} {
+			pkg := *pkg
+			modify(&pkg)
+			priv2, _, _, err := Unpack(&pkg)
+			if err != nil || !priv.Equal(priv2) {
+				t.Errorf("Unpack returned %v, %v; expected the original key",
+					priv2, err)
+			}
+			if c := pkg.Capabilities(); c != akp.CanSign {
+				t.Errorf("capabilities are %v; expected %v", c, akp.CanSign)
+			}
+		}
+	})
+	t.Run("WrongAlgorithm", func(t *testing.T) {
+		pkg := *pkg
+		pkg.PrivateKeyAlgorithm.Algorithm = asn1.ObjectIdentifier{1, 2, 3}
+		if _, _, _, err = Unpack(&pkg); err != ErrNotGOST {
+			t.Errorf("Unpack returned %v; expected %v", err, ErrNotGOST)
+		}
+	})
+}
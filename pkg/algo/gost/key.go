@@ -0,0 +1,161 @@
+package gostkp
+
+import (
+	"crypto"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"slices"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/internal/ecurve"
+)
+
+// PublicKey is a GOST R 34.10-2012 public key.
+type PublicKey struct {
+	ParamSet *ParamSet
+	X, Y     *big.Int
+}
+
+// Equal reports whether pub and x are the same public key,
+// possibly with different OIDs of the same curve.
+func (pub *PublicKey) Equal(x crypto.PublicKey) bool {
+	xx, ok := x.(*PublicKey)
+	return ok && pub.ParamSet.curve == xx.ParamSet.curve &&
+		pub.X.Cmp(xx.X) == 0 && pub.Y.Cmp(xx.Y) == 0
+}
+
+// PrivateKey is a GOST R 34.10-2012 private key.
+type PrivateKey struct {
+	PublicKey
+	D *big.Int
+}
+
+// GenerateKey generates a GOST R 34.10-2012 key pair with the given
+// parameter set.
+func GenerateKey(rand io.Reader, paramSet *ParamSet) (*PrivateKey, error) {
+	d, err := ecurve.RandScalar(rand, paramSet.curve.Params().N)
+	if err != nil {
+		return nil, err
+	}
+	return newPrivateKey(paramSet, d)
+}
+
+// newPrivateKey returns the private key with the given scalar,
+// with its public key computed.
+func newPrivateKey(paramSet *ParamSet, d *big.Int) (*PrivateKey, error) {
+	if d.Sign() <= 0 || d.Cmp(paramSet.curve.Params().N) >= 0 {
+		return nil, errors.New("GOST private key out of range")
+	}
+	priv := &PrivateKey{PublicKey: PublicKey{ParamSet: paramSet}, D: d}
+	priv.X, priv.Y = paramSet.curve.ScalarBaseMult(d.Bytes())
+	return priv, nil
+}
+
+// Public returns the public key.
+func (priv *PrivateKey) Public() crypto.PublicKey {
+	return &priv.PublicKey
+}
+
+// Equal reports whether priv and x are the same private key.
+func (priv *PrivateKey) Equal(x crypto.PrivateKey) bool {
+	xx, ok := x.(*PrivateKey)
+	return ok && priv.ParamSet.curve == xx.ParamSet.curve &&
+		priv.D.Cmp(xx.D) == 0
+}
+
+// digestScalar returns the integer e of the digest
+// (RFC 7091, section 6.1, steps 1 and 2).
+func (pub *PublicKey) digestScalar(digest []byte) (*big.Int, error) {
+	if size := pub.ParamSet.size(); len(digest) != size {
+		return nil, fmt.Errorf("GOST digest is %d bytes; expected %d",
+			len(digest), size)
+	}
+	// GOST R 34.11-2012 hashes are little-endian integers.
+	e := new(big.Int).SetBytes(reversed(digest))
+	if e.Mod(e, pub.ParamSet.curve.Params().N).Sign() == 0 {
+		e.SetInt64(1)
+	}
+	return e, nil
+}
+
+// Sign signs the digest, reading the random k from rand
+// (RFC 7091, section 6.1).
+//
+// The digest must be the GOST R 34.11-2012 (Streebog) hash of the message,
+// of the size of the key, which crypto.Hash does not name:
+// opts.HashFunc() must be zero.
+// The signature is s || r, as big-endian integers of the size of the key
+// (RFC 4491, section 2.2.2).
+func (priv *PrivateKey) Sign(
+	rand io.Reader, digest []byte, opts crypto.SignerOpts,
+) ([]byte, error) {
+	if opts.HashFunc() != 0 {
+		return nil, errors.New("GOST signs Streebog digests only")
+	}
+	e, err := priv.digestScalar(digest)
+	if err != nil {
+		return nil, err
+	}
+	curve := priv.ParamSet.curve
+	q := curve.Params().N
+	for {
+		k, err := ecurve.RandScalar(rand, q)
+		if err != nil {
+			return nil, err
+		}
+		r, _ := curve.ScalarBaseMult(k.Bytes())
+		if r.Mod(r, q).Sign() == 0 {
+			continue
+		}
+		// s = (rd + ke) mod q
+		s := new(big.Int).Mul(r, priv.D)
+		s.Add(s, k.Mul(k, e))
+		if s.Mod(s, q).Sign() == 0 {
+			continue
+		}
+		size := priv.ParamSet.size()
+		sig := make([]byte, 2*size)
+		s.FillBytes(sig[:size])
+		r.FillBytes(sig[size:])
+		return sig, nil
+	}
+}
+
+// Verify reports whether sig is a valid GOST R 34.10-2012 signature of the
+// digest under the public key (RFC 7091, section 6.2).
+func Verify(pub *PublicKey, digest, sig []byte) bool {
+	size := pub.ParamSet.size()
+	if len(sig) != 2*size {
+		return false
+	}
+	e, err := pub.digestScalar(digest)
+	if err != nil {
+		return false
+	}
+	curve := pub.ParamSet.curve
+	q := curve.Params().N
+	s := new(big.Int).SetBytes(sig[:size])
+	r := new(big.Int).SetBytes(sig[size:])
+	if r.Sign() <= 0 || r.Cmp(q) >= 0 || s.Sign() <= 0 || s.Cmp(q) >= 0 {
+		return false
+	}
+	// z1 = s/e, z2 = -r/e
+	v := e.ModInverse(e, q)
+	z1 := new(big.Int).Mul(s, v)
+	z1.Mod(z1, q)
+	z2 := new(big.Int).Mul(r, v)
+	z2.Sub(q, z2.Mod(z2, q))
+	x1, y1 := curve.ScalarBaseMult(z1.Bytes())
+	x2, y2 := curve.ScalarMult(pub.X, pub.Y, z2.Bytes())
+	x, _ := curve.Add(x1, y1, x2, y2)
+	return x.Mod(x, q).Cmp(r) == 0
+}
+
+// reversed returns the bytes of b in reverse order,
+// to convert between little-endian and big-endian integers.
+func reversed(b []byte) []byte {
+	b = slices.Clone(b)
+	slices.Reverse(b)
+	return b
+}
@@ -0,0 +1,94 @@
+package gostkp
+
+import (
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+)
+
+type packer struct{}
+
+// Packer is the singleton packer instance.
+var Packer packer
+
+func (packer packer) Pack(
+	priv interface{}, pub interface{}, options ...interface{},
+) (pkg *akp.OneAsymmetricKey, err error) {
+	var (
+		privKey *PrivateKey
+		pubKey  *PublicKey
+		ok      bool
+	)
+	if privKey, ok = priv.(*PrivateKey); !ok {
+		return nil, akp.ErrSkip
+	}
+	if pub == nil {
+		return Pack(privKey, nil, options...)
+	}
+	if pubKey, ok = pub.(*PublicKey); !ok {
+		return nil, akp.ErrSkip
+	}
+	return Pack(privKey, pubKey, options...)
+}
+
+// publicKeyParameters is the GostR3410-2012-PublicKeyParameters structure
+// of RFC 9215, section 5.2.
+type publicKeyParameters struct {
+	PublicKeyParamSet asn1.ObjectIdentifier
+	DigestParamSet    asn1.ObjectIdentifier `asn1:"optional"`
+}
+
+// Pack packs the given GOST R 34.10-2012 key pair into a key package.
+func Pack(
+	privKey *PrivateKey, pubKey *PublicKey, options ...interface{},
+) (pkg *akp.OneAsymmetricKey, err error) {
+	ps := privKey.ParamSet
+	if privKey.D == nil || privKey.D.Sign() <= 0 ||
+		privKey.D.Cmp(ps.curve.Params().N) >= 0 {
+		return nil, errors.New("GOST private key out of range")
+	}
+	params, err := asn1.Marshal(publicKeyParameters{
+		PublicKeyParamSet: ps.OID,
+		DigestParamSet:    ps.digestOID(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	pkg = &akp.OneAsymmetricKey{
+		Version: akp.V1,
+		PrivateKeyAlgorithm: pkix.AlgorithmIdentifier{
+			Algorithm:  ps.algorithmOID(),
+			Parameters: asn1.RawValue{FullBytes: params},
+		},
+	}
+	d := privKey.D.FillBytes(make([]byte, ps.size()))
+	if pkg.PrivateKey, err = asn1.Marshal(reversed(d)); err != nil {
+		return nil, err
+	}
+	if pubKey != nil {
+		if pubKey.ParamSet.curve != ps.curve {
+			return nil, errors.New("GOST public key is on a different curve")
+		}
+		if pkg.PublicKey, err = packPublicKey(pubKey); err != nil {
+			return nil, err
+		}
+		pkg.Version = akp.V2
+	}
+	return pkg, nil
+}
+
+// packPublicKey packs the public key as the OCTET STRING of its
+// little-endian coordinates X || Y (RFC 9215, section 5.3).
+func packPublicKey(pubKey *PublicKey) (asn1.BitString, error) {
+	size := pubKey.ParamSet.size()
+	point := make([]byte, 2*size)
+	copy(point, reversed(pubKey.X.FillBytes(make([]byte, size))))
+	copy(point[size:], reversed(pubKey.Y.FillBytes(make([]byte, size))))
+	bytes, err := asn1.Marshal(point)
+	if err != nil {
+		return asn1.BitString{}, err
+	}
+	return asn1.BitString{Bytes: bytes, BitLength: 8 * len(bytes)}, nil
+}
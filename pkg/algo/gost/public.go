@@ -0,0 +1,35 @@
+package gostkp
+
+import (
+	"encoding/asn1"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+)
+
+// DerivePublicKey returns the public portion of a GOST R 34.10-2012
+// private key.
+func (packer packer) DerivePublicKey(priv interface{}) (
+	pub interface{}, err error,
+) {
+	privKey, ok := priv.(*PrivateKey)
+	if !ok {
+		return nil, akp.ErrSkip
+	}
+	if privKey.X == nil {
+		privKey, err = newPrivateKey(privKey.ParamSet, privKey.D)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &privKey.PublicKey, nil
+}
+
+// PackPublicKey packs a GOST R 34.10-2012 public key
+// (RFC 9215, section 5.3).
+func (packer packer) PackPublicKey(pub interface{}) (asn1.BitString, error) {
+	pubKey, ok := pub.(*PublicKey)
+	if !ok {
+		return asn1.BitString{}, akp.ErrSkip
+	}
+	return packPublicKey(pubKey)
+}
@@ -0,0 +1,163 @@
+package gostkp
+
+import (
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+)
+
+type unpacker struct{}
+
+// Unpacker is the singleton GOST R 34.10-2012 unpacker instance.
+var Unpacker unpacker
+
+// packageParamSet returns the parameter set of the key package.
+//
+// The digest parameter set may be omitted, but must otherwise be the
+// Streebog digest of the size of the key.
+func packageParamSet(pkg *akp.OneAsymmetricKey) (*ParamSet, error) {
+	alg := pkg.PrivateKeyAlgorithm
+	var params publicKeyParameters
+	rest, err := asn1.Unmarshal(alg.Parameters.FullBytes, &params)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot unmarshal GOST public key parameters: %v", err)
+	}
+	if len(rest) > 0 {
+		return nil, errors.New("extra data after GOST public key parameters")
+	}
+	ps := paramSetOf(params.PublicKeyParamSet)
+	if ps == nil {
+		return nil, fmt.Errorf("unknown GOST parameter set %v",
+			params.PublicKeyParamSet)
+	}
+	if !ps.algorithmOID().Equal(alg.Algorithm) {
+		return nil, fmt.Errorf("GOST parameter set %v is not for algorithm %v",
+			ps, alg.Algorithm)
+	}
+	digestOID := digest256OID
+	if ps.size() == 64 {
+		digestOID = digest512OID
+	}
+	if params.DigestParamSet != nil && !params.DigestParamSet.Equal(digestOID) {
+		return nil, fmt.Errorf("unsupported GOST digest parameter set %v",
+			params.DigestParamSet)
+	}
+	return ps, nil
+}
+
+// Unpack unpacks a GOST R 34.10-2012 private key.
+//
+// The private key is the OCTET STRING of its little-endian bytes, or,
+// as some encoders write it, an INTEGER.
+// The public key, if any, must match the private key.
+func (unpacker unpacker) Unpack(pkg *akp.OneAsymmetricKey) (
+	priv interface{}, pub interface{}, extras []interface{}, err error,
+) {
+	alg := pkg.PrivateKeyAlgorithm.Algorithm
+	if !alg.Equal(algorithm256OID) && !alg.Equal(algorithm512OID) {
+		return nil, nil, nil, akp.ErrSkip
+	}
+	ps, err := packageParamSet(pkg)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	var raw asn1.RawValue
+	rest, err := asn1.Unmarshal(pkg.PrivateKey, &raw)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf(
+			"cannot unmarshal private key: %v", err)
+	}
+	if len(rest) > 0 {
+		return nil, nil, nil, errors.New("extra data after GOST private key")
+	}
+	d := new(big.Int)
+	switch {
+	case raw.Class == asn1.ClassUniversal && raw.Tag == asn1.TagOctetString:
+		if len(raw.Bytes) != ps.size() {
+			return nil, nil, nil, fmt.Errorf(
+				"GOST private key is %d bytes; expected %d",
+				len(raw.Bytes), ps.size())
+		}
+		d.SetBytes(reversed(raw.Bytes))
+	case raw.Class == asn1.ClassUniversal && raw.Tag == asn1.TagInteger:
+		if _, err = asn1.Unmarshal(pkg.PrivateKey, &d); err != nil {
+			return nil, nil, nil, fmt.Errorf(
+				"cannot unmarshal private key: %v", err)
+		}
+	default:
+		return nil, nil, nil, errors.New("malformed GOST private key")
+	}
+	privKey, err := newPrivateKey(ps, d)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if pkg.PublicKey.Bytes == nil {
+		return privKey, nil, nil, nil
+	}
+	pubKey, err := parsePublicKey(ps, pkg.PublicKey.RightAlign())
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("cannot unmarshal public key: %v", err)
+	}
+	if !pubKey.Equal(&privKey.PublicKey) {
+		return nil, nil, nil, errors.New(
+			"GOST public key does not match private key")
+	}
+	return privKey, pubKey, nil, nil
+}
+
+// parsePublicKey parses the OCTET STRING of the little-endian coordinates
+// of a point of the curve of the parameter set.
+func parsePublicKey(ps *ParamSet, data []byte) (*PublicKey, error) {
+	var point []byte
+	rest, err := asn1.Unmarshal(data, &point)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) > 0 {
+		return nil, errors.New("extra data after GOST public key")
+	}
+	size := ps.size()
+	if len(point) != 2*size {
+		return nil, fmt.Errorf("GOST public key is %d bytes; expected %d",
+			len(point), 2*size)
+	}
+	pub := &PublicKey{
+		ParamSet: ps,
+		X:        new(big.Int).SetBytes(reversed(point[:size])),
+		Y:        new(big.Int).SetBytes(reversed(point[size:])),
+	}
+	if !ps.curve.IsOnCurve(pub.X, pub.Y) {
+		return nil, errors.New("point not on curve")
+	}
+	return pub, nil
+}
+
+// ErrNotGOST means the unpacked key is not a GOST R 34.10-2012 key.
+var ErrNotGOST = errors.New("not a GOST R 34.10-2012 key")
+
+// Unpack unpacks a key package into a GOST R 34.10-2012 key pair.
+func Unpack(pkg *akp.OneAsymmetricKey) (
+	priv *PrivateKey, pub *PublicKey, extras []interface{}, err error,
+) {
+	priv, pub, extras, err = akp.UnpackWith[*PrivateKey, *PublicKey](
+		Unpacker, pkg)
+	var mismatch *akp.TypeMismatchError
+	if errors.As(err, &mismatch) || err == akp.ErrSkip {
+		return nil, nil, nil, ErrNotGOST
+	}
+	return priv, pub, extras, err
+}
+
+// Capabilities reports that GOST R 34.10-2012 keys can sign.
+func (unpacker unpacker) Capabilities(
+	pkg *akp.OneAsymmetricKey,
+) akp.Capability {
+	if _, err := packageParamSet(pkg); err != nil {
+		return 0
+	}
+	return akp.CanSign
+}
@@ -0,0 +1,308 @@
+package lmskp
+
+import (
+	"bytes"
+	"crypto"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sync"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+)
+
+// PrivateKey is an HSS private key.
+//
+// It implements akp.StatefulKey; a PrivateKey is safe for concurrent use.
+type PrivateKey struct {
+	levels []Params
+	id     []byte // I of the top-level tree
+	seed   []byte // SEED of the top-level tree
+	index  uint64 // next signature index
+	limit  uint64 // end of the usable signature indices
+
+	mu     sync.Mutex
+	trees  []*tree  // trees on path, computed on demand
+	path   []uint32 // leaves of the last signature
+	signed [][]byte // signed public keys of trees[1:]
+}
+
+// GenerateKey generates an HSS private key with the given parameter sets,
+// from the top-level tree down, e.g. two levels of
+// Params{LMS_SHA256_M32_H10, LMOTS_SHA256_N32_W4} for 2^20 signatures.
+func GenerateKey(rand io.Reader, levels ...Params) (*PrivateKey, error) {
+	id := make([]byte, idSize)
+	if _, err := io.ReadFull(rand, id); err != nil {
+		return nil, err
+	}
+	seed := make([]byte, n)
+	if _, err := io.ReadFull(rand, seed); err != nil {
+		return nil, err
+	}
+	return newPrivateKey(levels, id, seed, 0, 0)
+}
+
+// newPrivateKey checks the given key material and returns a private key,
+// whose limit is the number of signatures of the hierarchy if 0.
+func newPrivateKey(
+	levels []Params, id, seed []byte, index, limit uint64,
+) (*PrivateKey, error) {
+	if len(levels) < 1 || len(levels) > maxLevels {
+		return nil, fmt.Errorf("HSS key has %d levels; expected 1 to %d",
+			len(levels), maxLevels)
+	}
+	height := 0
+	for _, params := range levels {
+		if !params.valid() {
+			return nil, fmt.Errorf("unknown LMS/LM-OTS parameter sets %d/%d",
+				params.LMS, params.OTS)
+		}
+		height += params.LMS.height()
+	}
+	if height > maxHeight {
+		return nil, fmt.Errorf("HSS key has a total height of %d; "+
+			"expected at most %d", height, maxHeight)
+	}
+	if len(id) != idSize || len(seed) != n {
+		return nil, errors.New("HSS key has a wrong I or SEED size")
+	}
+	if limit == 0 {
+		limit = 1 << height
+	}
+	if limit > 1<<height || index > limit {
+		return nil, errors.New("HSS key has a signature index out of range")
+	}
+	return &PrivateKey{
+		levels: slices.Clone(levels),
+		id:     slices.Clone(id),
+		seed:   slices.Clone(seed),
+		index:  index,
+		limit:  limit,
+	}, nil
+}
+
+// Levels returns the parameter sets of the levels of the key's hierarchy.
+func (priv *PrivateKey) Levels() []Params {
+	return slices.Clone(priv.levels)
+}
+
+// height returns the total height of the key's hierarchy.
+func (priv *PrivateKey) height() int {
+	height := 0
+	for _, params := range priv.levels {
+		height += params.LMS.height()
+	}
+	return height
+}
+
+// matches reports whether pub can be the public key of the key,
+// comparing all but the root of the top-level tree.
+func (priv *PrivateKey) matches(pub *PublicKey) bool {
+	data := pub.data
+	return binary.BigEndian.Uint32(data) == uint32(len(priv.levels)) &&
+		LMSType(binary.BigEndian.Uint32(data[4:])) == priv.levels[0].LMS &&
+		OTSType(binary.BigEndian.Uint32(data[8:])) == priv.levels[0].OTS &&
+		bytes.Equal(data[12:12+idSize], priv.id)
+}
+
+// Public returns the public key of the key's hierarchy.
+func (priv *PrivateKey) Public() crypto.PublicKey {
+	priv.mu.Lock()
+	defer priv.mu.Unlock()
+	data := binary.BigEndian.AppendUint32(nil, uint32(len(priv.levels)))
+	return &PublicKey{data: append(data, priv.top().publicKey()...)}
+}
+
+// Equal reports whether priv and x are the same key with the same state.
+func (priv *PrivateKey) Equal(x crypto.PrivateKey) bool {
+	xx, ok := x.(*PrivateKey)
+	if !ok {
+		return false
+	}
+	if priv == xx {
+		return true
+	}
+	priv.mu.Lock()
+	defer priv.mu.Unlock()
+	xx.mu.Lock()
+	defer xx.mu.Unlock()
+	return slices.Equal(priv.levels, xx.levels) &&
+		bytes.Equal(priv.id, xx.id) && bytes.Equal(priv.seed, xx.seed) &&
+		priv.index == xx.index && priv.limit == xx.limit
+}
+
+// Remaining returns the number of signatures that the key can make.
+func (priv *PrivateKey) Remaining() uint64 {
+	priv.mu.Lock()
+	defer priv.mu.Unlock()
+	return priv.limit - priv.index
+}
+
+// Reserve splits off the next count signature indices of the key.
+func (priv *PrivateKey) Reserve(count uint64) (akp.StatefulKey, error) {
+	priv.mu.Lock()
+	defer priv.mu.Unlock()
+	if count == 0 || count > priv.limit-priv.index {
+		return nil, fmt.Errorf("cannot reserve %d of %d remaining signatures",
+			count, priv.limit-priv.index)
+	}
+	reserved := &PrivateKey{
+		levels: priv.levels,
+		id:     priv.id,
+		seed:   priv.seed,
+		index:  priv.index,
+		limit:  priv.index + count,
+	}
+	priv.index += count
+	return reserved, nil
+}
+
+// Sign signs the message with the next signature index.
+//
+// The message must not be hashed: opts.HashFunc() must be zero.
+func (priv *PrivateKey) Sign(
+	rand io.Reader, message []byte, opts crypto.SignerOpts,
+) ([]byte, error) {
+	if opts.HashFunc() != 0 {
+		return nil, errors.New("HSS signs unhashed messages only")
+	}
+	c := make([]byte, n)
+	if _, err := io.ReadFull(rand, c); err != nil {
+		return nil, err
+	}
+	priv.mu.Lock()
+	defer priv.mu.Unlock()
+	if priv.index >= priv.limit {
+		return nil, akp.ErrKeyExhausted
+	}
+	qs := priv.split(priv.index)
+	priv.index++
+	priv.prepare(qs)
+	last := len(priv.levels) - 1
+	sig := binary.BigEndian.AppendUint32(nil, uint32(last))
+	for _, signed := range priv.signed {
+		sig = append(sig, signed...)
+	}
+	return append(sig, priv.trees[last].sign(qs[last], c, message)...), nil
+}
+
+// split returns the leaves of the trees of each level that sign with the
+// given signature index.
+func (priv *PrivateKey) split(index uint64) []uint32 {
+	qs := make([]uint32, len(priv.levels))
+	for l := len(priv.levels) - 1; l >= 0; l-- {
+		h := priv.levels[l].LMS.height()
+		qs[l] = uint32(index & (1<<h - 1))
+		index >>= h
+	}
+	return qs
+}
+
+// top returns the top-level tree, computing it if needed.
+func (priv *PrivateKey) top() *tree {
+	if priv.trees == nil {
+		priv.trees = make([]*tree, len(priv.levels))
+		priv.path = make([]uint32, len(priv.levels))
+		priv.signed = make([][]byte, len(priv.levels)-1)
+	}
+	if priv.trees[0] == nil {
+		priv.trees[0] = newTree(priv.levels[0], priv.id, priv.seed)
+	}
+	return priv.trees[0]
+}
+
+// prepare computes the trees that sign with the given leaves and their
+// signed public keys, reusing those of the last signature.
+//
+// The randomizer C of the signature of a child tree is derived from SEED,
+// so a signed public key computed again is the same signature:
+// a one-time key never signs two different messages.
+func (priv *PrivateKey) prepare(qs []uint32) {
+	priv.top()
+	changed := false
+	for l := 1; l < len(priv.levels); l++ {
+		if !changed && priv.trees[l] != nil && priv.path[l-1] == qs[l-1] {
+			continue
+		}
+		parent := priv.trees[l-1]
+		id, seed := parent.child(qs[l-1])
+		priv.trees[l] = newTree(priv.levels[l], id, seed)
+		pub := priv.trees[l].publicKey()
+		c := parent.hasher.derive(parent.seed, qs[l-1], deriveC)
+		priv.signed[l-1] = append(parent.sign(qs[l-1], c, pub), pub...)
+		changed = true
+	}
+	copy(priv.path, qs)
+}
+
+// hssPublicKeySize is the size of an HSS public key: u32str(L) || pub[0].
+const hssPublicKeySize = 4 + lmsPublicKeySize
+
+// PublicKey is an HSS public key.
+type PublicKey struct {
+	data []byte
+}
+
+// ParsePublicKey parses an HSS public key (RFC 8554, section 6.1).
+func ParsePublicKey(data []byte) (*PublicKey, error) {
+	if len(data) != hssPublicKeySize {
+		return nil, fmt.Errorf("HSS public key is %d bytes; expected %d",
+			len(data), hssPublicKeySize)
+	}
+	if levels := binary.BigEndian.Uint32(data); levels < 1 ||
+		levels > maxLevels {
+		return nil, fmt.Errorf("HSS public key has %d levels", levels)
+	}
+	params := Params{
+		LMS: LMSType(binary.BigEndian.Uint32(data[4:])),
+		OTS: OTSType(binary.BigEndian.Uint32(data[8:])),
+	}
+	if !params.valid() {
+		return nil, fmt.Errorf("unknown LMS/LM-OTS parameter sets %d/%d",
+			params.LMS, params.OTS)
+	}
+	return &PublicKey{data: slices.Clone(data)}, nil
+}
+
+// Bytes returns the encoding of the public key (RFC 8554, section 6.1).
+func (pub *PublicKey) Bytes() []byte {
+	return slices.Clone(pub.data)
+}
+
+// Equal reports whether pub and x are the same public key.
+func (pub *PublicKey) Equal(x crypto.PublicKey) bool {
+	xx, ok := x.(*PublicKey)
+	return ok && bytes.Equal(pub.data, xx.data)
+}
+
+// Verify reports whether sig is a valid HSS signature of the message under
+// the public key (RFC 8554, algorithm 7).
+func Verify(pub *PublicKey, message, sig []byte) bool {
+	levels := binary.BigEndian.Uint32(pub.data)
+	if len(sig) < 4 || binary.BigEndian.Uint32(sig) != levels-1 {
+		return false
+	}
+	sig = sig[4:]
+	key := pub.data[4:]
+	for l := uint32(0); l < levels; l++ {
+		lmsSig, rest, ok := splitSignature(sig)
+		if !ok {
+			return false
+		}
+		signed := message
+		if l+1 < levels {
+			if len(rest) < lmsPublicKeySize {
+				return false
+			}
+			signed = rest[:lmsPublicKeySize]
+			rest = rest[lmsPublicKeySize:]
+		}
+		if !verifyLMS(key, signed, lmsSig) {
+			return false
+		}
+		key, sig = signed, rest
+	}
+	return len(sig) == 0
+}
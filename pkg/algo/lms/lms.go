@@ -0,0 +1,112 @@
+// Package lmskp implements stateful HSS/LMS hash-based signature keys
+// (RFC 8554), as specified in RFC 8708,
+// for the SHA-256 LMS and LM-OTS parameter sets of RFC 8554.
+//
+// Keys are *PrivateKey and *PublicKey values.
+// A private key makes one signature per index, and each index must only be
+// used once; see akp.StatefulKey and akp.StatefulSigner for keeping saved
+// keys from reusing indices.
+//
+// The public key is the HSS public key of RFC 8554, section 6.1.
+// RFC 8708 does not specify private keys; the private key here is
+//
+//	HSSPrivateKey ::= SEQUENCE {
+//	  levels     SEQUENCE SIZE (1..8) OF SEQUENCE {
+//	               lmsType   INTEGER,
+//	               lmotsType INTEGER },
+//	  identifier OCTET STRING (SIZE (16)), -- I of the top-level tree
+//	  seed       OCTET STRING (SIZE (32)), -- SEED of the top-level tree
+//	  index      INTEGER,                  -- next signature index
+//	  limit      INTEGER OPTIONAL }        -- end of the reserved indices
+//
+// The one-time private keys are derived from SEED as in RFC 8554,
+// appendix A, and the I and SEED of the lower-level trees are derived from
+// those of their parent tree in the same way.
+// The signature index counts the signatures of the whole hierarchy;
+// each tree of the hierarchy is computed in full when it is first used,
+// so trees of height 20 or more are slow to use.
+package lmskp
+
+import (
+	"encoding/asn1"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+)
+
+// id-alg-hss-lms-hashsig in the S/MIME algorithm arc
+var algorithmOID = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 16, 3, 17}
+
+func init() {
+	akp.Packers.Register(Packer, &PrivateKey{})
+	akp.Unpackers.Register(Unpacker, algorithmOID)
+}
+
+// LMSType is an LMS parameter set (RFC 8554, section 5.1).
+type LMSType uint32
+
+// LMS parameter sets
+const (
+	LMS_SHA256_M32_H5  LMSType = 5
+	LMS_SHA256_M32_H10 LMSType = 6
+	LMS_SHA256_M32_H15 LMSType = 7
+	LMS_SHA256_M32_H20 LMSType = 8
+	LMS_SHA256_M32_H25 LMSType = 9
+)
+
+// height returns the tree height of the parameter set, or 0 if unknown.
+func (t LMSType) height() int {
+	if t < LMS_SHA256_M32_H5 || t > LMS_SHA256_M32_H25 {
+		return 0
+	}
+	return 5 * int(t-LMS_SHA256_M32_H5+1)
+}
+
+// OTSType is an LM-OTS parameter set (RFC 8554, section 4.1).
+type OTSType uint32
+
+// LM-OTS parameter sets
+const (
+	LMOTS_SHA256_N32_W1 OTSType = 1
+	LMOTS_SHA256_N32_W2 OTSType = 2
+	LMOTS_SHA256_N32_W4 OTSType = 3
+	LMOTS_SHA256_N32_W8 OTSType = 4
+)
+
+// params returns the Winternitz parameter w, the number p of n-byte string
+// elements in a signature, and the checksum shift ls of the parameter set,
+// or all 0 if unknown.
+func (t OTSType) params() (w, p, ls int) {
+	switch t {
+	case LMOTS_SHA256_N32_W1:
+		return 1, 265, 7
+	case LMOTS_SHA256_N32_W2:
+		return 2, 133, 6
+	case LMOTS_SHA256_N32_W4:
+		return 4, 67, 4
+	case LMOTS_SHA256_N32_W8:
+		return 8, 34, 0
+	}
+	return 0, 0, 0
+}
+
+// Params are the parameter sets of one level of an HSS hierarchy.
+type Params struct {
+	LMS LMSType
+	OTS OTSType
+}
+
+// valid reports whether both parameter sets are known.
+func (params Params) valid() bool {
+	w, _, _ := params.OTS.params()
+	return params.LMS.height() > 0 && w > 0
+}
+
+const (
+	n         = 32 // hash output size of all parameter sets
+	idSize    = 16 // size of the tree identifier I
+	maxLevels = 8  // maximum number of HSS levels
+
+	// maxHeight is the maximum total height of an HSS hierarchy,
+	// so that signature indices are positive 64-bit integers.
+	maxHeight = 63
+)
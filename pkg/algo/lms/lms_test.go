@@ -0,0 +1,365 @@
+package lmskp
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"encoding/asn1"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+)
+
+// levels is a small two-level hierarchy of 2^10 signatures.
+var levels = []Params{
+	{LMS_SHA256_M32_H5, LMOTS_SHA256_N32_W8},
+	{LMS_SHA256_M32_H5, LMOTS_SHA256_N32_W4},
+}
+
+func generateKey(t *testing.T, levels ...Params) *PrivateKey {
+	priv, err := GenerateKey(rand.Reader, levels...)
+	if err != nil {
+		t.Fatalf("cannot generate HSS key: %v", err)
+	}
+	return priv
+}
+
+func sign(t *testing.T, signer crypto.Signer, message []byte) []byte {
+	sig, err := signer.Sign(rand.Reader, message, crypto.Hash(0))
+	if err != nil {
+		t.Fatalf("cannot sign: %v", err)
+	}
+	return sig
+}
+
+func TestSign(t *testing.T) {
+	priv := generateKey(t, levels...)
+	pub := priv.Public().(*PublicKey)
+	message := []byte("hello, world")
+	var sigs [][]byte
+	// Indices 31 and 32 use different bottom-level trees.
+	for _, index := range []uint64{0, 1, 31, 32, 1023} {
+		priv.index = index
+		sig := sign(t, priv, message)
+		if !Verify(pub, message, sig) {
+			t.Errorf("cannot verify signature %d", index)
+		}
+		sigs = append(sigs, sig)
+	}
+	// The signed public key of the bottom-level tree must not change.
+	top, _, _ := splitSignature(sigs[0][4:])
+	signedSize := 4 + len(top) + lmsPublicKeySize
+	if !bytes.Equal(sigs[0][:signedSize], sigs[1][:signedSize]) {
+		t.Error("signed public keys of the same tree are different")
+	}
+	if bytes.Equal(sigs[2][:signedSize], sigs[3][:signedSize]) {
+		t.Error("signed public keys of different trees are the same")
+	}
+	if _, err := priv.Sign(rand.Reader, message, crypto.Hash(0)); err !=
+		akp.ErrKeyExhausted {
+		t.Errorf("Sign returned %v; expected %v", err, akp.ErrKeyExhausted)
+	}
+	if _, err := priv.Sign(rand.Reader, message, crypto.SHA256); err == nil {
+		t.Error("Sign signed a digest")
+	}
+	t.Run("Tampered", func(t *testing.T) {
+		if Verify(pub, []byte("hello, world!"), sigs[0]) {
+			t.Error("signature of another message verified")
+		}
+		for _, i := range []int{3, 100, len(sigs[0]) - 1} {
+			sig := bytes.Clone(sigs[0])
+			sig[i] ^= 1
+			if Verify(pub, message, sig) {
+				t.Errorf("signature with byte %d flipped verified", i)
+			}
+		}
+		if Verify(pub, message, append(bytes.Clone(sigs[0]), 0)) {
+			t.Error("signature with trailing data verified")
+		}
+		if Verify(pub, message, sigs[0][:len(sigs[0])-1]) {
+			t.Error("truncated signature verified")
+		}
+		other := generateKey(t, levels...).Public().(*PublicKey)
+		if Verify(other, message, sigs[0]) {
+			t.Error("signature verified under another public key")
+		}
+	})
+}
+
+func TestRoundTrip(t *testing.T) {
+	priv := generateKey(t, levels...)
+	reserved, err := priv.Reserve(100)
+	if err != nil {
+		t.Fatalf("cannot reserve signatures: %v", err)
+	}
+	pub := priv.Public().(*PublicKey)
+	for _, key := range []*PrivateKey{priv, reserved.(*PrivateKey)} {
+		for _, pub := range []*PublicKey{nil, pub} {
+			pkg, err := Packer.Pack(key, pub)
+			if err != nil {
+				t.Fatalf("cannot pack HSS key pair: %v", err)
+			}
+			priv2, pub2, extras, err := Unpack(pkg)
+			if err != nil {
+				t.Fatalf("cannot unpack HSS key pair: %v", err)
+			}
+			if !key.Equal(priv2) {
+				t.Error("reconstructed key is different from the original")
+			}
+			if pub == nil && pub2 != nil || pub != nil && !pub.Equal(pub2) {
+				t.Errorf("expected public key %v but got %v", pub, pub2)
+			}
+			if len(extras) > 0 {
+				t.Errorf("no extras were expected, but got some: %+v", extras)
+			}
+		}
+	}
+}
+
+func TestReserve(t *testing.T) {
+	priv := generateKey(t, Params{LMS_SHA256_M32_H5, LMOTS_SHA256_N32_W4})
+	pub := priv.Public().(*PublicKey)
+	reserved, err := priv.Reserve(3)
+	if err != nil {
+		t.Fatalf("cannot reserve signatures: %v", err)
+	}
+	if r := priv.Remaining(); r != 29 {
+		t.Errorf("key has %d remaining signatures; expected 29", r)
+	}
+	if r := reserved.Remaining(); r != 3 {
+		t.Errorf("reserved key has %d remaining signatures; expected 3", r)
+	}
+	message := []byte("hello, world")
+	for i := 0; i < 3; i++ {
+		if !Verify(pub, message, sign(t, reserved, message)) {
+			t.Error("cannot verify signature of reserved key")
+		}
+	}
+	if _, err = reserved.Sign(rand.Reader, message, crypto.Hash(0)); err !=
+		akp.ErrKeyExhausted {
+		t.Errorf("Sign returned %v; expected %v", err, akp.ErrKeyExhausted)
+	}
+	if _, err = priv.Reserve(30); err == nil {
+		t.Error("Reserve reserved more signatures than remaining")
+	}
+	if _, err = priv.Reserve(0); err == nil {
+		t.Error("Reserve reserved no signatures")
+	}
+}
+
+func TestStatefulSigner(t *testing.T) {
+	priv := generateKey(t, levels...)
+	pub := priv.Public().(*PublicKey)
+	filename := filepath.Join(t.TempDir(), "hss.der")
+	if err := akp.Save(filename, priv, pub); err != nil {
+		t.Fatal(err)
+	}
+	load := func() *PrivateKey {
+		priv, pub2, _, err := akp.Load(filename)
+		if err != nil {
+			t.Fatalf("cannot load HSS key: %v", err)
+		}
+		if !pub.Equal(pub2) {
+			t.Error("saved public key is different from the original")
+		}
+		return priv.(*PrivateKey)
+	}
+	signer, err := akp.OpenStatefulSigner(filename, 4)
+	if err != nil {
+		t.Fatalf("cannot open stateful signer: %v", err)
+	}
+	if !pub.Equal(signer.Public()) {
+		t.Error("public key of signer is different from the original")
+	}
+	message := []byte("hello, world")
+	for i := 0; i < 5; i++ {
+		if !Verify(pub, message, sign(t, signer, message)) {
+			t.Errorf("cannot verify signature %d", i)
+		}
+		// The saved state is always past the signatures made.
+		if index := load().index; index < uint64(i+1) {
+			t.Errorf("saved index is %d after %d signatures", index, i+1)
+		}
+	}
+	if index := load().index; index != 8 {
+		t.Errorf("saved index is %d after two batches; expected 8", index)
+	}
+	if r := signer.Remaining(); r != 1024-5 {
+		t.Errorf("signer has %d remaining signatures; expected %d", r, 1024-5)
+	}
+	// No other signer can open the file while the first one is open.
+	if _, err = akp.OpenStatefulSigner(filename, 4); err != akp.ErrLocked {
+		t.Errorf("OpenStatefulSigner returned %v; expected %v",
+			err, akp.ErrLocked)
+	}
+	if err = signer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = signer.Sign(nil, message, crypto.Hash(0)); err == nil {
+		t.Error("closed signer signed")
+	}
+	// A signer opened again, e.g. after a crash, skips the rest of the batch.
+	signer, err = akp.OpenStatefulSigner(filename, 4)
+	if err != nil {
+		t.Fatalf("cannot open stateful signer again: %v", err)
+	}
+	defer signer.Close() // nolint
+	sign(t, signer, message)
+	if index := load().index; index != 12 {
+		t.Errorf("saved index is %d after three batches; expected 12", index)
+	}
+}
+
+func TestStatefulSignerAttributes(t *testing.T) {
+	priv := generateKey(t, levels...)
+	pkg, err := Pack(priv, priv.Public().(*PublicKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkg.SetFriendlyName("validator")
+	pkg.SetLocalKeyID([]byte{1, 2, 3, 4})
+	encoded, err := asn1.Marshal(*pkg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	filename := filepath.Join(t.TempDir(), "hss.der")
+	if err = os.WriteFile(filename, encoded, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	signer, err := akp.OpenStatefulSigner(filename, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer signer.Close() // nolint
+	sign(t, signer, []byte("hello, world"))
+	saved, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var originalPkg, savedPkg akp.OneAsymmetricKey
+	if _, err = asn1.Unmarshal(encoded, &originalPkg); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = asn1.Unmarshal(saved, &savedPkg); err != nil {
+		t.Fatal(err)
+	}
+	// Only the private key changed in the batch save.
+	if bytes.Equal(savedPkg.PrivateKey, originalPkg.PrivateKey) {
+		t.Error("saved private key was not advanced")
+	}
+	savedPkg.PrivateKey = originalPkg.PrivateKey
+	if !reflect.DeepEqual(savedPkg, originalPkg) {
+		t.Errorf("saved key package is %+v; expected %+v",
+			savedPkg, originalPkg)
+	}
+	if name, _ := savedPkg.FriendlyName(); name != "validator" {
+		t.Errorf("saved friendly name is %q", name)
+	}
+}
+
+func TestLoadSigner(t *testing.T) {
+	priv := generateKey(t, levels...)
+	filename := filepath.Join(t.TempDir(), "hss.der")
+	if err := akp.Save(filename, priv, nil); err != nil {
+		t.Fatal(err)
+	}
+	// Two signers loaded from the same file would both sign with index 0.
+	for i := 0; i < 2; i++ {
+		if _, err := akp.LoadSigner(filename); err != akp.ErrStatefulKey {
+			t.Errorf("LoadSigner returned %v; expected %v",
+				err, akp.ErrStatefulKey)
+		}
+	}
+	if _, err := akp.Packers.Signer(priv); err != akp.ErrStatefulKey {
+		t.Errorf("Signer returned %v; expected %v", err, akp.ErrStatefulKey)
+	}
+	// Signers opened one after the other never share an index.
+	var indices []uint64
+	for _, message := range []string{"a", "b"} {
+		signer, err := akp.OpenStatefulSigner(filename, 1)
+		if err != nil {
+			t.Fatalf("cannot open stateful signer: %v", err)
+		}
+		sign(t, signer, []byte(message))
+		if err = signer.Close(); err != nil {
+			t.Fatal(err)
+		}
+		saved, _, _, err := akp.Load(filename)
+		if err != nil {
+			t.Fatal(err)
+		}
+		indices = append(indices, saved.(*PrivateKey).index)
+	}
+	if indices[0] != 1 || indices[1] != 2 {
+		t.Errorf("saved indices are %v; expected [1 2]", indices)
+	}
+}
+
+func TestUnpack(t *testing.T) {
+	priv := generateKey(t, levels...)
+	pkg, err := Pack(priv, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range []struct {
+		name string
+		key  asn1PrivateKey
+	}{
+		{"NoLevels", asn1PrivateKey{nil, priv.id, priv.seed, 0, 0}},
+		{"UnknownType", asn1PrivateKey{
+			[]asn1Level{{10, 3}}, priv.id, priv.seed, 0, 0}},
+		{"TooHigh", asn1PrivateKey{[]asn1Level{
+			{9, 3}, {9, 3}, {9, 3}}, priv.id, priv.seed, 0, 0}},
+		{"ShortSeed", asn1PrivateKey{
+			[]asn1Level{{5, 3}}, priv.id, priv.seed[1:], 0, 0}},
+		{"IndexOutOfRange", asn1PrivateKey{
+			[]asn1Level{{5, 3}}, priv.id, priv.seed, 33, 0}},
+		{"LimitOutOfRange", asn1PrivateKey{
+			[]asn1Level{{5, 3}}, priv.id, priv.seed, 0, 33}},
+		{"NegativeIndex", asn1PrivateKey{
+			[]asn1Level{{5, 3}}, priv.id, priv.seed, -1, 0}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			pkg := *pkg
+			if pkg.PrivateKey, err = asn1.Marshal(test.key); err != nil {
+				t.Fatal(err)
+			}
+			if _, _, _, err = Unpack(&pkg); err == nil {
+				t.Error("Unpack succeeded")
+			}
+		})
+	}
+	t.Run("PublicKeyMismatch", func(t *testing.T) {
+		other := generateKey(t, levels...)
+		pkg := *pkg
+		pkg.PublicKey = packPublicKey(other.Public().(*PublicKey))
+		if _, _, _, err = Unpack(&pkg); err == nil {
+			t.Error("Unpack accepted a mismatching public key")
+		}
+	})
+	t.Run("WrongAlgorithm", func(t *testing.T) {
+		pkg := *pkg
+		pkg.PrivateKeyAlgorithm.Algorithm = asn1.ObjectIdentifier{1, 2, 3}
+		if _, _, _, err = Unpack(&pkg); err != ErrNotHSS {
+			t.Errorf("Unpack returned %v; expected %v", err, ErrNotHSS)
+		}
+	})
+}
+
+func TestCapabilities(t *testing.T) {
+	priv := generateKey(t, Params{LMS_SHA256_M32_H5, LMOTS_SHA256_N32_W4})
+	encoded, err := akp.Encode(priv, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var pkg akp.OneAsymmetricKey
+	if _, err = asn1.Unmarshal(encoded, &pkg); err != nil {
+		t.Fatal(err)
+	}
+	if c := pkg.Capabilities(); c != akp.CanSign {
+		t.Errorf("capabilities are %v; expected %v", c, akp.CanSign)
+	}
+}
@@ -0,0 +1,174 @@
+package lmskp
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"hash"
+)
+
+// Domain separation constants (RFC 8554, section 3.1)
+const (
+	dPBLC = 0x8080
+	dMESG = 0x8181
+	dLEAF = 0x8282
+	dINTR = 0x8383
+)
+
+// Values of i in H(I || u32str(q) || u16str(i) || u8str(0xff) || SEED)
+// for values derived from SEED other than the one-time private keys,
+// which use values of i below the number p of their elements.
+const (
+	deriveC         = 0xfffd // randomizer C of parent tree signatures
+	deriveChildI    = 0xfffe // I of the child tree
+	deriveChildSeed = 0xffff // SEED of the child tree
+)
+
+// hasher computes the hashes of one tree, which all start with I.
+type hasher struct {
+	h   hash.Hash
+	id  []byte
+	buf []byte
+}
+
+func newHasher(id []byte) *hasher {
+	return &hasher{h: sha256.New(), id: id}
+}
+
+// start starts a hash of I || u32str(q) || u16str(i).
+func (hasher *hasher) start(q uint32, i uint16) {
+	hasher.h.Reset()
+	hasher.h.Write(hasher.id)
+	var b [6]byte
+	binary.BigEndian.PutUint32(b[:], q)
+	binary.BigEndian.PutUint16(b[4:], i)
+	hasher.h.Write(b[:])
+}
+
+func (hasher *hasher) write(data ...[]byte) {
+	for _, d := range data {
+		hasher.h.Write(d)
+	}
+}
+
+func (hasher *hasher) sum(out []byte) {
+	hasher.buf = hasher.h.Sum(hasher.buf[:0])
+	copy(out, hasher.buf)
+}
+
+// derive returns H(I || u32str(q) || u16str(i) || u8str(0xff) || SEED)
+// (RFC 8554, appendix A).
+func (hasher *hasher) derive(seed []byte, q uint32, i uint16) []byte {
+	hasher.start(q, i)
+	hasher.write([]byte{0xff}, seed)
+	out := make([]byte, n)
+	hasher.sum(out)
+	return out
+}
+
+// chain applies the steps j = from, ..., to-1 of a hash chain of element i
+// of one-time key q to tmp, in place.
+func (hasher *hasher) chain(tmp []byte, q uint32, i uint16, from, to int) {
+	for j := from; j < to; j++ {
+		hasher.start(q, i)
+		hasher.write([]byte{byte(j)}, tmp)
+		hasher.sum(tmp)
+	}
+}
+
+// coef returns the i-th w-bit value of s (RFC 8554, section 3.1.3).
+func coef(s []byte, i, w int) int {
+	return int(s[i*w/8]>>(8-(w*(i%(8/w))+w))) & (1<<w - 1)
+}
+
+// digits returns the values that the chains of a one-time signature end at:
+// the w-bit values of Q || Cksm(Q) (RFC 8554, section 4.4).
+func digits(q []byte, w, p, ls int) []int {
+	sum := 0
+	for i := 0; i < n*8/w; i++ {
+		sum += 1<<w - 1 - coef(q, i, w)
+	}
+	s := binary.BigEndian.AppendUint16(q[:n:n], uint16(sum<<ls))
+	a := make([]int, p)
+	for i := range a {
+		a[i] = coef(s, i, w)
+	}
+	return a
+}
+
+// messageHash returns Q = H(I || u32str(q) || u16str(D_MESG) || C || message).
+func (hasher *hasher) messageHash(q uint32, c, message []byte) []byte {
+	hasher.start(q, dMESG)
+	hasher.write(c, message)
+	out := make([]byte, n)
+	hasher.sum(out)
+	return out
+}
+
+// otsPublicKey returns the LM-OTS public key K of one-time key q
+// (RFC 8554, algorithm 1).
+func (hasher *hasher) otsPublicKey(
+	ots OTSType, seed []byte, q uint32,
+) []byte {
+	w, p, _ := ots.params()
+	y := make([]byte, p*n)
+	for i := 0; i < p; i++ {
+		tmp := y[i*n : (i+1)*n]
+		copy(tmp, hasher.derive(seed, q, uint16(i)))
+		hasher.chain(tmp, q, uint16(i), 0, 1<<w-1)
+	}
+	hasher.start(q, dPBLC)
+	hasher.write(y)
+	k := make([]byte, n)
+	hasher.sum(k)
+	return k
+}
+
+// otsSign returns the LM-OTS signature of the message with one-time key q
+// and randomizer C (RFC 8554, algorithm 3).
+func (hasher *hasher) otsSign(
+	ots OTSType, seed []byte, q uint32, c, message []byte,
+) []byte {
+	w, p, ls := ots.params()
+	sig := binary.BigEndian.AppendUint32(nil, uint32(ots))
+	sig = append(sig, c...)
+	for i, a := range digits(hasher.messageHash(q, c, message), w, p, ls) {
+		tmp := hasher.derive(seed, q, uint16(i))
+		hasher.chain(tmp, q, uint16(i), 0, a)
+		sig = append(sig, tmp...)
+	}
+	return sig
+}
+
+// otsSignatureSize returns the size of an LM-OTS signature of the given
+// type, or 0 if the type is unknown.
+func otsSignatureSize(ots OTSType) int {
+	_, p, _ := ots.params()
+	if p == 0 {
+		return 0
+	}
+	return 4 + n + p*n
+}
+
+var errBadSignature = errors.New("malformed LMS signature")
+
+// otsCandidate returns the candidate public key Kc that the LM-OTS signature
+// sig of the message with one-time key q is valid for
+// (RFC 8554, algorithm 4b).
+// The type of sig must be ots and its size must be right.
+func (hasher *hasher) otsCandidate(
+	ots OTSType, q uint32, sig, message []byte,
+) []byte {
+	w, p, ls := ots.params()
+	c, y := sig[4:4+n], sig[4+n:]
+	z := make([]byte, p*n)
+	copy(z, y)
+	for i, a := range digits(hasher.messageHash(q, c, message), w, p, ls) {
+		hasher.chain(z[i*n:(i+1)*n], q, uint16(i), a, 1<<w-1)
+	}
+	hasher.start(q, dPBLC)
+	hasher.write(z)
+	k := make([]byte, n)
+	hasher.sum(k)
+	return k
+}
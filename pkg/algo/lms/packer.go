@@ -0,0 +1,100 @@
+package lmskp
+
+import (
+	"crypto"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+)
+
+type packer struct{}
+
+// Packer is the singleton packer instance.
+var Packer packer
+
+func (packer packer) Pack(
+	priv interface{}, pub interface{}, options ...interface{},
+) (pkg *akp.OneAsymmetricKey, err error) {
+	privKey, ok := priv.(*PrivateKey)
+	if !ok {
+		return nil, akp.ErrSkip
+	}
+	var pubKey *PublicKey
+	switch pub := pub.(type) {
+	case nil:
+	case *PublicKey:
+		pubKey = pub
+	default:
+		return nil, akp.ErrSkip
+	}
+	return Pack(privKey, pubKey, options...)
+}
+
+type asn1Level struct {
+	LMSType   int
+	LMOTSType int
+}
+
+type asn1PrivateKey struct {
+	Levels     []asn1Level
+	Identifier []byte
+	Seed       []byte
+	Index      int64
+	Limit      int64 `asn1:"optional"`
+}
+
+// Pack packs the given HSS key pair, including the state of the private key,
+// into a key package.
+//
+// The public key must match the private key;
+// the root of its top-level tree is not checked,
+// which would take computing the tree.
+func Pack(
+	privKey *PrivateKey, pubKey *PublicKey, options ...interface{},
+) (pkg *akp.OneAsymmetricKey, err error) {
+	privKey.mu.Lock()
+	key := asn1PrivateKey{
+		Identifier: privKey.id,
+		Seed:       privKey.seed,
+		Index:      int64(privKey.index),
+	}
+	if privKey.limit < 1<<privKey.height() {
+		key.Limit = int64(privKey.limit)
+	}
+	privKey.mu.Unlock()
+	for _, params := range privKey.levels {
+		key.Levels = append(key.Levels,
+			asn1Level{int(params.LMS), int(params.OTS)})
+	}
+	pkg = &akp.OneAsymmetricKey{
+		Version:             akp.V1,
+		PrivateKeyAlgorithm: pkix.AlgorithmIdentifier{Algorithm: algorithmOID},
+	}
+	if pkg.PrivateKey, err = asn1.Marshal(key); err != nil {
+		return nil, err
+	}
+	if pubKey != nil {
+		if !privKey.matches(pubKey) {
+			return nil, errors.New("HSS public key does not match private key")
+		}
+		pkg.PublicKey = packPublicKey(pubKey)
+		pkg.Version = akp.V2
+	}
+	return pkg, nil
+}
+
+func packPublicKey(pubKey *PublicKey) asn1.BitString {
+	return asn1.BitString{Bytes: pubKey.Bytes(), BitLength: 8 * len(pubKey.data)}
+}
+
+// Signer refuses to provide a signer for an HSS private key, which is
+// stateful: it must sign through akp.OpenStatefulSigner, so that no index
+// is used twice.
+func (packer packer) Signer(priv interface{}) (crypto.Signer, error) {
+	if _, ok := priv.(*PrivateKey); !ok {
+		return nil, akp.ErrSkip
+	}
+	return nil, akp.ErrStatefulKey
+}
@@ -0,0 +1,29 @@
+package lmskp
+
+import (
+	"encoding/asn1"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+)
+
+// DerivePublicKey returns the public portion of an HSS private key.
+// This computes the top-level tree of the key.
+func (packer packer) DerivePublicKey(priv interface{}) (
+	pub interface{}, err error,
+) {
+	privKey, ok := priv.(*PrivateKey)
+	if !ok {
+		return nil, akp.ErrSkip
+	}
+	return privKey.Public(), nil
+}
+
+// PackPublicKey packs an HSS public key as its RFC 8554 encoding,
+// which RFC 8708 puts in the subject public key unwrapped.
+func (packer packer) PackPublicKey(pub interface{}) (asn1.BitString, error) {
+	pubKey, ok := pub.(*PublicKey)
+	if !ok {
+		return asn1.BitString{}, akp.ErrSkip
+	}
+	return packPublicKey(pubKey), nil
+}
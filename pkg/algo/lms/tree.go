@@ -0,0 +1,136 @@
+package lmskp
+
+import (
+	"bytes"
+	"encoding/binary"
+)
+
+// lmsPublicKeySize is the size of an LMS public key:
+// u32str(type) || u32str(otstype) || I || T[1].
+const lmsPublicKeySize = 8 + idSize + n
+
+// tree is a fully computed LMS tree (RFC 8554, section 5.3).
+type tree struct {
+	params Params
+	id     []byte
+	seed   []byte
+	hasher *hasher
+	nodes  []byte // T[r] for r = 1, ..., 2^(h+1)-1, n bytes each
+}
+
+func newTree(params Params, id, seed []byte) *tree {
+	leaves := 1 << params.LMS.height()
+	t := &tree{
+		params: params,
+		id:     id,
+		seed:   seed,
+		hasher: newHasher(id),
+		nodes:  make([]byte, 2*leaves*n),
+	}
+	for q := 0; q < leaves; q++ {
+		k := t.hasher.otsPublicKey(params.OTS, seed, uint32(q))
+		r := leaves + q
+		t.hasher.start(uint32(r), dLEAF)
+		t.hasher.write(k)
+		t.hasher.sum(t.node(r))
+	}
+	for r := leaves - 1; r >= 1; r-- {
+		t.hasher.start(uint32(r), dINTR)
+		t.hasher.write(t.node(2*r), t.node(2*r+1))
+		t.hasher.sum(t.node(r))
+	}
+	return t
+}
+
+func (t *tree) node(r int) []byte {
+	return t.nodes[r*n : (r+1)*n]
+}
+
+// publicKey returns the LMS public key of the tree.
+func (t *tree) publicKey() []byte {
+	pub := binary.BigEndian.AppendUint32(nil, uint32(t.params.LMS))
+	pub = binary.BigEndian.AppendUint32(pub, uint32(t.params.OTS))
+	pub = append(pub, t.id...)
+	return append(pub, t.node(1)...)
+}
+
+// child returns the I and SEED of the tree that leaf q of the tree signs
+// in an HSS hierarchy.
+func (t *tree) child(q uint32) (id, seed []byte) {
+	id = t.hasher.derive(t.seed, q, deriveChildI)[:idSize]
+	seed = t.hasher.derive(t.seed, q, deriveChildSeed)
+	return id, seed
+}
+
+// sign returns the LMS signature of the message with leaf q and randomizer
+// C (RFC 8554, section 5.4.1).
+func (t *tree) sign(q uint32, c, message []byte) []byte {
+	h := t.params.LMS.height()
+	sig := binary.BigEndian.AppendUint32(nil, q)
+	sig = append(sig, t.hasher.otsSign(t.params.OTS, t.seed, q, c, message)...)
+	sig = binary.BigEndian.AppendUint32(sig, uint32(t.params.LMS))
+	r := 1<<h + int(q)
+	for i := 0; i < h; i++ {
+		sig = append(sig, t.node((r>>i)^1)...)
+	}
+	return sig
+}
+
+// splitSignature splits the LMS signature at the start of sig from the
+// rest, or returns false if sig does not start with one.
+func splitSignature(sig []byte) (lmsSig, rest []byte, ok bool) {
+	if len(sig) < 8 {
+		return nil, nil, false
+	}
+	size := otsSignatureSize(OTSType(binary.BigEndian.Uint32(sig[4:])))
+	if size == 0 || len(sig) < 4+size+4 {
+		return nil, nil, false
+	}
+	h := LMSType(binary.BigEndian.Uint32(sig[4+size:])).height()
+	end := 4 + size + 4 + h*n
+	if h == 0 || len(sig) < end {
+		return nil, nil, false
+	}
+	return sig[:end], sig[end:], true
+}
+
+// verifyLMS reports whether sig, as split by splitSignature,
+// is a valid LMS signature of the message under the LMS public key pub
+// (RFC 8554, algorithm 6a).
+func verifyLMS(pub, message, sig []byte) bool {
+	if len(pub) != lmsPublicKeySize {
+		return false
+	}
+	lmsType := LMSType(binary.BigEndian.Uint32(pub))
+	otsType := OTSType(binary.BigEndian.Uint32(pub[4:]))
+	id, root := pub[8:8+idSize], pub[8+idSize:]
+	h := lmsType.height()
+	q := binary.BigEndian.Uint32(sig)
+	if OTSType(binary.BigEndian.Uint32(sig[4:])) != otsType {
+		return false
+	}
+	end := 4 + otsSignatureSize(otsType)
+	if LMSType(binary.BigEndian.Uint32(sig[end:])) != lmsType ||
+		q >= 1<<h {
+		return false
+	}
+	path := sig[end+4:]
+	hasher := newHasher(id)
+	k := hasher.otsCandidate(otsType, q, sig[4:end], message)
+	r := uint32(1)<<h + q
+	tmp := make([]byte, n)
+	hasher.start(r, dLEAF)
+	hasher.write(k)
+	hasher.sum(tmp)
+	for i := 0; r > 1; i++ {
+		hasher.start(r/2, dINTR)
+		if r&1 == 1 {
+			hasher.write(path[i*n:(i+1)*n], tmp)
+		} else {
+			hasher.write(tmp, path[i*n:(i+1)*n])
+		}
+		hasher.sum(tmp)
+		r /= 2
+	}
+	return bytes.Equal(tmp, root)
+}
@@ -0,0 +1,86 @@
+package lmskp
+
+import (
+	"encoding/asn1"
+	"errors"
+	"fmt"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+)
+
+type unpacker struct{}
+
+// Unpacker is the singleton HSS unpacker instance.
+var Unpacker unpacker
+
+// Unpack unpacks an HSS private key, including its state.
+//
+// The public key, if present, must match the private key,
+// except for the root of its top-level tree, which is not checked.
+func (unpacker unpacker) Unpack(pkg *akp.OneAsymmetricKey) (
+	priv interface{}, pub interface{}, extras []interface{}, err error,
+) {
+	if !pkg.PrivateKeyAlgorithm.Algorithm.Equal(algorithmOID) {
+		return nil, nil, nil, akp.ErrSkip
+	}
+	if len(pkg.PrivateKeyAlgorithm.Parameters.FullBytes) > 0 {
+		return nil, nil, nil, errors.New("HSS key has parameters")
+	}
+	var key asn1PrivateKey
+	rest, err := asn1.Unmarshal(pkg.PrivateKey, &key)
+	if err == nil && len(rest) > 0 {
+		err = errors.New("trailing data")
+	}
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf(
+			"cannot unmarshal private key: %v", err)
+	}
+	if key.Index < 0 || key.Limit < 0 {
+		return nil, nil, nil, errors.New(
+			"HSS key has a negative signature index")
+	}
+	levels := make([]Params, len(key.Levels))
+	for i, level := range key.Levels {
+		levels[i] = Params{LMSType(level.LMSType), OTSType(level.LMOTSType)}
+	}
+	privKey, err := newPrivateKey(levels, key.Identifier, key.Seed,
+		uint64(key.Index), uint64(key.Limit))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if pkg.PublicKey.Bytes == nil {
+		return privKey, nil, nil, nil
+	}
+	pubKey, err := ParsePublicKey(pkg.PublicKey.RightAlign())
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if !privKey.matches(pubKey) {
+		return nil, nil, nil, errors.New(
+			"HSS public key does not match private key")
+	}
+	return privKey, pubKey, nil, nil
+}
+
+// ErrNotHSS means the unpacked key is not an HSS key.
+var ErrNotHSS = errors.New("not an HSS key")
+
+// Unpack unpacks a key package into an HSS key pair.
+func Unpack(pkg *akp.OneAsymmetricKey) (
+	priv *PrivateKey, pub *PublicKey, extras []interface{}, err error,
+) {
+	priv, pub, extras, err = akp.UnpackWith[*PrivateKey, *PublicKey](
+		Unpacker, pkg)
+	var mismatch *akp.TypeMismatchError
+	if errors.As(err, &mismatch) || err == akp.ErrSkip {
+		return nil, nil, nil, ErrNotHSS
+	}
+	return priv, pub, extras, err
+}
+
+// Capabilities reports that HSS keys can sign.
+func (unpacker unpacker) Capabilities(
+	pkg *akp.OneAsymmetricKey,
+) akp.Capability {
+	return akp.CanSign
+}
@@ -0,0 +1,90 @@
+// Package mldsakp implements ML-DSA (FIPS 204) signature keys,
+// as specified in draft-ietf-lamps-dilithium-certificates.
+//
+// Keys are the *PrivateKey and *PublicKey values of the mldsa44, mldsa65
+// and mldsa87 packages of github.com/cloudflare/circl/sign/mldsa.
+//
+// The private key is the 32-byte seed ξ, the expanded private key of
+// FIPS 204, or both; see Format.
+// The public key is the FIPS 204 public key encoding.
+package mldsakp
+
+import (
+	"encoding/asn1"
+
+	"github.com/cloudflare/circl/sign"
+	"github.com/cloudflare/circl/sign/mldsa/mldsa44"
+	"github.com/cloudflare/circl/sign/mldsa/mldsa65"
+	"github.com/cloudflare/circl/sign/mldsa/mldsa87"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+	"github.com/harmony-one/asym-key-pkgs/pkg/internal/pqkey"
+)
+
+// id-ml-dsa-44, id-ml-dsa-65 and id-ml-dsa-87 in the NIST algorithm arc
+var (
+	mldsa44OID = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 3, 17}
+	mldsa65OID = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 3, 18}
+	mldsa87OID = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 3, 19}
+)
+
+var parameterSets = []struct {
+	oid    asn1.ObjectIdentifier
+	scheme sign.Scheme
+}{
+	{mldsa44OID, mldsa44.Scheme()},
+	{mldsa65OID, mldsa65.Scheme()},
+	{mldsa87OID, mldsa87.Scheme()},
+}
+
+func init() {
+	akp.Packers.Register(Packer,
+		&mldsa44.PrivateKey{}, &mldsa65.PrivateKey{}, &mldsa87.PrivateKey{})
+	akp.Unpackers.Register(Unpacker, mldsa44OID, mldsa65OID, mldsa87OID)
+}
+
+// schemeOf returns the ML-DSA parameter set with the given OID, or nil.
+func schemeOf(oid asn1.ObjectIdentifier) sign.Scheme {
+	for _, set := range parameterSets {
+		if oid.Equal(set.oid) {
+			return set.scheme
+		}
+	}
+	return nil
+}
+
+// oidOf returns the OID of the given ML-DSA parameter set, or nil.
+func oidOf(scheme sign.Scheme) asn1.ObjectIdentifier {
+	for _, set := range parameterSets {
+		if scheme.Name() == set.scheme.Name() {
+			return set.oid
+		}
+	}
+	return nil
+}
+
+// Format is the private key format of an ML-DSA key package.
+//
+// Unpack returns it as an extra, and Pack takes it as an option.
+// Without this option, keys that retain their seed are packed as SeedOnly,
+// and other keys as ExpandedOnly.
+type Format = pqkey.Format
+
+// Private key formats
+const (
+	SeedOnly     = pqkey.SeedOnly     // the seed ξ
+	ExpandedOnly = pqkey.ExpandedOnly // the expanded private key
+	Both         = pqkey.Both         // the seed and the expanded key
+)
+
+// seedSize is the size of the seed ξ.
+const seedSize = mldsa65.SeedSize
+
+// seed returns the seed that a private key was generated from,
+// or nil if it does not retain it.
+func seed(privKey sign.PrivateKey) []byte {
+	if s, ok := privKey.(interface{ Seed() []byte }); ok {
+		return s.Seed()
+	}
+	return nil
+}
@@ -0,0 +1,237 @@
+package mldsakp
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"encoding/asn1"
+	"encoding/pem"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/cloudflare/circl/sign"
+	"github.com/cloudflare/circl/sign/mldsa/mldsa65"
+	"github.com/cloudflare/circl/sign/mldsa/mldsa87"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+	"github.com/harmony-one/asym-key-pkgs/pkg/internal/pqkey"
+)
+
+func generateKey(t *testing.T, scheme sign.Scheme) (
+	sign.PublicKey, sign.PrivateKey,
+) {
+	pub, priv, err := scheme.GenerateKey()
+	if err != nil {
+		t.Fatalf("cannot generate %s key pair: %v", scheme.Name(), err)
+	}
+	return pub, priv
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, set := range parameterSets {
+		pub, priv := generateKey(t, set.scheme)
+		for _, format := range []Format{SeedOnly, ExpandedOnly, Both} {
+			subtest := func(t *testing.T, pub sign.PublicKey) {
+				pkg, err := Packer.Pack(priv, pub, format)
+				if err != nil {
+					t.Fatalf("cannot pack ML-DSA key pair: %v", err)
+				}
+				priv2, pub2, extras, err := Unpack(pkg)
+				if err != nil {
+					t.Fatalf("cannot unpack ML-DSA key pair: %v", err)
+				}
+				if !priv.Equal(priv2) {
+					t.Error("reconstructed key is different from the original")
+				}
+				if pub == nil && pub2 != nil || pub != nil && !pub.Equal(pub2) {
+					t.Errorf("expected public key %v but got %v", pub, pub2)
+				}
+				if !reflect.DeepEqual(extras, []interface{}{format}) {
+					t.Errorf("extras are %+v; expected %v", extras, format)
+				}
+				pkg2, err := Pack(priv2, pub2, extras...)
+				if err != nil {
+					t.Fatalf("cannot repack ML-DSA key pair: %v", err)
+				}
+				if !reflect.DeepEqual(pkg, pkg2) {
+					t.Errorf("repacked key package %+v is different from %+v",
+						pkg2, pkg)
+				}
+			}
+			name := set.scheme.Name() + "/" + format.String()
+			t.Run(name+"/WithoutPublic", func(t *testing.T) {
+				subtest(t, nil)
+			})
+			t.Run(name+"/WithPublic", func(t *testing.T) {
+				subtest(t, pub)
+			})
+		}
+	}
+}
+
+// The testdata files were generated by the Go 1.27 crypto/mldsa and
+// crypto/x509 packages from the seed 00 01 02 ... 1f, as in the examples of
+// draft-ietf-lamps-dilithium-certificates:
+//
+//	priv, _ := mldsa.NewPrivateKey(mldsa.MLDSA44(), seed)
+//	der, _ := x509.MarshalPKCS8PrivateKey(priv)  // mldsa44.pem
+//	der, _ = x509.MarshalPKIXPublicKey(priv.PublicKey())  // mldsa44_pub.pem
+
+func readPEM(t *testing.T, filename string) []byte {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		t.Fatalf("%s: no PEM block", filename)
+	}
+	return block.Bytes
+}
+
+func TestVectors(t *testing.T) {
+	seed := make([]byte, seedSize)
+	for i := range seed {
+		seed[i] = byte(i)
+	}
+	for _, name := range []string{"mldsa44", "mldsa65", "mldsa87"} {
+		t.Run(name, func(t *testing.T) {
+			der := readPEM(t, "testdata/"+name+".pem")
+			priv, _, extras, err := akp.Decode(der)
+			if err != nil {
+				t.Fatalf("cannot decode ML-DSA key: %v", err)
+			}
+			if !reflect.DeepEqual(extras, []interface{}{SeedOnly}) {
+				t.Errorf("extras are %+v; expected %v", extras, SeedOnly)
+			}
+			privKey := priv.(sign.PrivateKey)
+			if !bytes.Equal(seed, privKey.(interface{ Seed() []byte }).Seed()) {
+				t.Error("decoded key has a different seed")
+			}
+			encoded, err := akp.Encode(priv, nil)
+			if err != nil {
+				t.Fatalf("cannot encode ML-DSA key: %v", err)
+			}
+			if !bytes.Equal(encoded, der) {
+				t.Errorf("re-encoded key is %x; expected %x", encoded, der)
+			}
+			pkg, err := Pack(privKey, privKey.Public().(sign.PublicKey))
+			if err != nil {
+				t.Fatal(err)
+			}
+			spki, err := pkg.MarshalPublicKeyInfo()
+			if err != nil {
+				t.Fatalf("cannot marshal SubjectPublicKeyInfo: %v", err)
+			}
+			expected := readPEM(t, "testdata/"+name+"_pub.pem")
+			if !bytes.Equal(spki, expected) {
+				t.Errorf("SubjectPublicKeyInfo is %x; expected %x", spki, expected)
+			}
+		})
+	}
+}
+
+func TestUnpack(t *testing.T) {
+	pub, priv := generateKey(t, mldsa65.Scheme())
+	seed := seed(priv)
+	expanded, err := priv.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherPub, other := generateKey(t, mldsa65.Scheme())
+	otherExpanded, err := other.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	badTR := bytes.Clone(expanded)
+	badTR[64] ^= 1
+	for _, test := range []struct {
+		name     string
+		format   Format
+		seed     []byte
+		expanded []byte
+		pub      sign.PublicKey
+	}{
+		{"ShortSeed", SeedOnly, seed[1:], nil, nil},
+		{"ShortExpandedKey", ExpandedOnly, nil, expanded[1:], nil},
+		{"BadPublicKeyHash", ExpandedOnly, nil, badTR, nil},
+		{"Mismatch", Both, seed, otherExpanded, nil},
+		{"PublicKeyMismatch", SeedOnly, seed, nil, otherPub},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			pkg, err := Pack(priv, pub)
+			if err != nil {
+				t.Fatal(err)
+			}
+			pkg.PrivateKey, err = pqkey.Marshal(
+				test.format, test.seed, test.expanded)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if test.pub != nil {
+				pkg.PublicKey, _ = packPublicKey(test.pub)
+			}
+			if _, _, _, err = Unpack(pkg); err == nil {
+				t.Error("Unpack succeeded")
+			}
+		})
+	}
+	t.Run("SeedlessKey", func(t *testing.T) {
+		priv2, err := mldsa65.Scheme().UnmarshalBinaryPrivateKey(expanded)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pkg, err := Pack(priv2, nil)
+		if err != nil {
+			t.Fatalf("cannot pack seedless ML-DSA key: %v", err)
+		}
+		_, _, extras, err := Unpack(pkg)
+		if err != nil || !reflect.DeepEqual(extras, []interface{}{ExpandedOnly}) {
+			t.Errorf("Unpack returned extras %+v, error %v; expected %v",
+				extras, err, ExpandedOnly)
+		}
+		if _, err = Pack(priv2, nil, SeedOnly); err == nil {
+			t.Error("Pack packed a seedless key as SeedOnly")
+		}
+	})
+	t.Run("WrongAlgorithm", func(t *testing.T) {
+		pkg, err := Pack(priv, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pkg.PrivateKeyAlgorithm.Algorithm = asn1.ObjectIdentifier{1, 2, 3}
+		if _, _, _, err = Unpack(pkg); err != ErrNotMLDSA {
+			t.Errorf("Unpack returned %v; expected %v", err, ErrNotMLDSA)
+		}
+	})
+}
+
+func TestSigner(t *testing.T) {
+	_, priv := generateKey(t, mldsa87.Scheme())
+	encoded, err := akp.Encode(priv, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := akp.DecodeSigner(encoded)
+	if err != nil {
+		t.Fatalf("cannot decode signer: %v", err)
+	}
+	message := []byte("hello, world")
+	sig, err := signer.Sign(rand.Reader, message, crypto.Hash(0))
+	if err != nil {
+		t.Fatalf("cannot sign: %v", err)
+	}
+	if !mldsa87.Scheme().Verify(
+		priv.Public().(sign.PublicKey), message, sig, nil,
+	) {
+		t.Error("cannot verify signature")
+	}
+	var pkg akp.OneAsymmetricKey
+	if _, err = asn1.Unmarshal(encoded, &pkg); err != nil {
+		t.Fatal(err)
+	}
+	if c := pkg.Capabilities(); c != akp.CanSign {
+		t.Errorf("capabilities are %v; expected %v", c, akp.CanSign)
+	}
+}
@@ -0,0 +1,100 @@
+package mldsakp
+
+import (
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+
+	"github.com/cloudflare/circl/sign"
+	"github.com/cloudflare/circl/sign/mldsa/mldsa44"
+	"github.com/cloudflare/circl/sign/mldsa/mldsa65"
+	"github.com/cloudflare/circl/sign/mldsa/mldsa87"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+	"github.com/harmony-one/asym-key-pkgs/pkg/internal/pqkey"
+)
+
+type packer struct{}
+
+// Packer is the singleton packer instance.
+var Packer packer
+
+func (packer packer) Pack(
+	priv interface{}, pub interface{}, options ...interface{},
+) (pkg *akp.OneAsymmetricKey, err error) {
+	var privKey sign.PrivateKey
+	switch priv := priv.(type) {
+	case *mldsa44.PrivateKey:
+		privKey = priv
+	case *mldsa65.PrivateKey:
+		privKey = priv
+	case *mldsa87.PrivateKey:
+		privKey = priv
+	default:
+		return nil, akp.ErrSkip
+	}
+	if pub == nil {
+		return Pack(privKey, nil, options...)
+	}
+	pubKey, ok := pub.(sign.PublicKey)
+	if !ok || pubKey.Scheme().Name() != privKey.Scheme().Name() {
+		return nil, akp.ErrSkip
+	}
+	return Pack(privKey, pubKey, options...)
+}
+
+// Pack packs the given ML-DSA key pair into a key package.
+//
+// A Format option selects the private key format; see Format for the
+// default.
+// SeedOnly and Both require a private key that retains its seed.
+func Pack(
+	privKey sign.PrivateKey, pubKey sign.PublicKey, options ...interface{},
+) (pkg *akp.OneAsymmetricKey, err error) {
+	oid := oidOf(privKey.Scheme())
+	if oid == nil {
+		return nil, errors.New("not an ML-DSA key")
+	}
+	seed := seed(privKey)
+	format := ExpandedOnly
+	if seed != nil {
+		format = SeedOnly
+	}
+	for _, option := range options {
+		if f, ok := option.(Format); ok {
+			format = f
+		}
+	}
+	if seed == nil && format != ExpandedOnly {
+		return nil, errors.New("ML-DSA private key does not retain its seed")
+	}
+	var expanded []byte
+	if format != SeedOnly {
+		if expanded, err = privKey.MarshalBinary(); err != nil {
+			return nil, err
+		}
+	}
+	pkg = &akp.OneAsymmetricKey{
+		Version:             akp.V1,
+		PrivateKeyAlgorithm: pkix.AlgorithmIdentifier{Algorithm: oid},
+	}
+	pkg.PrivateKey, err = pqkey.Marshal(format, seed, expanded)
+	if err != nil {
+		return nil, err
+	}
+	if pubKey != nil {
+		if pkg.PublicKey, err = packPublicKey(pubKey); err != nil {
+			return nil, err
+		}
+		pkg.Version = akp.V2
+	}
+	return pkg, nil
+}
+
+func packPublicKey(pubKey sign.PublicKey) (asn1.BitString, error) {
+	pubBytes, err := pubKey.MarshalBinary()
+	if err != nil {
+		return asn1.BitString{}, err
+	}
+	return asn1.BitString{Bytes: pubBytes, BitLength: 8 * len(pubBytes)}, nil
+}
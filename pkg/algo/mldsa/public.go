@@ -0,0 +1,36 @@
+package mldsakp
+
+import (
+	"encoding/asn1"
+
+	"github.com/cloudflare/circl/sign"
+	"github.com/cloudflare/circl/sign/mldsa/mldsa44"
+	"github.com/cloudflare/circl/sign/mldsa/mldsa65"
+	"github.com/cloudflare/circl/sign/mldsa/mldsa87"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+)
+
+// DerivePublicKey returns the public portion of an ML-DSA private key.
+func (packer packer) DerivePublicKey(priv interface{}) (
+	pub interface{}, err error,
+) {
+	switch privKey := priv.(type) {
+	case *mldsa44.PrivateKey:
+		return privKey.Public(), nil
+	case *mldsa65.PrivateKey:
+		return privKey.Public(), nil
+	case *mldsa87.PrivateKey:
+		return privKey.Public(), nil
+	}
+	return nil, akp.ErrSkip
+}
+
+// PackPublicKey packs an ML-DSA public key in its FIPS 204 encoding.
+func (packer packer) PackPublicKey(pub interface{}) (asn1.BitString, error) {
+	switch pubKey := pub.(type) {
+	case *mldsa44.PublicKey, *mldsa65.PublicKey, *mldsa87.PublicKey:
+		return packPublicKey(pubKey.(sign.PublicKey))
+	}
+	return asn1.BitString{}, akp.ErrSkip
+}
@@ -0,0 +1,135 @@
+package mldsakp
+
+import (
+	"bytes"
+	"crypto/sha3"
+	"errors"
+	"fmt"
+
+	"github.com/cloudflare/circl/sign"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+	"github.com/harmony-one/asym-key-pkgs/pkg/internal/pqkey"
+)
+
+type unpacker struct{}
+
+// Unpacker is the singleton ML-DSA unpacker instance.
+var Unpacker unpacker
+
+// trSize is the size of tr, the hash of the public key in the expanded
+// private key (FIPS 204, algorithm 24).
+const trSize = 64
+
+// Unpack unpacks an ML-DSA private key in any format,
+// and returns the format as an extra.
+//
+// If the key package has both the seed and the expanded key,
+// the expanded key must be the one derived from the seed.
+// An expanded key alone must be canonically encoded,
+// and its public key hash must match its public key.
+// The public key, if present, must match the private key.
+func (unpacker unpacker) Unpack(pkg *akp.OneAsymmetricKey) (
+	priv interface{}, pub interface{}, extras []interface{}, err error,
+) {
+	scheme := schemeOf(pkg.PrivateKeyAlgorithm.Algorithm)
+	if scheme == nil {
+		return nil, nil, nil, akp.ErrSkip
+	}
+	if len(pkg.PrivateKeyAlgorithm.Parameters.FullBytes) > 0 {
+		return nil, nil, nil, errors.New("ML-DSA key has parameters")
+	}
+	seed, expanded, format, err := pqkey.Unmarshal(pkg.PrivateKey)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf(
+			"cannot unmarshal private key: %v", err)
+	}
+	var privKey sign.PrivateKey
+	if seed != nil {
+		if len(seed) != seedSize {
+			return nil, nil, nil, fmt.Errorf(
+				"ML-DSA seed is %d bytes; expected %d", len(seed), seedSize)
+		}
+		_, privKey = scheme.DeriveKey(seed)
+		if expanded != nil {
+			derived, err := privKey.MarshalBinary()
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			if !bytes.Equal(expanded, derived) {
+				return nil, nil, nil, errors.New(
+					"ML-DSA expanded key does not match seed")
+			}
+		}
+	} else if privKey, err = unpackExpanded(scheme, expanded); err != nil {
+		return nil, nil, nil, err
+	}
+	extras = []interface{}{format}
+	if pkg.PublicKey.Bytes == nil {
+		return privKey, nil, extras, nil
+	}
+	pubKey, err := scheme.UnmarshalBinaryPublicKey(pkg.PublicKey.RightAlign())
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("cannot unmarshal public key: %v", err)
+	}
+	if !pubKey.Equal(privKey.Public()) {
+		return nil, nil, nil, errors.New(
+			"ML-DSA public key does not match private key")
+	}
+	return privKey, pubKey, extras, nil
+}
+
+// unpackExpanded unpacks and checks an expanded ML-DSA private key.
+func unpackExpanded(scheme sign.Scheme, expanded []byte) (
+	sign.PrivateKey, error,
+) {
+	if len(expanded) != scheme.PrivateKeySize() {
+		return nil, fmt.Errorf("ML-DSA expanded key is %d bytes; expected %d",
+			len(expanded), scheme.PrivateKeySize())
+	}
+	privKey, err := scheme.UnmarshalBinaryPrivateKey(expanded)
+	if err != nil {
+		return nil, fmt.Errorf("cannot unmarshal private key: %v", err)
+	}
+	repacked, err := privKey.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(repacked, expanded) {
+		return nil, errors.New("ML-DSA expanded key is not canonical")
+	}
+	pubBytes, err := privKey.Public().(sign.PublicKey).MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	// The expanded key is ρ || K || tr || s1 || s2 || t0.
+	tr := sha3.SumSHAKE256(pubBytes, trSize)
+	if !bytes.Equal(tr, expanded[64:64+trSize]) {
+		return nil, errors.New(
+			"ML-DSA expanded key does not match its public key")
+	}
+	return privKey, nil
+}
+
+// ErrNotMLDSA means the unpacked key is not an ML-DSA key.
+var ErrNotMLDSA = errors.New("not an ML-DSA key")
+
+// Unpack unpacks a key package into an ML-DSA key pair.
+func Unpack(pkg *akp.OneAsymmetricKey) (
+	priv sign.PrivateKey, pub sign.PublicKey, extras []interface{}, err error,
+) {
+	priv, pub, extras, err = akp.UnpackWith[sign.PrivateKey, sign.PublicKey](
+		Unpacker, pkg)
+	var mismatch *akp.TypeMismatchError
+	if errors.As(err, &mismatch) || err == akp.ErrSkip {
+		return nil, nil, nil, ErrNotMLDSA
+	}
+	return priv, pub, extras, err
+}
+
+// Capabilities reports that ML-DSA keys can sign.
+func (unpacker unpacker) Capabilities(
+	pkg *akp.OneAsymmetricKey,
+) akp.Capability {
+	return akp.CanSign
+}
@@ -0,0 +1,135 @@
+// Package mlkemkp implements ML-KEM (FIPS 203) key encapsulation keys,
+// as specified in draft-ietf-lamps-kyber-certificates.
+//
+// Private keys are *PrivateKey values, which wrap the private keys of the
+// mlkem512, mlkem768 and mlkem1024 packages of
+// github.com/cloudflare/circl/kem/mlkem and retain the seed they were
+// derived from.
+// Public keys are the circl public keys, as kem.PublicKey values.
+//
+// The private key is the 64-byte seed (d, z), the expanded decapsulation
+// key of FIPS 203, or both; see Format.
+// The public key is the FIPS 203 encapsulation key.
+package mlkemkp
+
+import (
+	"crypto/rand"
+	"encoding/asn1"
+	"fmt"
+
+	"github.com/cloudflare/circl/kem"
+	"github.com/cloudflare/circl/kem/mlkem/mlkem1024"
+	"github.com/cloudflare/circl/kem/mlkem/mlkem512"
+	"github.com/cloudflare/circl/kem/mlkem/mlkem768"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+	"github.com/harmony-one/asym-key-pkgs/pkg/internal/pqkey"
+)
+
+// id-alg-ml-kem-512, id-alg-ml-kem-768 and id-alg-ml-kem-1024 in the NIST
+// algorithm arc
+var (
+	mlkem512OID  = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 4, 1}
+	mlkem768OID  = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 4, 2}
+	mlkem1024OID = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 4, 3}
+)
+
+var parameterSets = []struct {
+	oid    asn1.ObjectIdentifier
+	scheme kem.Scheme
+}{
+	{mlkem512OID, mlkem512.Scheme()},
+	{mlkem768OID, mlkem768.Scheme()},
+	{mlkem1024OID, mlkem1024.Scheme()},
+}
+
+func init() {
+	akp.Packers.Register(Packer, &PrivateKey{})
+	akp.Unpackers.Register(Unpacker, mlkem512OID, mlkem768OID, mlkem1024OID)
+}
+
+// schemeOf returns the ML-KEM parameter set with the given OID, or nil.
+func schemeOf(oid asn1.ObjectIdentifier) kem.Scheme {
+	for _, set := range parameterSets {
+		if oid.Equal(set.oid) {
+			return set.scheme
+		}
+	}
+	return nil
+}
+
+// oidOf returns the OID of the given ML-KEM parameter set, or nil.
+func oidOf(scheme kem.Scheme) asn1.ObjectIdentifier {
+	for _, set := range parameterSets {
+		if scheme.Name() == set.scheme.Name() {
+			return set.oid
+		}
+	}
+	return nil
+}
+
+// Format is the private key format of an ML-KEM key package.
+//
+// Unpack returns it as an extra, and Pack takes it as an option.
+// Without this option, keys that retain their seed are packed as SeedOnly,
+// and other keys as ExpandedOnly.
+type Format = pqkey.Format
+
+// Private key formats
+const (
+	SeedOnly     = pqkey.SeedOnly     // the seed (d, z)
+	ExpandedOnly = pqkey.ExpandedOnly // the expanded decapsulation key
+	Both         = pqkey.Both         // the seed and the expanded key
+)
+
+// seedSize is the size of the seed (d, z).
+const seedSize = mlkem768.KeySeedSize
+
+// PrivateKey is an ML-KEM private (decapsulation) key.
+//
+// A PrivateKey made from a circl private key alone, e.g.
+// &PrivateKey{PrivateKey: sk}, does not retain its seed,
+// and can only be packed as ExpandedOnly.
+type PrivateKey struct {
+	kem.PrivateKey
+	seed []byte
+}
+
+// GenerateKey generates a private key for the given ML-KEM parameter set,
+// e.g. mlkem768.Scheme().
+func GenerateKey(scheme kem.Scheme) (*PrivateKey, error) {
+	seed := make([]byte, seedSize)
+	if _, err := rand.Read(seed); err != nil {
+		return nil, err
+	}
+	return NewKeyFromSeed(scheme, seed)
+}
+
+// NewKeyFromSeed derives a private key for the given ML-KEM parameter set
+// from the 64-byte seed (d, z).
+func NewKeyFromSeed(scheme kem.Scheme, seed []byte) (*PrivateKey, error) {
+	if oidOf(scheme) == nil {
+		return nil, fmt.Errorf("%s is not an ML-KEM parameter set",
+			scheme.Name())
+	}
+	if len(seed) != seedSize {
+		return nil, fmt.Errorf("ML-KEM seed is %d bytes; expected %d",
+			len(seed), seedSize)
+	}
+	_, sk := scheme.DeriveKeyPair(seed)
+	return &PrivateKey{PrivateKey: sk, seed: append([]byte(nil), seed...)}, nil
+}
+
+// Seed returns the seed (d, z) that the private key was derived from,
+// or nil if the private key does not retain it.
+func (priv *PrivateKey) Seed() []byte {
+	if priv.seed == nil {
+		return nil
+	}
+	return append([]byte(nil), priv.seed...)
+}
+
+// Decapsulate returns the shared key encapsulated in the given ciphertext.
+func (priv *PrivateKey) Decapsulate(ciphertext []byte) ([]byte, error) {
+	return priv.Scheme().Decapsulate(priv.PrivateKey, ciphertext)
+}
@@ -0,0 +1,222 @@
+package mlkemkp
+
+import (
+	"bytes"
+	"crypto/mlkem"
+	"encoding/asn1"
+	"reflect"
+	"testing"
+
+	"github.com/cloudflare/circl/kem"
+	"github.com/cloudflare/circl/kem/mlkem/mlkem1024"
+	"github.com/cloudflare/circl/kem/mlkem/mlkem768"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+	"github.com/harmony-one/asym-key-pkgs/pkg/internal/pqkey"
+)
+
+func generateKey(t *testing.T, scheme kem.Scheme) *PrivateKey {
+	priv, err := GenerateKey(scheme)
+	if err != nil {
+		t.Fatalf("cannot generate %s key pair: %v", scheme.Name(), err)
+	}
+	return priv
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, set := range parameterSets {
+		priv := generateKey(t, set.scheme)
+		for _, format := range []Format{SeedOnly, ExpandedOnly, Both} {
+			subtest := func(t *testing.T, pub kem.PublicKey) {
+				pkg, err := Packer.Pack(priv, pub, format)
+				if err != nil {
+					t.Fatalf("cannot pack ML-KEM key pair: %v", err)
+				}
+				priv2, pub2, extras, err := Unpack(pkg)
+				if err != nil {
+					t.Fatalf("cannot unpack ML-KEM key pair: %v", err)
+				}
+				if !priv.Equal(priv2.PrivateKey) {
+					t.Error("reconstructed key is different from the original")
+				}
+				if pub == nil && pub2 != nil || pub != nil && !pub.Equal(pub2) {
+					t.Errorf("expected public key %v but got %v", pub, pub2)
+				}
+				if !reflect.DeepEqual(extras, []interface{}{format}) {
+					t.Errorf("extras are %+v; expected %v", extras, format)
+				}
+				pkg2, err := Pack(priv2, pub2, extras...)
+				if err != nil {
+					t.Fatalf("cannot repack ML-KEM key pair: %v", err)
+				}
+				if !reflect.DeepEqual(pkg, pkg2) {
+					t.Errorf("repacked key package %+v is different from %+v",
+						pkg2, pkg)
+				}
+			}
+			name := set.scheme.Name() + "/" + format.String()
+			t.Run(name+"/WithoutPublic", func(t *testing.T) {
+				subtest(t, nil)
+			})
+			t.Run(name+"/WithPublic", func(t *testing.T) {
+				subtest(t, priv.Public())
+			})
+		}
+	}
+}
+
+// TestStdlib checks seeds and decapsulation against crypto/mlkem.
+func TestStdlib(t *testing.T) {
+	for _, test := range []struct {
+		scheme   kem.Scheme
+		generate func() (seed, ek, ss, ct []byte, err error)
+	}{
+		{mlkem768.Scheme(), func() (seed, ek, ss, ct []byte, err error) {
+			dk, err := mlkem.GenerateKey768()
+			if err != nil {
+				return
+			}
+			ss, ct = dk.EncapsulationKey().Encapsulate()
+			return dk.Bytes(), dk.EncapsulationKey().Bytes(), ss, ct, nil
+		}},
+		{mlkem1024.Scheme(), func() (seed, ek, ss, ct []byte, err error) {
+			dk, err := mlkem.GenerateKey1024()
+			if err != nil {
+				return
+			}
+			ss, ct = dk.EncapsulationKey().Encapsulate()
+			return dk.Bytes(), dk.EncapsulationKey().Bytes(), ss, ct, nil
+		}},
+	} {
+		t.Run(test.scheme.Name(), func(t *testing.T) {
+			seed, ek, ss, ct, err := test.generate()
+			if err != nil {
+				t.Fatal(err)
+			}
+			priv, err := NewKeyFromSeed(test.scheme, seed)
+			if err != nil {
+				t.Fatal(err)
+			}
+			pkg, err := Pack(priv, nil)
+			if err != nil {
+				t.Fatalf("cannot pack ML-KEM key: %v", err)
+			}
+			priv2, _, _, err := Unpack(pkg)
+			if err != nil {
+				t.Fatalf("cannot unpack ML-KEM key: %v", err)
+			}
+			if !bytes.Equal(priv2.Seed(), seed) {
+				t.Errorf("seed is %x; expected %x", priv2.Seed(), seed)
+			}
+			pub, err := Packer.DerivePublicKey(priv2)
+			if err != nil {
+				t.Fatal(err)
+			}
+			bits, err := Packer.PackPublicKey(pub)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(bits.Bytes, ek) {
+				t.Errorf("encapsulation key is %x; expected %x", bits.Bytes, ek)
+			}
+			ss2, err := priv2.Decapsulate(ct)
+			if err != nil || !bytes.Equal(ss2, ss) {
+				t.Errorf("Decapsulate returned %x, %v; expected %x", ss2, err, ss)
+			}
+		})
+	}
+}
+
+func TestUnpack(t *testing.T) {
+	priv := generateKey(t, mlkem768.Scheme())
+	expanded, err := priv.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	other := generateKey(t, mlkem768.Scheme())
+	otherExpanded, err := other.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The expanded key is dk_PKE || ek || H(ek) || z.
+	badHash := bytes.Clone(expanded)
+	badHash[len(badHash)-64] ^= 1
+	// Swap dk_PKE for that of the other key.
+	mixed := bytes.Clone(expanded)
+	copy(mixed, otherExpanded[:384*3])
+	for _, test := range []struct {
+		name     string
+		format   Format
+		seed     []byte
+		expanded []byte
+		pub      kem.PublicKey
+	}{
+		{"ShortSeed", SeedOnly, priv.seed[1:], nil, nil},
+		{"ShortExpandedKey", ExpandedOnly, nil, expanded[1:], nil},
+		{"BadHash", ExpandedOnly, nil, badHash, nil},
+		{"Inconsistent", ExpandedOnly, nil, mixed, nil},
+		{"Mismatch", Both, priv.seed, otherExpanded, nil},
+		{"PublicKeyMismatch", SeedOnly, priv.seed, nil, other.Public()},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			pkg, err := Pack(priv, priv.Public())
+			if err != nil {
+				t.Fatal(err)
+			}
+			pkg.PrivateKey, err = pqkey.Marshal(
+				test.format, test.seed, test.expanded)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if test.pub != nil {
+				pkg.PublicKey, _ = packPublicKey(test.pub)
+			}
+			if _, _, _, err = Unpack(pkg); err == nil {
+				t.Error("Unpack succeeded")
+			}
+		})
+	}
+	t.Run("SeedlessKey", func(t *testing.T) {
+		sk, err := mlkem768.Scheme().UnmarshalBinaryPrivateKey(expanded)
+		if err != nil {
+			t.Fatal(err)
+		}
+		priv2 := &PrivateKey{PrivateKey: sk}
+		pkg, err := Pack(priv2, nil)
+		if err != nil {
+			t.Fatalf("cannot pack seedless ML-KEM key: %v", err)
+		}
+		_, _, extras, err := Unpack(pkg)
+		if err != nil || !reflect.DeepEqual(extras, []interface{}{ExpandedOnly}) {
+			t.Errorf("Unpack returned extras %+v, error %v; expected %v",
+				extras, err, ExpandedOnly)
+		}
+		if _, err = Pack(priv2, nil, Both); err == nil {
+			t.Error("Pack packed a seedless key as Both")
+		}
+	})
+	t.Run("WrongAlgorithm", func(t *testing.T) {
+		pkg, err := Pack(priv, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pkg.PrivateKeyAlgorithm.Algorithm = asn1.ObjectIdentifier{1, 2, 3}
+		if _, _, _, err = Unpack(pkg); err != ErrNotMLKEM {
+			t.Errorf("Unpack returned %v; expected %v", err, ErrNotMLKEM)
+		}
+	})
+}
+
+func TestCapabilities(t *testing.T) {
+	encoded, err := akp.Encode(generateKey(t, mlkem1024.Scheme()), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var pkg akp.OneAsymmetricKey
+	if _, err = asn1.Unmarshal(encoded, &pkg); err != nil {
+		t.Fatal(err)
+	}
+	if c := pkg.Capabilities(); c != akp.CanDecapsulate {
+		t.Errorf("capabilities are %v; expected %v", c, akp.CanDecapsulate)
+	}
+}
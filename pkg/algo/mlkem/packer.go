@@ -0,0 +1,89 @@
+package mlkemkp
+
+import (
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+
+	"github.com/cloudflare/circl/kem"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+	"github.com/harmony-one/asym-key-pkgs/pkg/internal/pqkey"
+)
+
+type packer struct{}
+
+// Packer is the singleton packer instance.
+var Packer packer
+
+func (packer packer) Pack(
+	priv interface{}, pub interface{}, options ...interface{},
+) (pkg *akp.OneAsymmetricKey, err error) {
+	privKey, ok := priv.(*PrivateKey)
+	if !ok {
+		return nil, akp.ErrSkip
+	}
+	if pub == nil {
+		return Pack(privKey, nil, options...)
+	}
+	pubKey, ok := pub.(kem.PublicKey)
+	if !ok || pubKey.Scheme().Name() != privKey.Scheme().Name() {
+		return nil, akp.ErrSkip
+	}
+	return Pack(privKey, pubKey, options...)
+}
+
+// Pack packs the given ML-KEM key pair into a key package.
+//
+// A Format option selects the private key format; see Format for the
+// default.
+// SeedOnly and Both require a private key that retains its seed.
+func Pack(
+	privKey *PrivateKey, pubKey kem.PublicKey, options ...interface{},
+) (pkg *akp.OneAsymmetricKey, err error) {
+	oid := oidOf(privKey.Scheme())
+	if oid == nil {
+		return nil, errors.New("not an ML-KEM key")
+	}
+	format := ExpandedOnly
+	if privKey.seed != nil {
+		format = SeedOnly
+	}
+	for _, option := range options {
+		if f, ok := option.(Format); ok {
+			format = f
+		}
+	}
+	if privKey.seed == nil && format != ExpandedOnly {
+		return nil, errors.New("ML-KEM private key does not retain its seed")
+	}
+	var expanded []byte
+	if format != SeedOnly {
+		if expanded, err = privKey.MarshalBinary(); err != nil {
+			return nil, err
+		}
+	}
+	pkg = &akp.OneAsymmetricKey{
+		Version:             akp.V1,
+		PrivateKeyAlgorithm: pkix.AlgorithmIdentifier{Algorithm: oid},
+	}
+	pkg.PrivateKey, err = pqkey.Marshal(format, privKey.seed, expanded)
+	if err != nil {
+		return nil, err
+	}
+	if pubKey != nil {
+		if pkg.PublicKey, err = packPublicKey(pubKey); err != nil {
+			return nil, err
+		}
+		pkg.Version = akp.V2
+	}
+	return pkg, nil
+}
+
+func packPublicKey(pubKey kem.PublicKey) (asn1.BitString, error) {
+	pubBytes, err := pubKey.MarshalBinary()
+	if err != nil {
+		return asn1.BitString{}, err
+	}
+	return asn1.BitString{Bytes: pubBytes, BitLength: 8 * len(pubBytes)}, nil
+}
@@ -0,0 +1,33 @@
+package mlkemkp
+
+import (
+	"encoding/asn1"
+
+	"github.com/cloudflare/circl/kem"
+	"github.com/cloudflare/circl/kem/mlkem/mlkem1024"
+	"github.com/cloudflare/circl/kem/mlkem/mlkem512"
+	"github.com/cloudflare/circl/kem/mlkem/mlkem768"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+)
+
+// DerivePublicKey returns the public portion of an ML-KEM private key.
+func (packer packer) DerivePublicKey(priv interface{}) (
+	pub interface{}, err error,
+) {
+	privKey, ok := priv.(*PrivateKey)
+	if !ok {
+		return nil, akp.ErrSkip
+	}
+	return privKey.Public(), nil
+}
+
+// PackPublicKey packs an ML-KEM public key as its FIPS 203 encapsulation
+// key.
+func (packer packer) PackPublicKey(pub interface{}) (asn1.BitString, error) {
+	switch pubKey := pub.(type) {
+	case *mlkem512.PublicKey, *mlkem768.PublicKey, *mlkem1024.PublicKey:
+		return packPublicKey(pubKey.(kem.PublicKey))
+	}
+	return asn1.BitString{}, akp.ErrSkip
+}
@@ -0,0 +1,130 @@
+package mlkemkp
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/cloudflare/circl/kem"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+	"github.com/harmony-one/asym-key-pkgs/pkg/internal/pqkey"
+)
+
+type unpacker struct{}
+
+// Unpacker is the singleton ML-KEM unpacker instance.
+var Unpacker unpacker
+
+// Unpack unpacks an ML-KEM private key in any format,
+// and returns the format as an extra.
+//
+// If the key package has both the seed and the expanded key,
+// the expanded key must be the one derived from the seed.
+// An expanded key alone must be canonically encoded, pass the FIPS 203
+// hash check, and decapsulate what its encapsulation key encapsulates.
+// The public key, if present, must match the private key.
+func (unpacker unpacker) Unpack(pkg *akp.OneAsymmetricKey) (
+	priv interface{}, pub interface{}, extras []interface{}, err error,
+) {
+	scheme := schemeOf(pkg.PrivateKeyAlgorithm.Algorithm)
+	if scheme == nil {
+		return nil, nil, nil, akp.ErrSkip
+	}
+	if len(pkg.PrivateKeyAlgorithm.Parameters.FullBytes) > 0 {
+		return nil, nil, nil, errors.New("ML-KEM key has parameters")
+	}
+	seed, expanded, format, err := pqkey.Unmarshal(pkg.PrivateKey)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf(
+			"cannot unmarshal private key: %v", err)
+	}
+	var privKey *PrivateKey
+	if seed != nil {
+		if privKey, err = NewKeyFromSeed(scheme, seed); err != nil {
+			return nil, nil, nil, err
+		}
+		if expanded != nil {
+			derived, err := privKey.MarshalBinary()
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			if !bytes.Equal(expanded, derived) {
+				return nil, nil, nil, errors.New(
+					"ML-KEM expanded key does not match seed")
+			}
+		}
+	} else if privKey, err = unpackExpanded(scheme, expanded); err != nil {
+		return nil, nil, nil, err
+	}
+	extras = []interface{}{format}
+	if pkg.PublicKey.Bytes == nil {
+		return privKey, nil, extras, nil
+	}
+	pubKey, err := scheme.UnmarshalBinaryPublicKey(pkg.PublicKey.RightAlign())
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("cannot unmarshal public key: %v", err)
+	}
+	if !pubKey.Equal(privKey.Public()) {
+		return nil, nil, nil, errors.New(
+			"ML-KEM public key does not match private key")
+	}
+	return privKey, pubKey, extras, nil
+}
+
+// unpackExpanded unpacks and checks an expanded ML-KEM decapsulation key.
+func unpackExpanded(scheme kem.Scheme, expanded []byte) (*PrivateKey, error) {
+	if len(expanded) != scheme.PrivateKeySize() {
+		return nil, fmt.Errorf("ML-KEM expanded key is %d bytes; expected %d",
+			len(expanded), scheme.PrivateKeySize())
+	}
+	// This includes the hash check of FIPS 203, section 7.3.
+	sk, err := scheme.UnmarshalBinaryPrivateKey(expanded)
+	if err != nil {
+		return nil, fmt.Errorf("cannot unmarshal private key: %v", err)
+	}
+	repacked, err := sk.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(repacked, expanded) {
+		return nil, errors.New("ML-KEM expanded key is not canonical")
+	}
+	// Pairwise consistency test (FIPS 203, section 7.1).
+	ct, ss, err := scheme.Encapsulate(sk.Public())
+	if err != nil {
+		return nil, err
+	}
+	ss2, err := scheme.Decapsulate(sk, ct)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(ss, ss2) {
+		return nil, errors.New(
+			"ML-KEM expanded key does not match its encapsulation key")
+	}
+	return &PrivateKey{PrivateKey: sk}, nil
+}
+
+// ErrNotMLKEM means the unpacked key is not an ML-KEM key.
+var ErrNotMLKEM = errors.New("not an ML-KEM key")
+
+// Unpack unpacks a key package into an ML-KEM key pair.
+func Unpack(pkg *akp.OneAsymmetricKey) (
+	priv *PrivateKey, pub kem.PublicKey, extras []interface{}, err error,
+) {
+	priv, pub, extras, err = akp.UnpackWith[*PrivateKey, kem.PublicKey](
+		Unpacker, pkg)
+	var mismatch *akp.TypeMismatchError
+	if errors.As(err, &mismatch) || err == akp.ErrSkip {
+		return nil, nil, nil, ErrNotMLKEM
+	}
+	return priv, pub, extras, err
+}
+
+// Capabilities reports that ML-KEM keys can decapsulate.
+func (unpacker unpacker) Capabilities(
+	pkg *akp.OneAsymmetricKey,
+) akp.Capability {
+	return akp.CanDecapsulate
+}
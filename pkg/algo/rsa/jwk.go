@@ -0,0 +1,129 @@
+package rsakp
+
+import (
+	"crypto/rsa"
+	"errors"
+	"math/big"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+	"github.com/harmony-one/asym-key-pkgs/pkg/internal/jwkfield"
+)
+
+const jwkKeyType = "RSA"
+
+// JWKThumbprintMembers returns the required members of an RSA JWK
+// (RFC 7518, section 6.3.1).
+func (packer packer) JWKThumbprintMembers(pub interface{}) (
+	map[string]string, error,
+) {
+	pubKey, ok := pub.(*rsa.PublicKey)
+	if !ok {
+		return nil, akp.ErrSkip
+	}
+	return map[string]string{
+		"kty": jwkKeyType,
+		"n":   jwkfield.Int(pubKey.N),
+		"e":   jwkfield.Int(big.NewInt(int64(pubKey.E))),
+	}, nil
+}
+
+// JWKKeyTypes returns the JWK key type of RSA keys.
+func (packer packer) JWKKeyTypes() []string {
+	return []string{jwkKeyType}
+}
+
+// MarshalJWK returns the members of an RSA private JWK
+// (RFC 7518, section 6.3.2).
+func (packer packer) MarshalJWK(priv interface{}) (
+	map[string]interface{}, error,
+) {
+	privKey, ok := priv.(*rsa.PrivateKey)
+	if !ok {
+		return nil, akp.ErrSkip
+	}
+	if len(privKey.Primes) < 2 {
+		return nil, errors.New("RSA private key has fewer than two primes")
+	}
+	privKey.Precompute()
+	pre := &privKey.Precomputed
+	members := map[string]interface{}{
+		"kty": jwkKeyType,
+		"n":   jwkfield.Int(privKey.N),
+		"e":   jwkfield.Int(big.NewInt(int64(privKey.E))),
+		"d":   jwkfield.Int(privKey.D),
+		"p":   jwkfield.Int(privKey.Primes[0]),
+		"q":   jwkfield.Int(privKey.Primes[1]),
+		"dp":  jwkfield.Int(pre.Dp),
+		"dq":  jwkfield.Int(pre.Dq),
+		"qi":  jwkfield.Int(pre.Qinv),
+	}
+	if len(privKey.Primes) > 2 {
+		var oth []interface{}
+		for i, r := range privKey.Primes[2:] {
+			oth = append(oth, map[string]interface{}{
+				"r": jwkfield.Int(r),
+				"d": jwkfield.Int(pre.CRTValues[i].Exp),
+				"t": jwkfield.Int(pre.CRTValues[i].Coeff),
+			})
+		}
+		members["oth"] = oth
+	}
+	return members, nil
+}
+
+// UnmarshalJWK converts the members of an RSA private JWK into an RSA key
+// pair.
+//
+// The CRT members are recomputed rather than trusted.
+func (packer packer) UnmarshalJWK(members map[string]interface{}) (
+	priv interface{}, pub interface{}, err error,
+) {
+	var privKey rsa.PrivateKey
+	if privKey.N, err = jwkfield.GetInt(members, "n"); err != nil {
+		return nil, nil, err
+	}
+	e, err := jwkfield.GetInt(members, "e")
+	if err != nil {
+		return nil, nil, err
+	}
+	if !e.IsInt64() || e.Int64() > 1<<31-1 {
+		return nil, nil, errors.New("RSA public exponent out of range")
+	}
+	privKey.E = int(e.Int64())
+	if _, ok := members["d"]; !ok {
+		return nil, nil, errors.New("JWK has no private key")
+	}
+	if privKey.D, err = jwkfield.GetInt(members, "d"); err != nil {
+		return nil, nil, err
+	}
+	for _, name := range []string{"p", "q"} {
+		prime, err := jwkfield.GetInt(members, name)
+		if err != nil {
+			return nil, nil, err
+		}
+		privKey.Primes = append(privKey.Primes, prime)
+	}
+	if oth, ok := members["oth"]; ok {
+		others, ok := oth.([]interface{})
+		if !ok {
+			return nil, nil, errors.New("JWK member \"oth\" is not an array")
+		}
+		for _, other := range others {
+			info, ok := other.(map[string]interface{})
+			if !ok {
+				return nil, nil, errors.New(
+					"JWK member \"oth\" has a non-object element")
+			}
+			prime, err := jwkfield.GetInt(info, "r")
+			if err != nil {
+				return nil, nil, err
+			}
+			privKey.Primes = append(privKey.Primes, prime)
+		}
+	}
+	if err = privKey.Validate(); err != nil {
+		return nil, nil, err
+	}
+	privKey.Precompute()
+	return &privKey, &privKey.PublicKey, nil
+}
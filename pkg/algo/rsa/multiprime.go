@@ -0,0 +1,95 @@
+package rsakp
+
+import (
+	"crypto/rsa"
+	"encoding/asn1"
+	"errors"
+	"math/big"
+)
+
+// RSAPrivateKey in RFC 8017, with OtherPrimeInfos for multi-prime keys.
+//
+// x509.ParsePKCS1PrivateKey ignores the CRT values of the other primes,
+// and crypto/rsa does not check that the primes of a multi-prime key
+// multiply to the modulus, so multi-prime keys are checked here.
+type asn1RSAPrivateKey struct {
+	Version         int
+	N, E, D         *big.Int
+	P, Q            *big.Int
+	Dp, Dq, Qinv    *big.Int
+	OtherPrimeInfos []asn1OtherPrimeInfo `asn1:"optional,omitempty"`
+}
+
+// OtherPrimeInfo in RFC 8017.
+type asn1OtherPrimeInfo struct {
+	Prime, Exponent, Coefficient *big.Int
+}
+
+// RSAPrivateKey versions in RFC 8017.
+const (
+	twoPrimeVersion   = 0
+	multiPrimeVersion = 1
+)
+
+var errBadCRTValue = errors.New("RSA CRT value does not match the primes")
+
+// checkPrimes checks that the primes of an RSA private key multiply to its
+// modulus.
+func checkPrimes(privKey *rsa.PrivateKey) error {
+	if len(privKey.Primes) < 2 {
+		return errors.New("RSA private key has fewer than two primes")
+	}
+	n := big.NewInt(1)
+	for _, prime := range privKey.Primes {
+		if prime == nil || prime.Cmp(big.NewInt(1)) <= 0 {
+			return errors.New("RSA prime is not greater than one")
+		}
+		n.Mul(n, prime)
+	}
+	if n.Cmp(privKey.N) != 0 {
+		return errors.New("RSA primes do not multiply to the modulus")
+	}
+	return nil
+}
+
+// checkMultiPrime checks the version, the primes and the CRT values of an
+// encoded RSA private key with other primes against the parsed key.
+func checkMultiPrime(der []byte, privKey *rsa.PrivateKey) error {
+	var raw asn1RSAPrivateKey
+	if _, err := asn1.Unmarshal(der, &raw); err != nil {
+		return err
+	}
+	if len(raw.OtherPrimeInfos) == 0 {
+		if raw.Version != twoPrimeVersion {
+			return errors.New("multi-prime RSA private key has two primes")
+		}
+		return nil
+	}
+	if raw.Version != multiPrimeVersion {
+		return errors.New("two-prime RSA private key has other primes")
+	}
+	if err := checkPrimes(privKey); err != nil {
+		return err
+	}
+	// crypto/rsa recomputes the CRT values of multi-prime keys.
+	pre := privKey.Precomputed
+	if raw.Dp.Cmp(pre.Dp) != 0 || raw.Dq.Cmp(pre.Dq) != 0 ||
+		raw.Qinv.Cmp(pre.Qinv) != 0 {
+		return errBadCRTValue
+	}
+	one := big.NewInt(1)
+	r := new(big.Int).Mul(raw.P, raw.Q)
+	for _, info := range raw.OtherPrimeInfos {
+		exp := new(big.Int).Sub(info.Prime, one)
+		exp.Mod(privKey.D, exp)
+		coeff := new(big.Int).ModInverse(r, info.Prime)
+		if coeff == nil {
+			return errors.New("RSA primes are not relatively prime")
+		}
+		if info.Exponent.Cmp(exp) != 0 || info.Coefficient.Cmp(coeff) != 0 {
+			return errBadCRTValue
+		}
+		r.Mul(r, info.Prime)
+	}
+	return nil
+}
@@ -3,7 +3,6 @@ package rsakp
 import (
 	"crypto/rsa"
 	"crypto/x509"
-	"crypto/x509/pkix"
 	"encoding/asn1"
 	"errors"
 
@@ -27,38 +26,52 @@ func (packer packer) Pack(
 		return nil, akp.ErrSkip
 	}
 	if pub == nil {
-		return Pack(privKey, nil, options)
+		return Pack(privKey, nil, options...)
 	}
 	if pubKey, ok = pub.(*rsa.PublicKey); !ok {
 		return nil, akp.ErrSkip
 	}
-	return Pack(privKey, pubKey, options)
+	return Pack(privKey, pubKey, options...)
 }
 
 // Pack packs the given RSA key pair into a key package.
+//
+// Keys with more than two primes are packed with the CRT values of the
+// other primes (RFC 8017, section A.1.2).
+//
+// A PSSParams or OAEPParams option restricts the key to RSASSA-PSS or
+// RSAES-OAEP with the given parameters.
 func Pack(
 	privKey *rsa.PrivateKey, pubKey *rsa.PublicKey, options ...interface{},
 ) (pkg *akp.OneAsymmetricKey, err error) {
-	pkg = &akp.OneAsymmetricKey{
-		Version: akp.V1,
-		PrivateKeyAlgorithm: pkix.AlgorithmIdentifier{
-			Algorithm: algorithmOID, Parameters: asn1.NullRawValue,
-		},
+	pkg = &akp.OneAsymmetricKey{Version: akp.V1}
+	pkg.PrivateKeyAlgorithm, err = restriction(options)
+	if err != nil {
+		return nil, err
+	}
+	if err = checkPrimes(privKey); err != nil {
+		return nil, err
 	}
 	pkg.PrivateKey = x509.MarshalPKCS1PrivateKey(privKey)
 	if pkg.PrivateKey == nil {
 		return nil, errors.New("cannot serialize RSA private key")
 	}
 	if pubKey != nil {
-		pubBytes := x509.MarshalPKCS1PublicKey(pubKey)
-		if pubBytes == nil {
-			return nil, errors.New("cannot serialize RSA public key")
-		}
-		pkg.PublicKey = asn1.BitString{
-			Bytes:     pubBytes,
-			BitLength: 8 * len(pubBytes),
+		if pkg.PublicKey, err = packPublicKey(pubKey); err != nil {
+			return nil, err
 		}
 		pkg.Version = akp.V2
 	}
 	return pkg, nil
 }
+
+func packPublicKey(pubKey *rsa.PublicKey) (asn1.BitString, error) {
+	pubBytes := x509.MarshalPKCS1PublicKey(pubKey)
+	if pubBytes == nil {
+		return asn1.BitString{}, errors.New("cannot serialize RSA public key")
+	}
+	return asn1.BitString{
+		Bytes:     pubBytes,
+		BitLength: 8 * len(pubBytes),
+	}, nil
+}
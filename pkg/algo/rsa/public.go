@@ -0,0 +1,28 @@
+package rsakp
+
+import (
+	"crypto/rsa"
+	"encoding/asn1"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+)
+
+// DerivePublicKey returns the public portion of an RSA private key.
+func (packer packer) DerivePublicKey(priv interface{}) (
+	pub interface{}, err error,
+) {
+	privKey, ok := priv.(*rsa.PrivateKey)
+	if !ok {
+		return nil, akp.ErrSkip
+	}
+	return &privKey.PublicKey, nil
+}
+
+// PackPublicKey packs an RSA public key as an RSAPublicKey (RFC 8017).
+func (packer packer) PackPublicKey(pub interface{}) (asn1.BitString, error) {
+	pubKey, ok := pub.(*rsa.PublicKey)
+	if !ok {
+		return asn1.BitString{}, akp.ErrSkip
+	}
+	return packPublicKey(pubKey)
+}
@@ -0,0 +1,460 @@
+package rsakp
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rsa"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+)
+
+// RSA keys restricted to RSASSA-PSS or RSAES-OAEP (RFC 4055).
+var (
+	pssOID  = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 10}
+	oaepOID = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 7}
+
+	mgf1OID       = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 8}
+	pSpecifiedOID = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 9}
+)
+
+var hashOIDs = map[crypto.Hash]asn1.ObjectIdentifier{
+	crypto.SHA1:       {1, 3, 14, 3, 2, 26},
+	crypto.SHA224:     {2, 16, 840, 1, 101, 3, 4, 2, 4},
+	crypto.SHA256:     {2, 16, 840, 1, 101, 3, 4, 2, 1},
+	crypto.SHA384:     {2, 16, 840, 1, 101, 3, 4, 2, 2},
+	crypto.SHA512:     {2, 16, 840, 1, 101, 3, 4, 2, 3},
+	crypto.SHA512_224: {2, 16, 840, 1, 101, 3, 4, 2, 5},
+	crypto.SHA512_256: {2, 16, 840, 1, 101, 3, 4, 2, 6},
+}
+
+// PSSParams are the parameters of an RSA key restricted to RSASSA-PSS
+// (RFC 4055, section 3.1).
+//
+// Unpack returns them as an extra for such a key,
+// and Pack takes them as an option to restrict the key.
+//
+// The zero value means the parameters are absent,
+// i.e. the key may be used with any RSASSA-PSS parameters.
+// Otherwise, zero Hash and MGFHash mean SHA-1,
+// and zero SaltLength means 20, the RFC 4055 defaults.
+// Unpack rejects keys restricted to an empty salt, which crypto/rsa cannot
+// produce.
+type PSSParams struct {
+	Hash       crypto.Hash
+	MGFHash    crypto.Hash
+	SaltLength int
+}
+
+// SignerOpts returns the options for signing with the key.
+func (params PSSParams) SignerOpts() *rsa.PSSOptions {
+	p := params.withDefaults()
+	return &rsa.PSSOptions{SaltLength: p.SaltLength, Hash: p.Hash}
+}
+
+func (params PSSParams) withDefaults() PSSParams {
+	if params.Hash == 0 {
+		params.Hash = crypto.SHA1
+	}
+	if params.MGFHash == 0 {
+		params.MGFHash = crypto.SHA1
+	}
+	if params.SaltLength == 0 {
+		params.SaltLength = 20
+	}
+	return params
+}
+
+// OAEPParams are the parameters of an RSA key restricted to RSAES-OAEP
+// (RFC 4055, section 4.1).
+//
+// Unpack returns them as an extra for such a key,
+// and Pack takes them as an option to restrict the key.
+//
+// Zero Hash and MGFHash mean SHA-1, the RFC 4055 defaults.
+type OAEPParams struct {
+	Hash    crypto.Hash
+	MGFHash crypto.Hash
+	Label   []byte
+}
+
+// DecrypterOpts returns the options for decrypting with the key.
+func (params OAEPParams) DecrypterOpts() *rsa.OAEPOptions {
+	p := params.withDefaults()
+	return &rsa.OAEPOptions{Hash: p.Hash, MGFHash: p.MGFHash, Label: p.Label}
+}
+
+func (params OAEPParams) withDefaults() OAEPParams {
+	if params.Hash == 0 {
+		params.Hash = crypto.SHA1
+	}
+	if params.MGFHash == 0 {
+		params.MGFHash = crypto.SHA1
+	}
+	return params
+}
+
+// RSASSA-PSS-params in RFC 4055.
+type asn1PSSParams struct {
+	Hash         pkix.AlgorithmIdentifier `asn1:"optional,explicit,tag:0"`
+	MGF          pkix.AlgorithmIdentifier `asn1:"optional,explicit,tag:1"`
+	SaltLength   int                      `asn1:"optional,explicit,tag:2,default:20"`
+	TrailerField int                      `asn1:"optional,explicit,tag:3,default:1"`
+}
+
+// RSAES-OAEP-params in RFC 4055.
+type asn1OAEPParams struct {
+	Hash    pkix.AlgorithmIdentifier `asn1:"optional,explicit,tag:0"`
+	MGF     pkix.AlgorithmIdentifier `asn1:"optional,explicit,tag:1"`
+	PSource pkix.AlgorithmIdentifier `asn1:"optional,explicit,tag:2"`
+}
+
+// hashAlgorithm returns the AlgorithmIdentifier of a hash function,
+// or the zero value for SHA-1, which is the default.
+func hashAlgorithm(h crypto.Hash) (pkix.AlgorithmIdentifier, error) {
+	if h == crypto.SHA1 {
+		return pkix.AlgorithmIdentifier{}, nil
+	}
+	oid, ok := hashOIDs[h]
+	if !ok {
+		return pkix.AlgorithmIdentifier{}, fmt.Errorf(
+			"unsupported hash function %v", h)
+	}
+	return pkix.AlgorithmIdentifier{
+		Algorithm: oid, Parameters: asn1.NullRawValue,
+	}, nil
+}
+
+// mgf1Algorithm returns the AlgorithmIdentifier of MGF1 with a hash
+// function, or the zero value for MGF1 with SHA-1, which is the default.
+func mgf1Algorithm(h crypto.Hash) (pkix.AlgorithmIdentifier, error) {
+	if h == crypto.SHA1 {
+		return pkix.AlgorithmIdentifier{}, nil
+	}
+	hashAlg, err := hashAlgorithm(h)
+	if err != nil {
+		return pkix.AlgorithmIdentifier{}, err
+	}
+	hashBytes, err := asn1.Marshal(hashAlg)
+	if err != nil {
+		return pkix.AlgorithmIdentifier{}, err
+	}
+	return pkix.AlgorithmIdentifier{
+		Algorithm: mgf1OID, Parameters: asn1.RawValue{FullBytes: hashBytes},
+	}, nil
+}
+
+// hashFunction is the inverse of hashAlgorithm.
+func hashFunction(alg pkix.AlgorithmIdentifier) (crypto.Hash, error) {
+	if alg.Algorithm == nil {
+		return crypto.SHA1, nil
+	}
+	for h, oid := range hashOIDs {
+		if alg.Algorithm.Equal(oid) {
+			return h, nil
+		}
+	}
+	return 0, fmt.Errorf("unsupported hash algorithm %v", alg.Algorithm)
+}
+
+// mgf1Hash is the inverse of mgf1Algorithm.
+func mgf1Hash(alg pkix.AlgorithmIdentifier) (crypto.Hash, error) {
+	if alg.Algorithm == nil {
+		return crypto.SHA1, nil
+	}
+	if !alg.Algorithm.Equal(mgf1OID) {
+		return 0, fmt.Errorf("unsupported mask generation function %v",
+			alg.Algorithm)
+	}
+	var hashAlg pkix.AlgorithmIdentifier
+	if err := unmarshalExact(alg.Parameters.FullBytes, &hashAlg); err != nil {
+		return 0, fmt.Errorf("cannot unmarshal MGF1 parameters: %v", err)
+	}
+	return hashFunction(hashAlg)
+}
+
+func unmarshalExact(der []byte, val interface{}) error {
+	rest, err := asn1.Unmarshal(der, val)
+	if err != nil {
+		return err
+	}
+	if len(rest) > 0 {
+		return errors.New("trailing data")
+	}
+	return nil
+}
+
+// restriction returns the algorithm identifier of an RSA key with the
+// restriction given in the options, if any.
+//
+// It returns an error if the options have more than one restriction.
+func restriction(options []interface{}) (pkix.AlgorithmIdentifier, error) {
+	alg := pkix.AlgorithmIdentifier{
+		Algorithm: algorithmOID, Parameters: asn1.NullRawValue,
+	}
+	restricted := false
+	for _, option := range options {
+		var err error
+		switch params := option.(type) {
+		case PSSParams:
+			alg, err = marshalPSSParams(params)
+		case OAEPParams:
+			alg, err = marshalOAEPParams(params)
+		default:
+			continue
+		}
+		if err != nil {
+			return alg, err
+		}
+		if restricted {
+			return alg, errors.New("RSA key options have several restrictions")
+		}
+		restricted = true
+	}
+	return alg, nil
+}
+
+func marshalPSSParams(params PSSParams) (pkix.AlgorithmIdentifier, error) {
+	alg := pkix.AlgorithmIdentifier{Algorithm: pssOID}
+	if params == (PSSParams{}) {
+		return alg, nil
+	}
+	params = params.withDefaults()
+	var asn1Params asn1PSSParams
+	var err error
+	if asn1Params.Hash, err = hashAlgorithm(params.Hash); err != nil {
+		return alg, err
+	}
+	if asn1Params.MGF, err = mgf1Algorithm(params.MGFHash); err != nil {
+		return alg, err
+	}
+	asn1Params.SaltLength = params.SaltLength
+	asn1Params.TrailerField = 1
+	der, err := asn1.Marshal(asn1Params)
+	if err != nil {
+		return alg, err
+	}
+	alg.Parameters = asn1.RawValue{FullBytes: der}
+	return alg, nil
+}
+
+func marshalOAEPParams(params OAEPParams) (pkix.AlgorithmIdentifier, error) {
+	alg := pkix.AlgorithmIdentifier{Algorithm: oaepOID}
+	params = params.withDefaults()
+	var asn1Params asn1OAEPParams
+	var err error
+	if asn1Params.Hash, err = hashAlgorithm(params.Hash); err != nil {
+		return alg, err
+	}
+	if asn1Params.MGF, err = mgf1Algorithm(params.MGFHash); err != nil {
+		return alg, err
+	}
+	if len(params.Label) > 0 {
+		label, err := asn1.Marshal(params.Label)
+		if err != nil {
+			return alg, err
+		}
+		asn1Params.PSource = pkix.AlgorithmIdentifier{
+			Algorithm: pSpecifiedOID, Parameters: asn1.RawValue{FullBytes: label},
+		}
+	}
+	der, err := asn1.Marshal(asn1Params)
+	if err != nil {
+		return alg, err
+	}
+	alg.Parameters = asn1.RawValue{FullBytes: der}
+	return alg, nil
+}
+
+// unmarshalRestriction returns the restriction of an RSA key package as
+// extras.
+func unmarshalRestriction(pkg *akp.OneAsymmetricKey) ([]interface{}, error) {
+	alg := pkg.PrivateKeyAlgorithm
+	der := alg.Parameters.FullBytes
+	switch {
+	case alg.Algorithm.Equal(algorithmOID):
+		return nil, nil
+	case alg.Algorithm.Equal(pssOID):
+		if len(der) == 0 {
+			return []interface{}{PSSParams{}}, nil
+		}
+		var asn1Params asn1PSSParams
+		if err := unmarshalExact(der, &asn1Params); err != nil {
+			return nil, fmt.Errorf("cannot unmarshal PSS parameters: %v", err)
+		}
+		if asn1Params.TrailerField != 1 {
+			return nil, fmt.Errorf("unsupported PSS trailer field %d",
+				asn1Params.TrailerField)
+		}
+		if asn1Params.SaltLength < 0 {
+			return nil, errors.New("negative PSS salt length")
+		}
+		// A zero SaltLength means 20 in PSSParams and means
+		// PSSSaltLengthAuto in crypto/rsa.
+		if asn1Params.SaltLength == 0 {
+			return nil, errors.New("unsupported empty PSS salt")
+		}
+		var params PSSParams
+		var err error
+		if params.Hash, err = hashFunction(asn1Params.Hash); err != nil {
+			return nil, err
+		}
+		if params.MGFHash, err = mgf1Hash(asn1Params.MGF); err != nil {
+			return nil, err
+		}
+		params.SaltLength = asn1Params.SaltLength
+		return []interface{}{params}, nil
+	case alg.Algorithm.Equal(oaepOID):
+		var asn1Params asn1OAEPParams
+		if len(der) > 0 {
+			if err := unmarshalExact(der, &asn1Params); err != nil {
+				return nil, fmt.Errorf(
+					"cannot unmarshal OAEP parameters: %v", err)
+			}
+		}
+		var params OAEPParams
+		var err error
+		if params.Hash, err = hashFunction(asn1Params.Hash); err != nil {
+			return nil, err
+		}
+		if params.MGFHash, err = mgf1Hash(asn1Params.MGF); err != nil {
+			return nil, err
+		}
+		if pSource := asn1Params.PSource; pSource.Algorithm != nil {
+			if !pSource.Algorithm.Equal(pSpecifiedOID) {
+				return nil, fmt.Errorf("unsupported OAEP label source %v",
+					pSource.Algorithm)
+			}
+			err = unmarshalExact(pSource.Parameters.FullBytes, &params.Label)
+			if err != nil {
+				return nil, fmt.Errorf("cannot unmarshal OAEP label: %v", err)
+			}
+			if len(params.Label) == 0 {
+				params.Label = nil
+			}
+		}
+		return []interface{}{params}, nil
+	}
+	return nil, akp.ErrSkip
+}
+
+// RestrictedSigner returns a signer for an RSA private key restricted to
+// RSASSA-PSS by a PSSParams extra.
+//
+// The signer only accepts *rsa.PSSOptions,
+// with the hash function and salt length of the parameters, if any.
+// As crypto/rsa always uses MGF1 with the message hash function,
+// it refuses to sign for parameters with another MGF1 hash function.
+func (packer packer) RestrictedSigner(
+	priv interface{}, extras []interface{},
+) (crypto.Signer, error) {
+	privKey, ok := priv.(*rsa.PrivateKey)
+	if !ok {
+		return nil, akp.ErrSkip
+	}
+	for _, extra := range extras {
+		if params, ok := extra.(PSSParams); ok {
+			return &pssSigner{privKey, params}, nil
+		}
+	}
+	return nil, akp.ErrSkip
+}
+
+// RestrictedDecrypter returns a decrypter for an RSA private key restricted
+// to RSAES-OAEP by an OAEPParams extra.
+//
+// The decrypter only accepts *rsa.OAEPOptions,
+// with the hash functions and label of the parameters.
+func (packer packer) RestrictedDecrypter(
+	priv interface{}, extras []interface{},
+) (crypto.Decrypter, error) {
+	privKey, ok := priv.(*rsa.PrivateKey)
+	if !ok {
+		return nil, akp.ErrSkip
+	}
+	for _, extra := range extras {
+		if params, ok := extra.(OAEPParams); ok {
+			return &oaepDecrypter{privKey, params}, nil
+		}
+	}
+	return nil, akp.ErrSkip
+}
+
+// pssSigner is an RSA private key restricted to RSASSA-PSS.
+//
+// It does not embed the key, so that it cannot be asserted to a
+// crypto.Decrypter.
+type pssSigner struct {
+	privKey *rsa.PrivateKey
+	params  PSSParams
+}
+
+func (signer *pssSigner) Public() crypto.PublicKey {
+	return &signer.privKey.PublicKey
+}
+
+func (signer *pssSigner) Sign(
+	rand io.Reader, digest []byte, opts crypto.SignerOpts,
+) ([]byte, error) {
+	pssOpts, ok := opts.(*rsa.PSSOptions)
+	if !ok {
+		return nil, fmt.Errorf(
+			"RSA key is restricted to RSASSA-PSS, but got %T options", opts)
+	}
+	if signer.params != (PSSParams{}) {
+		p := signer.params.withDefaults()
+		if pssOpts.Hash != p.Hash {
+			return nil, fmt.Errorf(
+				"RSA-PSS key is restricted to %v, but got %v",
+				p.Hash, pssOpts.Hash)
+		}
+		if p.MGFHash != p.Hash {
+			return nil, fmt.Errorf("cannot sign with MGF1 with %v", p.MGFHash)
+		}
+		saltLength := pssOpts.SaltLength
+		if saltLength == rsa.PSSSaltLengthEqualsHash {
+			saltLength = p.Hash.Size()
+		}
+		if saltLength != p.SaltLength {
+			return nil, fmt.Errorf(
+				"RSA-PSS key is restricted to a %d-byte salt, but got %d",
+				p.SaltLength, pssOpts.SaltLength)
+		}
+	}
+	return signer.privKey.Sign(rand, digest, pssOpts)
+}
+
+// oaepDecrypter is an RSA private key restricted to RSAES-OAEP.
+type oaepDecrypter struct {
+	privKey *rsa.PrivateKey
+	params  OAEPParams
+}
+
+func (decrypter *oaepDecrypter) Public() crypto.PublicKey {
+	return &decrypter.privKey.PublicKey
+}
+
+func (decrypter *oaepDecrypter) Decrypt(
+	rand io.Reader, ciphertext []byte, opts crypto.DecrypterOpts,
+) ([]byte, error) {
+	oaepOpts, ok := opts.(*rsa.OAEPOptions)
+	if !ok {
+		return nil, fmt.Errorf(
+			"RSA key is restricted to RSAES-OAEP, but got %T options", opts)
+	}
+	p := decrypter.params.withDefaults()
+	mgfHash := oaepOpts.MGFHash
+	if mgfHash == 0 {
+		mgfHash = oaepOpts.Hash
+	}
+	if oaepOpts.Hash != p.Hash || mgfHash != p.MGFHash ||
+		!bytes.Equal(oaepOpts.Label, p.Label) {
+		return nil, errors.New(
+			"OAEP options do not match the parameters of the RSA-OAEP key")
+	}
+	return decrypter.privKey.Decrypt(rand, ciphertext, oaepOpts)
+}
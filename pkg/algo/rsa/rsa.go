@@ -11,5 +11,5 @@ var algorithmOID = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 1}
 
 func init() {
 	akp.Packers.Register(Packer, &rsa.PrivateKey{})
-	akp.Unpackers.Register(Unpacker, algorithmOID)
+	akp.Unpackers.Register(Unpacker, algorithmOID, pssOID, oaepOID)
 }
@@ -1,12 +1,23 @@
 package rsakp
 
 import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
 	"crypto/rand"
 	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/x509"
 	"encoding/asn1"
 	"encoding/base64"
+	"encoding/pem"
+	"errors"
 	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
@@ -57,16 +68,16 @@ func TestPacker_Pack(t *testing.T) {
 		t.Fatalf("cannot generate RSA key pair: %v", err)
 	}
 	t.Run("BadPrivateKey", func(t *testing.T) {
-        for _, priv := range []interface{}{
-        	nil, 0, 1, "", "OMG", struct{}{},
+		for _, priv := range []interface{}{
+			nil, 0, 1, "", "OMG", struct{}{},
 		} {
-            t.Run(fmt.Sprintf("%v", priv), func(t *testing.T) {
-            	pkg, err := Packer.Pack(priv, nil)
-            	if err != akp.ErrSkip {
-            		t.Errorf("Pack returned %+v; expected %+v", err, akp.ErrSkip)
+			t.Run(fmt.Sprintf("%v", priv), func(t *testing.T) {
+				pkg, err := Packer.Pack(priv, nil)
+				if err != akp.ErrSkip {
+					t.Errorf("Pack returned %+v; expected %+v", err, akp.ErrSkip)
 				}
-            	if pkg != nil {
-            		t.Errorf("Pack returned non-nil key package %+v", pkg)
+				if pkg != nil {
+					t.Errorf("Pack returned non-nil key package %+v", pkg)
 				}
 			})
 		}
@@ -86,4 +97,422 @@ func TestPacker_Pack(t *testing.T) {
 			})
 		}
 	})
-}
\ No newline at end of file
+}
+
+func TestFingerprint(t *testing.T) {
+	t.Run("RFC7638", func(t *testing.T) {
+		// RFC 7638, section 3.1.
+		n, err := base64.RawURLEncoding.DecodeString(
+			"0vx7agoebGcQSuuPiLJXZptN9nndrQmbXEps2aiAFbWhM78LhWx4cbbfAAtVT86zwu" +
+				"1RK7aPFFxuhDR1L6tSoc_BJECPebWKRXjBZCiFV4n3oknjhMstn64tZ_2W-5Js" +
+				"GY4Hc5n9yBXArwl93lqt7_RN5w6Cf0h4QyQ5v-65YGjQR0_FDW2QvzqY368QQMi" +
+				"cAtaSqzs8KJZgnYb9c7d0zgdAZHzu6qMQvRL5hajrn1n91CbOpbISD08qNLyrdkt" +
+				"-bFTWhAI4vMQFh6WeZu0fM4lFd2NcRwr3XPksINHaQ-G_xBniIqbw0Ls1jF44-c" +
+				"sFCur-kEgU8awapJzKnqDKgw",
+		)
+		if err != nil {
+			t.Fatalf("cannot decode modulus: %v", err)
+		}
+		pub := &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: 65537}
+		priv := &rsa.PrivateKey{PublicKey: *pub}
+		fp, err := akp.KeyFingerprint(priv, pub)
+		if err != nil {
+			t.Fatalf("cannot fingerprint RSA key: %v", err)
+		}
+		const expected = "NzbLsXh8uDCcd-6MNwXF4W_7noWXFZAfHkxZsRGC9Xs"
+		if got := fp.JWKThumbprintString(); got != expected {
+			t.Errorf("JWK thumbprint is %s; expected %s", got, expected)
+		}
+	})
+	t.Run("DerivedPublic", func(t *testing.T) {
+		priv, err := rsa.GenerateKey(rand.Reader, 1024)
+		if err != nil {
+			t.Fatalf("cannot generate RSA key pair: %v", err)
+		}
+		withPub, err := Pack(priv, &priv.PublicKey)
+		if err != nil {
+			t.Fatalf("cannot pack RSA key pair: %v", err)
+		}
+		withoutPub, err := Pack(priv, nil)
+		if err != nil {
+			t.Fatalf("cannot pack RSA key pair: %v", err)
+		}
+		fp1, err := withPub.Fingerprint()
+		if err != nil {
+			t.Fatalf("cannot fingerprint key package: %v", err)
+		}
+		fp2, err := withoutPub.Fingerprint()
+		if err != nil {
+			t.Fatalf("cannot fingerprint key package: %v", err)
+		}
+		if !reflect.DeepEqual(fp1, fp2) {
+			t.Errorf("fingerprints differ: %+v vs. %+v", fp1, fp2)
+		}
+		pubBytes := x509.MarshalPKCS1PublicKey(&priv.PublicKey)
+		keyID1 := sha1.Sum(pubBytes)
+		if !bytes.Equal(fp1.KeyID1, keyID1[:]) {
+			t.Errorf("KeyID1 is %x; expected %x", fp1.KeyID1, keyID1)
+		}
+		if len(fp1.KeyID2) != 8 || fp1.KeyID2[0]>>4 != 4 ||
+			!bytes.Equal(fp1.KeyID2[1:], keyID1[13:]) {
+			t.Errorf("KeyID2 %x does not match KeyID1 %x", fp1.KeyID2, keyID1)
+		}
+		if !strings.HasPrefix(fp1.SSHSHA256, "SHA256:") {
+			t.Errorf("unexpected SSH fingerprint %q", fp1.SSHSHA256)
+		}
+	})
+}
+
+func TestPublicKeyInfo(t *testing.T) {
+	priv, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatalf("cannot generate RSA key pair: %v", err)
+	}
+	expected, err := x509.MarshalPKIXPublicKey(&priv.PublicKey)
+	if err != nil {
+		t.Fatalf("cannot marshal RSA public key: %v", err)
+	}
+	subtest := func(t *testing.T, pub *rsa.PublicKey) {
+		pkg, err := Pack(priv, pub)
+		if err != nil {
+			t.Fatalf("cannot pack RSA key pair: %v", err)
+		}
+		der, err := pkg.MarshalPublicKeyInfo()
+		if err != nil {
+			t.Fatalf("cannot marshal SubjectPublicKeyInfo: %v", err)
+		}
+		if !bytes.Equal(der, expected) {
+			t.Errorf("SubjectPublicKeyInfo is %x; expected %x", der, expected)
+		}
+	}
+	t.Run("WithoutPublic", func(t *testing.T) { subtest(t, nil) })
+	t.Run("WithPublic", func(t *testing.T) { subtest(t, &priv.PublicKey) })
+}
+
+func TestLoadAs(t *testing.T) {
+	priv, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatalf("cannot generate RSA key pair: %v", err)
+	}
+	filename := filepath.Join(t.TempDir(), "key.der")
+	if err = akp.Save(filename, priv, &priv.PublicKey); err != nil {
+		t.Fatalf("cannot save RSA key pair: %v", err)
+	}
+	priv2, pub2, _, err := akp.LoadAs[*rsa.PrivateKey, *rsa.PublicKey](filename)
+	if err != nil {
+		t.Fatalf("cannot load RSA key pair: %v", err)
+	}
+	if !reflect.DeepEqual(priv, priv2) {
+		t.Errorf("loaded key %+v is different from the original %+v", priv2, priv)
+	}
+	if !reflect.DeepEqual(&priv.PublicKey, pub2) {
+		t.Errorf("expected public key %+v but got %+v", &priv.PublicKey, pub2)
+	}
+	_, _, _, err = akp.LoadAs[*ecdsa.PrivateKey, *ecdsa.PublicKey](filename)
+	var mismatch *akp.TypeMismatchError
+	if !errors.As(err, &mismatch) || mismatch.Public {
+		t.Errorf("LoadAs returned %+v; expected private key type mismatch", err)
+	}
+}
+
+// The restricted key testdata files were generated by OpenSSL 3.0:
+//
+//	openssl genpkey -algorithm RSA-PSS -pkeyopt rsa_keygen_bits:1024 \
+//		-out pss.pem
+//	openssl genpkey -algorithm RSA-PSS -pkeyopt rsa_keygen_bits:1024 \
+//		-pkeyopt rsa_pss_keygen_md:sha256 \
+//		-pkeyopt rsa_pss_keygen_mgf1_md:sha256 \
+//		-pkeyopt rsa_pss_keygen_saltlen:32 -out pss256.pem
+
+func readPEM(t *testing.T, filename string) []byte {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		t.Fatalf("%s: no PEM block", filename)
+	}
+	return block.Bytes
+}
+
+func TestRestricted(t *testing.T) {
+	for _, test := range []struct {
+		filename string
+		params   PSSParams
+	}{
+		{"testdata/pss.pem", PSSParams{}},
+		{"testdata/pss256.pem", PSSParams{
+			Hash: crypto.SHA256, MGFHash: crypto.SHA256, SaltLength: 32,
+		}},
+	} {
+		t.Run(test.filename, func(t *testing.T) {
+			der := readPEM(t, test.filename)
+			priv, pub, extras, err := akp.Decode(der)
+			if err != nil {
+				t.Fatalf("cannot decode RSA-PSS key: %v", err)
+			}
+			if !reflect.DeepEqual(extras, []interface{}{test.params}) {
+				t.Errorf("extras are %+v; expected %+v",
+					extras, []interface{}{test.params})
+			}
+			encoded, err := akp.Encode(priv, pub, extras...)
+			if err != nil {
+				t.Fatalf("cannot encode RSA-PSS key: %v", err)
+			}
+			if !bytes.Equal(encoded, der) {
+				t.Errorf("re-encoded key is %x; expected %x", encoded, der)
+			}
+			var pkg akp.OneAsymmetricKey
+			if _, err = asn1.Unmarshal(der, &pkg); err != nil {
+				t.Fatal(err)
+			}
+			if c := pkg.Capabilities(); c != akp.CanSign {
+				t.Errorf("capabilities are %v; expected %v", c, akp.CanSign)
+			}
+			opts := test.params.SignerOpts()
+			digest := make([]byte, opts.Hash.Size())
+			signer := priv.(*rsa.PrivateKey)
+			sig, err := signer.Sign(rand.Reader, digest, opts)
+			if err != nil {
+				t.Fatalf("cannot sign: %v", err)
+			}
+			err = rsa.VerifyPSS(&signer.PublicKey, opts.Hash, digest, sig, opts)
+			if err != nil {
+				t.Errorf("cannot verify signature: %v", err)
+			}
+		})
+	}
+	priv, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatalf("cannot generate RSA key pair: %v", err)
+	}
+	for _, params := range []interface{}{
+		PSSParams{Hash: crypto.SHA1, SaltLength: 20},
+		PSSParams{Hash: crypto.SHA512, MGFHash: crypto.SHA384, SaltLength: 64},
+		OAEPParams{},
+		OAEPParams{Hash: crypto.SHA256, MGFHash: crypto.SHA256,
+			Label: []byte("label")},
+	} {
+		t.Run(fmt.Sprintf("%+v", params), func(t *testing.T) {
+			pkg, err := Pack(priv, nil, params)
+			if err != nil {
+				t.Fatalf("cannot pack RSA key: %v", err)
+			}
+			_, _, extras, err := Unpack(pkg)
+			if err != nil {
+				t.Fatalf("cannot unpack RSA key: %v", err)
+			}
+			var expected interface{}
+			switch params := params.(type) {
+			case PSSParams:
+				expected = params.withDefaults()
+			case OAEPParams:
+				expected = params.withDefaults()
+			}
+			if !reflect.DeepEqual(extras, []interface{}{expected}) {
+				t.Errorf("extras are %+v; expected %+v", extras, expected)
+			}
+		})
+	}
+	t.Run("OAEP", func(t *testing.T) {
+		params := OAEPParams{
+			Hash: crypto.SHA256, MGFHash: crypto.SHA256, Label: []byte("label"),
+		}
+		encoded, err := akp.Encode(priv, nil, params)
+		if err != nil {
+			t.Fatalf("cannot encode RSA-OAEP key: %v", err)
+		}
+		decrypter, err := akp.DecodeDecrypter(encoded)
+		if err != nil {
+			t.Fatalf("cannot decode RSA-OAEP key: %v", err)
+		}
+		opts := params.DecrypterOpts()
+		ciphertext, err := rsa.EncryptOAEP(opts.Hash.New(), rand.Reader,
+			&priv.PublicKey, []byte("hello"), opts.Label)
+		if err != nil {
+			t.Fatalf("cannot encrypt: %v", err)
+		}
+		plaintext, err := decrypter.Decrypt(rand.Reader, ciphertext, opts)
+		if err != nil || string(plaintext) != "hello" {
+			t.Errorf("Decrypt returned %q, %v", plaintext, err)
+		}
+		for _, opts := range []crypto.DecrypterOpts{
+			nil,
+			&rsa.PKCS1v15DecryptOptions{},
+			&rsa.OAEPOptions{Hash: crypto.SHA1, Label: opts.Label},
+			&rsa.OAEPOptions{Hash: crypto.SHA256, MGFHash: crypto.SHA1,
+				Label: opts.Label},
+			&rsa.OAEPOptions{Hash: crypto.SHA256},
+		} {
+			_, err = decrypter.Decrypt(rand.Reader, ciphertext, opts)
+			if err == nil {
+				t.Errorf("Decrypt accepted %+v", opts)
+			}
+		}
+		if _, ok := decrypter.(crypto.Signer); ok {
+			t.Error("RSA-OAEP decrypter is a signer")
+		}
+		if _, err = akp.DecodeSigner(encoded); !errors.Is(
+			err, akp.ErrNotSigner) {
+			t.Errorf("DecodeSigner returned %v; expected %v",
+				err, akp.ErrNotSigner)
+		}
+	})
+	t.Run("PSS", func(t *testing.T) {
+		params := PSSParams{
+			Hash: crypto.SHA256, MGFHash: crypto.SHA256, SaltLength: 32,
+		}
+		encoded, err := akp.Encode(priv, nil, params)
+		if err != nil {
+			t.Fatalf("cannot encode RSA-PSS key: %v", err)
+		}
+		signer, err := akp.DecodeSigner(encoded)
+		if err != nil {
+			t.Fatalf("cannot decode RSA-PSS key: %v", err)
+		}
+		digest := make([]byte, crypto.SHA256.Size())
+		for _, opts := range []crypto.SignerOpts{
+			params.SignerOpts(),
+			&rsa.PSSOptions{
+				Hash: crypto.SHA256, SaltLength: rsa.PSSSaltLengthEqualsHash,
+			},
+		} {
+			sig, err := signer.Sign(rand.Reader, digest, opts)
+			if err != nil {
+				t.Errorf("cannot sign with %+v: %v", opts, err)
+				continue
+			}
+			err = rsa.VerifyPSS(&priv.PublicKey, crypto.SHA256, digest, sig,
+				params.SignerOpts())
+			if err != nil {
+				t.Errorf("cannot verify signature: %v", err)
+			}
+		}
+		for _, opts := range []crypto.SignerOpts{
+			crypto.SHA256,
+			&rsa.PSSOptions{Hash: crypto.SHA384, SaltLength: 32},
+			&rsa.PSSOptions{Hash: crypto.SHA256, SaltLength: 20},
+			&rsa.PSSOptions{
+				Hash: crypto.SHA256, SaltLength: rsa.PSSSaltLengthAuto,
+			},
+		} {
+			if _, err = signer.Sign(rand.Reader, digest, opts); err == nil {
+				t.Errorf("Sign accepted %+v", opts)
+			}
+		}
+		if _, ok := signer.(crypto.Decrypter); ok {
+			t.Error("RSA-PSS signer is a decrypter")
+		}
+		if _, err = akp.DecodeDecrypter(encoded); !errors.Is(
+			err, akp.ErrNotDecrypter) {
+			t.Errorf("DecodeDecrypter returned %v; expected %v",
+				err, akp.ErrNotDecrypter)
+		}
+	})
+	t.Run("UnsupportedHash", func(t *testing.T) {
+		_, err := Pack(priv, nil, PSSParams{Hash: crypto.MD5})
+		if err == nil {
+			t.Error("Pack accepted MD5")
+		}
+	})
+	t.Run("EmptySalt", func(t *testing.T) {
+		// RFC 4055 allows a zero saltLength, but crypto/rsa cannot sign
+		// with an empty salt, so Unpack rejects it rather than read it as
+		// the default of 20.
+		pkg, err := Pack(priv, nil, PSSParams{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		der, err := asn1.Marshal(asn1PSSParams{TrailerField: 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		pkg.PrivateKeyAlgorithm.Parameters = asn1.RawValue{FullBytes: der}
+		if _, _, _, err = Unpack(pkg); err == nil {
+			t.Error("Unpack accepted an empty PSS salt")
+		}
+	})
+	t.Run("SeveralRestrictions", func(t *testing.T) {
+		_, err := Pack(priv, nil, PSSParams{}, OAEPParams{})
+		if err == nil {
+			t.Error("Pack accepted both PSS and OAEP parameters")
+		}
+	})
+}
+
+// The multi-prime testdata files were generated by OpenSSL 3.0:
+//
+//	openssl genpkey -algorithm RSA -pkeyopt rsa_keygen_bits:1024 \
+//		-pkeyopt rsa_keygen_primes:3 -out multiprime3.pem
+//	openssl genpkey -algorithm RSA -pkeyopt rsa_keygen_bits:4096 \
+//		-pkeyopt rsa_keygen_primes:4 -out multiprime4.pem
+
+func TestMultiPrime(t *testing.T) {
+	for _, test := range []struct {
+		filename string
+		primes   int
+	}{
+		{"testdata/multiprime3.pem", 3},
+		{"testdata/multiprime4.pem", 4},
+	} {
+		t.Run(test.filename, func(t *testing.T) {
+			der := readPEM(t, test.filename)
+			var pkg akp.OneAsymmetricKey
+			if _, err := asn1.Unmarshal(der, &pkg); err != nil {
+				t.Fatal(err)
+			}
+			priv, _, _, err := Unpack(&pkg)
+			if err != nil {
+				t.Fatalf("cannot unpack multi-prime RSA key: %v", err)
+			}
+			if len(priv.Primes) != test.primes {
+				t.Errorf("key has %d primes; expected %d",
+					len(priv.Primes), test.primes)
+			}
+			encoded, err := akp.Encode(priv, nil)
+			if err != nil {
+				t.Fatalf("cannot encode multi-prime RSA key: %v", err)
+			}
+			if !bytes.Equal(encoded, der) {
+				t.Errorf("re-encoded key is %x; expected %x", encoded, der)
+			}
+
+			var raw asn1RSAPrivateKey
+			if _, err = asn1.Unmarshal(pkg.PrivateKey, &raw); err != nil {
+				t.Fatal(err)
+			}
+			info := &raw.OtherPrimeInfos[len(raw.OtherPrimeInfos)-1]
+			info.Coefficient.Add(info.Coefficient, big.NewInt(1))
+			if pkg.PrivateKey, err = asn1.Marshal(raw); err != nil {
+				t.Fatal(err)
+			}
+			if _, _, _, err = Unpack(&pkg); err != errBadCRTValue {
+				t.Errorf("Unpack returned %v for a bad CRT coefficient; "+
+					"expected %v", err, errBadCRTValue)
+			}
+		})
+	}
+	t.Run("Generated", func(t *testing.T) {
+		priv, err := rsa.GenerateMultiPrimeKey(rand.Reader, 3, 1024)
+		if err != nil {
+			t.Fatalf("cannot generate multi-prime RSA key: %v", err)
+		}
+		pkg, err := Pack(priv, &priv.PublicKey)
+		if err != nil {
+			t.Fatalf("cannot pack multi-prime RSA key: %v", err)
+		}
+		priv2, _, _, err := Unpack(pkg)
+		if err != nil {
+			t.Fatalf("cannot unpack multi-prime RSA key: %v", err)
+		}
+		if !priv.Equal(priv2) || len(priv2.Primes) != 3 {
+			t.Errorf("unpacked key %+v is different from the original %+v",
+				priv2, priv)
+		}
+		priv.Primes[2] = new(big.Int).Add(priv.Primes[2], big.NewInt(2))
+		if _, err = Pack(priv, nil); err == nil {
+			t.Error("Pack accepted primes that do not multiply to N")
+		}
+	})
+}
@@ -0,0 +1,81 @@
+package rsakp
+
+import (
+	"crypto/rsa"
+	"errors"
+	"math/big"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+	"github.com/harmony-one/asym-key-pkgs/pkg/internal/sshwire"
+)
+
+const sshKeyType = "ssh-rsa"
+
+// MarshalSSHPublicKey encodes an RSA public key in the "ssh-rsa" format
+// (RFC 4253, section 6.6).
+func (packer packer) MarshalSSHPublicKey(pub interface{}) ([]byte, error) {
+	pubKey, ok := pub.(*rsa.PublicKey)
+	if !ok {
+		return nil, akp.ErrSkip
+	}
+	var b sshwire.Builder
+	b.String([]byte(sshKeyType))
+	b.MPInt(big.NewInt(int64(pubKey.E)))
+	b.MPInt(pubKey.N)
+	return b.Bytes(), nil
+}
+
+// SSHKeyTypes returns the SSH key type name of RSA keys.
+func (packer packer) SSHKeyTypes() []string {
+	return []string{sshKeyType}
+}
+
+// MarshalSSHPrivateKey encodes an RSA private key as the OpenSSH fields
+// n, e, d, iqmp, p and q.
+func (packer packer) MarshalSSHPrivateKey(priv interface{}) (
+	keyType string, fields []byte, err error,
+) {
+	privKey, ok := priv.(*rsa.PrivateKey)
+	if !ok {
+		return "", nil, akp.ErrSkip
+	}
+	if len(privKey.Primes) != 2 {
+		return "", nil, errors.New("OpenSSH supports only two-prime RSA keys")
+	}
+	privKey.Precompute()
+	var b sshwire.Builder
+	b.MPInt(privKey.N).MPInt(big.NewInt(int64(privKey.E))).MPInt(privKey.D)
+	b.MPInt(privKey.Precomputed.Qinv)
+	b.MPInt(privKey.Primes[0]).MPInt(privKey.Primes[1])
+	return sshKeyType, b.Bytes(), nil
+}
+
+// UnmarshalSSHPrivateKey parses the OpenSSH fields of an RSA private key.
+func (packer packer) UnmarshalSSHPrivateKey(keyType string, data []byte) (
+	priv interface{}, pub interface{}, rest []byte, err error,
+) {
+	if keyType != sshKeyType {
+		return nil, nil, nil, akp.ErrSkip
+	}
+	p := sshwire.NewParser(data)
+	var ints [6]*big.Int
+	for i := range ints {
+		if ints[i], err = p.MPInt(); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+	n, e, d, p1, p2 := ints[0], ints[1], ints[2], ints[4], ints[5]
+	if !e.IsInt64() || e.Int64() > 1<<31-1 {
+		return nil, nil, nil, errors.New("RSA public exponent out of range")
+	}
+	privKey := &rsa.PrivateKey{
+		PublicKey: rsa.PublicKey{N: n, E: int(e.Int64())},
+		D:         d,
+		Primes:    []*big.Int{p1, p2},
+	}
+	if err = privKey.Validate(); err != nil {
+		return nil, nil, nil, err
+	}
+	privKey.Precompute()
+	return privKey, &privKey.PublicKey, p.Rest(), nil
+}
@@ -16,17 +16,24 @@ var Unpacker unpacker
 func (unpacker unpacker) Unpack(pkg *akp.OneAsymmetricKey) (
 	priv interface{}, pub interface{}, extras []interface{}, err error,
 ) {
-	priv, err = x509.ParsePKCS1PrivateKey(pkg.PrivateKey)
+	if extras, err = unmarshalRestriction(pkg); err != nil {
+		return nil, nil, nil, err
+	}
+	privKey, err := x509.ParsePKCS1PrivateKey(pkg.PrivateKey)
 	if err != nil {
 		return nil, nil, nil, err
 	}
+	if err = checkMultiPrime(pkg.PrivateKey, privKey); err != nil {
+		return nil, nil, nil, err
+	}
+	priv = privKey
 	if pkg.PublicKey.Bytes != nil {
 		pub, err = x509.ParsePKCS1PublicKey(pkg.PublicKey.Bytes)
 		if err != nil {
 			return nil, nil, nil, err
 		}
 	}
-	return priv, pub, nil, nil
+	return priv, pub, extras, nil
 }
 
 // ErrNotRSA means the unpacked key is not an RSA key.
@@ -36,19 +43,25 @@ var ErrNotRSA = errors.New("not an RSA key")
 func Unpack(pkg *akp.OneAsymmetricKey) (
 	priv *rsa.PrivateKey, pub *rsa.PublicKey, extras []interface{}, err error,
 ) {
-	privKey, pubKey, extras, err := Unpacker.Unpack(pkg)
-	if err != nil {
-		return nil, nil, nil, err
-	}
-	var ok bool
-	if priv, ok = privKey.(*rsa.PrivateKey); !ok {
+	priv, pub, extras, err = akp.UnpackWith[*rsa.PrivateKey, *rsa.PublicKey](
+		Unpacker, pkg)
+	var mismatch *akp.TypeMismatchError
+	if errors.As(err, &mismatch) {
 		return nil, nil, nil, ErrNotRSA
 	}
-	if pubKey == nil {
-		return priv, nil, extras, nil
-	}
-	if pub, ok = pubKey.(*rsa.PublicKey); !ok {
-		return nil, nil, nil, ErrNotRSA
+	return priv, pub, extras, err
+}
+
+// Capabilities reports that RSA keys can sign and decrypt,
+// unless restricted to RSASSA-PSS (sign only) or RSAES-OAEP (decrypt only).
+func (unpacker unpacker) Capabilities(
+	pkg *akp.OneAsymmetricKey,
+) akp.Capability {
+	switch alg := pkg.PrivateKeyAlgorithm.Algorithm; {
+	case alg.Equal(pssOID):
+		return akp.CanSign
+	case alg.Equal(oaepOID):
+		return akp.CanDecrypt
 	}
-	return priv, pub, extras, nil
+	return akp.CanSign | akp.CanDecrypt
 }
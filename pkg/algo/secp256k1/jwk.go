@@ -0,0 +1,102 @@
+package secp256k1kp
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+	"github.com/harmony-one/asym-key-pkgs/pkg/internal/jwkfield"
+)
+
+const (
+	jwkKeyType = "EC"
+	jwkCurve   = "secp256k1" // RFC 8812, section 3.1
+)
+
+// JWKThumbprintMembers returns the required members of a secp256k1 JWK
+// (RFC 7518, section 6.2.1).
+func (packer packer) JWKThumbprintMembers(pub interface{}) (
+	map[string]string, error,
+) {
+	pubKey, ok := pub.(*ecdsa.PublicKey)
+	if !ok || !isS256(pubKey) {
+		return nil, akp.ErrSkip
+	}
+	return map[string]string{
+		"kty": jwkKeyType,
+		"crv": jwkCurve,
+		"x":   jwkfield.FixedInt(pubKey.X, privKeyLen),
+		"y":   jwkfield.FixedInt(pubKey.Y, privKeyLen),
+	}, nil
+}
+
+// JWKKeyTypes returns the JWK key type of secp256k1 keys.
+func (packer packer) JWKKeyTypes() []string {
+	return []string{jwkKeyType}
+}
+
+// MarshalJWK returns the members of a secp256k1 private JWK
+// (RFC 7518, section 6.2.2).
+func (packer packer) MarshalJWK(priv interface{}) (
+	map[string]interface{}, error,
+) {
+	privKey, ok := priv.(*ecdsa.PrivateKey)
+	if !ok || !isS256(&privKey.PublicKey) {
+		return nil, akp.ErrSkip
+	}
+	pub, err := packer.DerivePublicKey(privKey)
+	if err != nil {
+		return nil, err
+	}
+	pubKey := pub.(*ecdsa.PublicKey)
+	return map[string]interface{}{
+		"kty": jwkKeyType,
+		"crv": jwkCurve,
+		"x":   jwkfield.FixedInt(pubKey.X, privKeyLen),
+		"y":   jwkfield.FixedInt(pubKey.Y, privKeyLen),
+		"d":   jwkfield.FixedInt(privKey.D, privKeyLen),
+	}, nil
+}
+
+// UnmarshalJWK converts the members of a secp256k1 private JWK into a
+// secp256k1 key pair.
+//
+// It skips JWKs for other curves.
+// The public key is recomputed and must match the "x" and "y" members.
+func (packer packer) UnmarshalJWK(members map[string]interface{}) (
+	priv interface{}, pub interface{}, err error,
+) {
+	if crv, _ := members["crv"].(string); crv != jwkCurve {
+		return nil, nil, akp.ErrSkip
+	}
+	var coords [2]*big.Int
+	for i, name := range []string{"x", "y"} {
+		b, err := jwkfield.GetBytes(members, name)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(b) != privKeyLen {
+			return nil, nil, errors.New("bad secp256k1 coordinate length")
+		}
+		coords[i] = new(big.Int).SetBytes(b)
+	}
+	if _, ok := members["d"]; !ok {
+		return nil, nil, errors.New("JWK has no private key")
+	}
+	d, err := jwkfield.GetBytes(members, "d")
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(d) != privKeyLen {
+		return nil, nil, errors.New("bad secp256k1 private key length")
+	}
+	privKey, err := newPrivateKey(new(big.Int).SetBytes(d))
+	if err != nil {
+		return nil, nil, err
+	}
+	if privKey.X.Cmp(coords[0]) != 0 || privKey.Y.Cmp(coords[1]) != 0 {
+		return nil, nil, errors.New("public key does not match private key")
+	}
+	return privKey, &privKey.PublicKey, nil
+}
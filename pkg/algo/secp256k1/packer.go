@@ -0,0 +1,103 @@
+package secp256k1kp
+
+import (
+	"crypto/ecdsa"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+	"github.com/harmony-one/asym-key-pkgs/pkg/internal/ecurve"
+)
+
+type packer struct{}
+
+// Packer is the singleton packer instance.
+var Packer packer
+
+// isS256 reports whether the given public key is on the secp256k1 curve.
+func isS256(pubKey *ecdsa.PublicKey) bool {
+	return pubKey.Curve == S256()
+}
+
+func (packer packer) Pack(
+	priv interface{}, pub interface{}, options ...interface{},
+) (pkg *akp.OneAsymmetricKey, err error) {
+	var (
+		privKey *ecdsa.PrivateKey
+		pubKey  *ecdsa.PublicKey
+		ok      bool
+	)
+	privKey, ok = priv.(*ecdsa.PrivateKey)
+	if !ok || !isS256(&privKey.PublicKey) {
+		return nil, akp.ErrSkip
+	}
+	if pub == nil {
+		return Pack(privKey, nil, options...)
+	}
+	pubKey, ok = pub.(*ecdsa.PublicKey)
+	if !ok || !isS256(pubKey) {
+		return nil, akp.ErrSkip
+	}
+	return Pack(privKey, pubKey, options...)
+}
+
+// Pack packs the given secp256k1 key pair into a key package.
+//
+// A PointFormat option selects the encoding of the public key.
+func Pack(
+	privKey *ecdsa.PrivateKey, pubKey *ecdsa.PublicKey, options ...interface{},
+) (pkg *akp.OneAsymmetricKey, err error) {
+	if !isS256(&privKey.PublicKey) {
+		return nil, errors.New("private key is not on the secp256k1 curve")
+	}
+	if privKey.D == nil || privKey.D.Sign() <= 0 ||
+		privKey.D.Cmp(s256.Params().N) >= 0 {
+		return nil, errors.New("secp256k1 private key out of range")
+	}
+	format := Uncompressed
+	for _, option := range options {
+		if f, ok := option.(PointFormat); ok {
+			format = f
+		}
+	}
+	params, err := asn1.Marshal(curveOID)
+	if err != nil {
+		return nil, err
+	}
+	pkg = &akp.OneAsymmetricKey{
+		Version: akp.V1,
+		PrivateKeyAlgorithm: pkix.AlgorithmIdentifier{
+			Algorithm:  algorithmOID,
+			Parameters: asn1.RawValue{FullBytes: params},
+		},
+	}
+	// RFC 5915, section 3: the private key is an octet string of
+	// ceiling(log2(n)/8) bytes.
+	pkg.PrivateKey, err = asn1.Marshal(ecurve.ECPrivateKey{
+		Version:    ecurve.ECPrivateKeyVersion,
+		PrivateKey: privKey.D.FillBytes(make([]byte, privKeyLen)),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if pubKey != nil {
+		if !isS256(pubKey) {
+			return nil, errors.New("public key is not on the secp256k1 curve")
+		}
+		if privKey.X != nil && !pubKey.Equal(&privKey.PublicKey) {
+			return nil, errors.New(
+				"secp256k1 public key does not match private key")
+		}
+		pkg.PublicKey = packPublicKey(pubKey, format)
+		pkg.Version = akp.V2
+	}
+	return pkg, nil
+}
+
+func packPublicKey(
+	pubKey *ecdsa.PublicKey, format PointFormat,
+) asn1.BitString {
+	point := ecurve.MarshalPoint(s256, pubKey.X, pubKey.Y, format)
+	return asn1.BitString{Bytes: point, BitLength: 8 * len(point)}
+}
@@ -0,0 +1,34 @@
+package secp256k1kp
+
+import (
+	"crypto/ecdsa"
+	"encoding/asn1"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+)
+
+// DerivePublicKey returns the public portion of a secp256k1 private key.
+func (packer packer) DerivePublicKey(priv interface{}) (
+	pub interface{}, err error,
+) {
+	privKey, ok := priv.(*ecdsa.PrivateKey)
+	if !ok || !isS256(&privKey.PublicKey) {
+		return nil, akp.ErrSkip
+	}
+	if privKey.X == nil {
+		if privKey, err = newPrivateKey(privKey.D); err != nil {
+			return nil, err
+		}
+	}
+	return &privKey.PublicKey, nil
+}
+
+// PackPublicKey packs a secp256k1 public key as an uncompressed point
+// (RFC 5480, section 2.2).
+func (packer packer) PackPublicKey(pub interface{}) (asn1.BitString, error) {
+	pubKey, ok := pub.(*ecdsa.PublicKey)
+	if !ok || !isS256(pubKey) {
+		return asn1.BitString{}, akp.ErrSkip
+	}
+	return packPublicKey(pubKey, Uncompressed), nil
+}
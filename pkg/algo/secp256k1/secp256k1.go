@@ -0,0 +1,90 @@
+// Package secp256k1kp implements secp256k1 (SEC 2, section 2.4.1) keys,
+// as used by Bitcoin and Ethereum,
+// as id-ecPublicKey keys with the secp256k1 named curve (RFC 5480) and an
+// RFC 5915 ECPrivateKey.
+// Unpack also accepts explicit curve parameters equal to those of
+// secp256k1, as some HSMs export; Pack always names the curve.
+//
+// Keys are *ecdsa.PrivateKey and *ecdsa.PublicKey values on the S256 curve,
+// a pure-Go implementation that crypto/ecdsa signs and verifies with
+// through its generic code for custom curves.
+// Its scalar multiplication, which computes public keys and the nonce
+// points of signatures, is constant-time.
+package secp256k1kp
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/asn1"
+	"errors"
+	"math/big"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+	"github.com/harmony-one/asym-key-pkgs/pkg/internal/ecurve"
+)
+
+var algorithmOID = asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}
+
+var curveOID = asn1.ObjectIdentifier{1, 3, 132, 0, 10}
+
+func init() {
+	akp.Packers.Register(Packer, &ecdsa.PrivateKey{})
+	akp.Unpackers.Register(Unpacker, algorithmOID)
+	ecurve.Register(curveOID, s256)
+}
+
+// PointFormat is the encoding of public keys, given as a Pack option.
+type PointFormat = ecurve.PointFormat
+
+// Point formats
+const (
+	Uncompressed = ecurve.Uncompressed // the default
+	Compressed   = ecurve.Compressed
+)
+
+func hexInt(s string) *big.Int {
+	n, ok := new(big.Int).SetString(s, 16)
+	if !ok {
+		panic("bad hex integer " + s)
+	}
+	return n
+}
+
+var s256 = ecurve.New(&elliptic.CurveParams{
+	Name:    "secp256k1",
+	BitSize: 256,
+	P: hexInt("FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF" +
+		"FFFFFFFFFFFFFFFFFFFFFFFEFFFFFC2F"),
+	N: hexInt("FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFE" +
+		"BAAEDCE6AF48A03BBFD25E8CD0364141"),
+	B: big.NewInt(7),
+	Gx: hexInt("79BE667EF9DCBBAC55A06295CE870B07" +
+		"029BFCDB2DCE28D959F2815B16F81798"),
+	Gy: hexInt("483ADA7726A3C4655DA4FBFC0E1108A8" +
+		"FD17B448A68554199C47D08FFB10D4B8"),
+}, new(big.Int))
+
+// S256 returns the secp256k1 curve.
+func S256() elliptic.Curve {
+	return s256
+}
+
+const privKeyLen = 32
+
+// GenerateKey generates a secp256k1 key pair.
+func GenerateKey() (*ecdsa.PrivateKey, error) {
+	return ecdsa.GenerateKey(s256, rand.Reader)
+}
+
+// newPrivateKey returns the private key with the given scalar,
+// with its public key computed.
+func newPrivateKey(d *big.Int) (*ecdsa.PrivateKey, error) {
+	if d.Sign() <= 0 || d.Cmp(s256.Params().N) >= 0 {
+		return nil, errors.New("secp256k1 private key out of range")
+	}
+	privKey := &ecdsa.PrivateKey{D: d}
+	privKey.Curve = s256
+	privKey.X, privKey.Y = s256.ScalarBaseMult(d.Bytes())
+	return privKey, nil
+}
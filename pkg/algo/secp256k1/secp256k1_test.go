@@ -0,0 +1,293 @@
+package secp256k1kp
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/asn1"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+)
+
+// The testdata files were generated by OpenSSL 3.0:
+//
+//	openssl genpkey -algorithm EC -pkeyopt ec_paramgen_curve:secp256k1 \
+//		-pkeyopt ec_param_enc:named_curve -out key.pem
+//	openssl ec -in key.pem -conv_form compressed |
+//		openssl pkcs8 -topk8 -nocrypt -out compressed.pem
+//	openssl ec -in key.pem -param_enc explicit |
+//		openssl pkcs8 -topk8 -nocrypt -out explicit.pem
+//	openssl pkey -in key.pem -pubout -out pub.pem
+//	openssl pkey -in compressed.pem -pubout -out compressed_pub.pem
+//	printf 'hello, world' |
+//		openssl dgst -sha256 -sign key.pem -out message.sig
+
+func readPEM(t *testing.T, filename string) []byte {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		t.Fatalf("%s: no PEM block", filename)
+	}
+	return block.Bytes
+}
+
+func generateKey(t *testing.T) *ecdsa.PrivateKey {
+	priv, err := GenerateKey()
+	if err != nil {
+		t.Fatalf("cannot generate secp256k1 key pair: %v", err)
+	}
+	return priv
+}
+
+func TestCurve(t *testing.T) {
+	curve := S256()
+	params := curve.Params()
+	if !curve.IsOnCurve(params.Gx, params.Gy) {
+		t.Fatal("base point is not on the curve")
+	}
+	x, y := curve.ScalarBaseMult(params.N.Bytes())
+	if x.Sign() != 0 || y.Sign() != 0 {
+		t.Errorf("N×G = (%x, %x); expected the point at infinity", x, y)
+	}
+	// 2×G, computed three ways, and its well-known x coordinate.
+	x, y = curve.ScalarBaseMult([]byte{2})
+	x2, y2 := curve.Double(params.Gx, params.Gy)
+	x3, y3 := curve.Add(params.Gx, params.Gy, params.Gx, params.Gy)
+	if x.Cmp(x2) != 0 || y.Cmp(y2) != 0 || x.Cmp(x3) != 0 || y.Cmp(y3) != 0 {
+		t.Error("2×G, G+G and double(G) differ")
+	}
+	expected, _ := new(big.Int).SetString(
+		"C6047F9441ED7D6D3045406E95C07CD85C778E4B8CEF3CA7ABAC09B95C709EE5", 16)
+	if x.Cmp(expected) != 0 {
+		t.Errorf("2×G has x = %X; expected %X", x, expected)
+	}
+	// (N - 1)×G = -G.
+	nMinus1 := new(big.Int).Sub(params.N, big.NewInt(1))
+	x, y = curve.ScalarBaseMult(nMinus1.Bytes())
+	negGy := new(big.Int).Sub(params.P, params.Gy)
+	if x.Cmp(params.Gx) != 0 || y.Cmp(negGy) != 0 {
+		t.Errorf("(N - 1)×G = (%x, %x); expected -G", x, y)
+	}
+	// The constant-time ScalarMult agrees with double-and-add.
+	k := generateKey(t).D.Bytes()
+	x, y = curve.ScalarMult(x2, y2, k)
+	ex, ey := new(big.Int), new(big.Int)
+	for _, b := range k {
+		for bit := 7; bit >= 0; bit-- {
+			ex, ey = curve.Double(ex, ey)
+			if b>>uint(bit)&1 == 1 {
+				ex, ey = curve.Add(ex, ey, x2, y2)
+			}
+		}
+	}
+	if x.Cmp(ex) != 0 || y.Cmp(ey) != 0 {
+		t.Errorf("k×(2×G) = (%x, %x); expected (%x, %x)", x, y, ex, ey)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	priv := generateKey(t)
+	subtest := func(t *testing.T, pub interface{}, options ...interface{}) {
+		pkg, err := Packer.Pack(priv, pub, options...)
+		if err != nil {
+			t.Fatalf("cannot pack secp256k1 key pair: %v", err)
+		}
+		priv2, pub2, extras, err := Unpacker.Unpack(pkg)
+		if err != nil {
+			t.Fatalf("cannot unpack secp256k1 key pair: %v", err)
+		}
+		if !priv.Equal(priv2) {
+			t.Errorf("reconstructed key %+v is different from the original %+v",
+				priv2, priv)
+		}
+		if pub == nil {
+			if pub2 != nil {
+				t.Errorf("expected no public key but got %+v", pub2)
+			}
+		} else if !priv.PublicKey.Equal(pub2) {
+			t.Errorf("expected public key %+v but got %+v", pub, pub2)
+		}
+		// A compressed public key comes back as the Compressed extra.
+		if !reflect.DeepEqual(extras, options) {
+			t.Errorf("extras are %+v; expected %+v", extras, options)
+		}
+	}
+	t.Run("WithoutPublic", func(t *testing.T) { subtest(t, nil) })
+	t.Run("WithPublic", func(t *testing.T) { subtest(t, &priv.PublicKey) })
+	t.Run("Compressed", func(t *testing.T) {
+		subtest(t, &priv.PublicKey, Compressed)
+	})
+}
+
+func TestPacker_Pack(t *testing.T) {
+	for _, priv := range []interface{}{
+		nil, 0, "OMG", struct{}{}, &ecdsa.PrivateKey{},
+	} {
+		pkg, err := Packer.Pack(priv, nil)
+		if err != akp.ErrSkip {
+			t.Errorf("Pack(%T) returned %+v; expected %+v",
+				priv, err, akp.ErrSkip)
+		}
+		if pkg != nil {
+			t.Errorf("Pack(%T) returned non-nil key package", priv)
+		}
+	}
+}
+
+func TestOpenSSL(t *testing.T) {
+	var expected *ecdsa.PrivateKey
+	for _, filename := range []string{
+		"testdata/key.pem", "testdata/compressed.pem", "testdata/explicit.pem",
+	} {
+		var pkg akp.OneAsymmetricKey
+		if _, err := asn1.Unmarshal(readPEM(t, filename), &pkg); err != nil {
+			t.Fatal(err)
+		}
+		priv, pub, _, err := Unpack(&pkg)
+		if err != nil {
+			t.Fatalf("%s: %v", filename, err)
+		}
+		if !priv.PublicKey.Equal(pub) {
+			t.Errorf("%s: public key does not match private key", filename)
+		}
+		if expected == nil {
+			expected = priv
+		} else if !expected.Equal(priv) {
+			t.Errorf("%s: private key differs", filename)
+		}
+		if pkg.Capabilities() != akp.CanSign {
+			t.Errorf("%s: capabilities are %v", filename, pkg.Capabilities())
+		}
+	}
+
+	// The compressed key keeps its point format in its
+	// SubjectPublicKeyInfo, as OpenSSL does.
+	var compressed akp.OneAsymmetricKey
+	_, err := asn1.Unmarshal(readPEM(t, "testdata/compressed.pem"),
+		&compressed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	spki, err := compressed.MarshalPublicKeyInfo()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expectedSPKI := readPEM(t, "testdata/compressed_pub.pem"); !bytes.Equal(
+		spki, expectedSPKI) {
+		t.Errorf("SubjectPublicKeyInfo is %x; expected %x", spki, expectedSPKI)
+	}
+
+	pkg, err := Pack(expected, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	spki, err = pkg.MarshalPublicKeyInfo()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expectedSPKI := readPEM(t, "testdata/pub.pem"); !bytes.Equal(
+		spki, expectedSPKI) {
+		t.Errorf("SubjectPublicKeyInfo is %x; expected %x", spki, expectedSPKI)
+	}
+
+	sig, err := os.ReadFile("testdata/message.sig")
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest := sha256.Sum256([]byte("hello, world"))
+	if !ecdsa.VerifyASN1(&expected.PublicKey, digest[:], sig) {
+		t.Error("cannot verify OpenSSL signature")
+	}
+}
+
+func TestSigner(t *testing.T) {
+	pkg, err := Pack(generateKey(t), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := akp.UnpackSigner(pkg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest := sha256.Sum256([]byte("hello, world"))
+	sig, err := signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ecdsa.VerifyASN1(signer.Public().(*ecdsa.PublicKey), digest[:], sig) {
+		t.Error("cannot verify signature")
+	}
+}
+
+func TestJWK(t *testing.T) {
+	priv := generateKey(t)
+	members, err := akp.Packers.MarshalJWK(priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if members["crv"] != "secp256k1" {
+		t.Errorf("crv is %v", members["crv"])
+	}
+	priv2, _, err := akp.Packers.UnmarshalJWK(members)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !priv.Equal(priv2) {
+		t.Errorf("JWK round trip returned %+v; expected %+v", priv2, priv)
+	}
+	members["x"] = members["y"]
+	if _, _, err = akp.Packers.UnmarshalJWK(members); err == nil {
+		t.Error("mismatching public key was accepted")
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	priv := generateKey(t)
+	pkg, err := Pack(priv, &priv.PublicKey, Compressed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fp, err := pkg.Fingerprint()
+	if err != nil {
+		t.Fatal(err)
+	}
+	spki, err := pkg.PublicKeyInfo()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The key identifier hashes the compressed point of the
+	// SubjectPublicKeyInfo, like that of a certificate for the key would.
+	sum := sha256.Sum256(spki.PublicKey.Bytes)
+	if !bytes.Equal(fp.KeyIDSHA256, sum[:20]) {
+		t.Errorf("key identifier is %x; expected %x", fp.KeyIDSHA256, sum[:20])
+	}
+	if len(spki.PublicKey.Bytes) != 33 {
+		t.Errorf("public key is %x; expected a compressed point",
+			spki.PublicKey.Bytes)
+	}
+}
+
+func TestPublicKeyMismatch(t *testing.T) {
+	priv, other := generateKey(t), generateKey(t)
+	if _, err := Pack(priv, &other.PublicKey); err == nil {
+		t.Error("Pack accepted a public key of another private key")
+	}
+	pkg, err := Pack(priv, &priv.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkg.PublicKey = packPublicKey(&other.PublicKey, Uncompressed)
+	if _, _, _, err = Unpack(pkg); err == nil {
+		t.Error("Unpack accepted a public key of another private key")
+	}
+}
@@ -0,0 +1,121 @@
+package secp256k1kp
+
+import (
+	"crypto/ecdsa"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+	"github.com/harmony-one/asym-key-pkgs/pkg/internal/ecurve"
+)
+
+type unpacker struct{}
+
+// Unpacker is the singleton secp256k1 unpacker instance.
+var Unpacker unpacker
+
+// isS256Package reports whether the key package is for the secp256k1
+// curve, named or explicit.
+func isS256Package(pkg *akp.OneAsymmetricKey) bool {
+	oid, err := ecurve.ParseParameters(
+		pkg.PrivateKeyAlgorithm.Parameters.FullBytes)
+	return err == nil && oid.Equal(curveOID)
+}
+
+// Unpack unpacks a secp256k1 private key.
+//
+// It skips key packages for other named curves, so that other
+// id-ecPublicKey unpackers may handle them,
+// and fails for explicit parameters of an unknown curve.
+// The public key is taken from the PublicKey field of the key package, or
+// else from the ECPrivateKey, in either point format, and must match the
+// private key.
+// If it is a compressed point, the Compressed point format is returned as an
+// extra, so that repacking keeps the format.
+func (unpacker unpacker) Unpack(pkg *akp.OneAsymmetricKey) (
+	priv interface{}, pub interface{}, extras []interface{}, err error,
+) {
+	oid, err := ecurve.ParseParameters(
+		pkg.PrivateKeyAlgorithm.Parameters.FullBytes)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if !oid.Equal(curveOID) {
+		return nil, nil, nil, akp.ErrSkip
+	}
+	var ecPrivKey ecurve.ECPrivateKey
+	rest, err := asn1.Unmarshal(pkg.PrivateKey, &ecPrivKey)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf(
+			"cannot unmarshal private key: %v", err)
+	}
+	if len(rest) > 0 {
+		return nil, nil, nil, errors.New("extra data after ECPrivateKey")
+	}
+	if ecPrivKey.Version != ecurve.ECPrivateKeyVersion {
+		return nil, nil, nil, fmt.Errorf(
+			"unsupported ECPrivateKey version %d", ecPrivKey.Version)
+	}
+	if params := ecPrivKey.Parameters.Bytes; params != nil {
+		oid, err = ecurve.ParseParameters(params)
+		if err != nil || !oid.Equal(curveOID) {
+			return nil, nil, nil, errors.New(
+				"ECPrivateKey parameters do not match the algorithm")
+		}
+	}
+	if len(ecPrivKey.PrivateKey) > privKeyLen {
+		return nil, nil, nil, errors.New("secp256k1 private key too long")
+	}
+	privKey, err := newPrivateKey(new(big.Int).SetBytes(ecPrivKey.PrivateKey))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	bits := pkg.PublicKey
+	if bits.Bytes == nil {
+		bits = ecPrivKey.PublicKey
+	}
+	if bits.Bytes == nil {
+		return privKey, nil, nil, nil
+	}
+	pubKey := &ecdsa.PublicKey{Curve: s256}
+	var format PointFormat
+	pubKey.X, pubKey.Y, format, err = ecurve.UnmarshalPoint(
+		s256, bits.RightAlign())
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("cannot unmarshal public key: %v", err)
+	}
+	if !pubKey.Equal(&privKey.PublicKey) {
+		return nil, nil, nil, errors.New(
+			"secp256k1 public key does not match private key")
+	}
+	if format == Compressed {
+		extras = append(extras, Compressed)
+	}
+	return privKey, pubKey, extras, nil
+}
+
+// ErrNotSecp256k1 means the unpacked key is not a secp256k1 key.
+var ErrNotSecp256k1 = errors.New("not a secp256k1 key")
+
+// Unpack unpacks a key package into a secp256k1 key pair.
+func Unpack(pkg *akp.OneAsymmetricKey) (
+	priv *ecdsa.PrivateKey, pub *ecdsa.PublicKey, extras []interface{},
+	err error,
+) {
+	if !isS256Package(pkg) {
+		return nil, nil, nil, ErrNotSecp256k1
+	}
+	return akp.UnpackWith[*ecdsa.PrivateKey, *ecdsa.PublicKey](Unpacker, pkg)
+}
+
+// Capabilities reports that secp256k1 keys can sign.
+func (unpacker unpacker) Capabilities(
+	pkg *akp.OneAsymmetricKey,
+) akp.Capability {
+	if !isS256Package(pkg) {
+		return 0
+	}
+	return akp.CanSign
+}
@@ -0,0 +1,78 @@
+package slhdsakp
+
+import (
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+
+	"github.com/cloudflare/circl/sign/slhdsa"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+)
+
+type packer struct{}
+
+// Packer is the singleton packer instance.
+var Packer packer
+
+func (packer packer) Pack(
+	priv interface{}, pub interface{}, options ...interface{},
+) (pkg *akp.OneAsymmetricKey, err error) {
+	var privKey *slhdsa.PrivateKey
+	switch priv := priv.(type) {
+	case slhdsa.PrivateKey:
+		privKey = &priv
+	case *slhdsa.PrivateKey:
+		privKey = priv
+	default:
+		return nil, akp.ErrSkip
+	}
+	var pubKey *slhdsa.PublicKey
+	switch pub := pub.(type) {
+	case nil:
+	case slhdsa.PublicKey:
+		pubKey = &pub
+	case *slhdsa.PublicKey:
+		pubKey = pub
+	default:
+		return nil, akp.ErrSkip
+	}
+	return Pack(privKey, pubKey, options...)
+}
+
+// Pack packs the given SLH-DSA key pair into a key package.
+func Pack(
+	privKey *slhdsa.PrivateKey, pubKey *slhdsa.PublicKey,
+	options ...interface{},
+) (pkg *akp.OneAsymmetricKey, err error) {
+	oid, ok := oids[privKey.ID]
+	if !ok {
+		return nil, errors.New("unknown SLH-DSA parameter set")
+	}
+	pkg = &akp.OneAsymmetricKey{
+		Version:             akp.V1,
+		PrivateKeyAlgorithm: pkix.AlgorithmIdentifier{Algorithm: oid},
+	}
+	if pkg.PrivateKey, err = privKey.MarshalBinary(); err != nil {
+		return nil, err
+	}
+	if pubKey != nil {
+		if pubKey.ID != privKey.ID {
+			return nil, errors.New(
+				"SLH-DSA public key has a different parameter set")
+		}
+		if pkg.PublicKey, err = packPublicKey(pubKey); err != nil {
+			return nil, err
+		}
+		pkg.Version = akp.V2
+	}
+	return pkg, nil
+}
+
+func packPublicKey(pubKey *slhdsa.PublicKey) (asn1.BitString, error) {
+	pubBytes, err := pubKey.MarshalBinary()
+	if err != nil {
+		return asn1.BitString{}, err
+	}
+	return asn1.BitString{Bytes: pubBytes, BitLength: 8 * len(pubBytes)}, nil
+}
@@ -0,0 +1,35 @@
+package slhdsakp
+
+import (
+	"encoding/asn1"
+
+	"github.com/cloudflare/circl/sign/slhdsa"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+)
+
+// DerivePublicKey returns the public portion of an SLH-DSA private key.
+func (packer packer) DerivePublicKey(priv interface{}) (
+	pub interface{}, err error,
+) {
+	switch privKey := priv.(type) {
+	case slhdsa.PrivateKey:
+		pubKey := privKey.PublicKey()
+		return &pubKey, nil
+	case *slhdsa.PrivateKey:
+		pubKey := privKey.PublicKey()
+		return &pubKey, nil
+	}
+	return nil, akp.ErrSkip
+}
+
+// PackPublicKey packs an SLH-DSA public key as PK.seed || PK.root.
+func (packer packer) PackPublicKey(pub interface{}) (asn1.BitString, error) {
+	switch pubKey := pub.(type) {
+	case slhdsa.PublicKey:
+		return packPublicKey(&pubKey)
+	case *slhdsa.PublicKey:
+		return packPublicKey(pubKey)
+	}
+	return asn1.BitString{}, akp.ErrSkip
+}
@@ -0,0 +1,52 @@
+// Package slhdsakp implements SLH-DSA (FIPS 205) stateless hash-based
+// signature keys, as specified in draft-ietf-lamps-x509-slhdsa,
+// for the twelve SHA2 and SHAKE parameter sets.
+//
+// Keys are the *PrivateKey and *PublicKey values of
+// github.com/cloudflare/circl/sign/slhdsa.
+// Unlike ML-DSA keys, which are a CHOICE of seed and expanded key,
+// the private key is the raw FIPS 205 encoding
+// SK.seed || SK.prf || PK.seed || PK.root, with no ASN.1 wrapping,
+// and the public key is PK.seed || PK.root.
+package slhdsakp
+
+import (
+	"encoding/asn1"
+
+	"github.com/cloudflare/circl/sign/slhdsa"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+)
+
+// id-slh-dsa-* in the NIST algorithm arc
+var oids = map[slhdsa.ID]asn1.ObjectIdentifier{
+	slhdsa.SHA2_128s:  {2, 16, 840, 1, 101, 3, 4, 3, 20},
+	slhdsa.SHA2_128f:  {2, 16, 840, 1, 101, 3, 4, 3, 21},
+	slhdsa.SHA2_192s:  {2, 16, 840, 1, 101, 3, 4, 3, 22},
+	slhdsa.SHA2_192f:  {2, 16, 840, 1, 101, 3, 4, 3, 23},
+	slhdsa.SHA2_256s:  {2, 16, 840, 1, 101, 3, 4, 3, 24},
+	slhdsa.SHA2_256f:  {2, 16, 840, 1, 101, 3, 4, 3, 25},
+	slhdsa.SHAKE_128s: {2, 16, 840, 1, 101, 3, 4, 3, 26},
+	slhdsa.SHAKE_128f: {2, 16, 840, 1, 101, 3, 4, 3, 27},
+	slhdsa.SHAKE_192s: {2, 16, 840, 1, 101, 3, 4, 3, 28},
+	slhdsa.SHAKE_192f: {2, 16, 840, 1, 101, 3, 4, 3, 29},
+	slhdsa.SHAKE_256s: {2, 16, 840, 1, 101, 3, 4, 3, 30},
+	slhdsa.SHAKE_256f: {2, 16, 840, 1, 101, 3, 4, 3, 31},
+}
+
+func init() {
+	akp.Packers.Register(Packer, slhdsa.PrivateKey{}, &slhdsa.PrivateKey{})
+	for _, oid := range oids {
+		akp.Unpackers.Register(Unpacker, oid)
+	}
+}
+
+// idOf returns the SLH-DSA parameter set with the given OID, or 0.
+func idOf(oid asn1.ObjectIdentifier) slhdsa.ID {
+	for id, idOID := range oids {
+		if oid.Equal(idOID) {
+			return id
+		}
+	}
+	return 0
+}
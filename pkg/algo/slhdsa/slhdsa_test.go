@@ -0,0 +1,172 @@
+package slhdsakp
+
+import (
+	"crypto"
+	"crypto/rand"
+	"encoding/asn1"
+	"encoding/hex"
+	"testing"
+
+	"github.com/cloudflare/circl/sign/slhdsa"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+)
+
+func generateKey(t *testing.T, id slhdsa.ID) (
+	*slhdsa.PublicKey, *slhdsa.PrivateKey,
+) {
+	pub, priv, err := slhdsa.GenerateKey(rand.Reader, id)
+	if err != nil {
+		t.Fatalf("cannot generate %v key pair: %v", id, err)
+	}
+	return &pub, &priv
+}
+
+func TestRoundTrip(t *testing.T) {
+	for id := range oids {
+		pub, priv := generateKey(t, id)
+		subtest := func(t *testing.T, pub *slhdsa.PublicKey) {
+			var pkg *akp.OneAsymmetricKey
+			var err error
+			if pub == nil {
+				pkg, err = Packer.Pack(priv, nil)
+			} else {
+				pkg, err = Packer.Pack(priv, pub)
+			}
+			if err != nil {
+				t.Fatalf("cannot pack SLH-DSA key pair: %v", err)
+			}
+			priv2, pub2, extras, err := Unpack(pkg)
+			if err != nil {
+				t.Fatalf("cannot unpack SLH-DSA key pair: %v", err)
+			}
+			if !priv.Equal(*priv2) {
+				t.Error("reconstructed key is different from the original")
+			}
+			if pub == nil && pub2 != nil || pub != nil && !pub.Equal(*pub2) {
+				t.Errorf("expected public key %v but got %v", pub, pub2)
+			}
+			if len(extras) > 0 {
+				t.Errorf("no extras were expected, but got some: %+v", extras)
+			}
+		}
+		t.Run(id.String()+"/WithoutPublic", func(t *testing.T) {
+			subtest(t, nil)
+		})
+		t.Run(id.String()+"/WithPublic", func(t *testing.T) {
+			subtest(t, pub)
+		})
+	}
+}
+
+// TestVectors unpacks private keys of the NIST ACVP SLH-DSA keyGen
+// vectors (FIPS 205), which checks the recomputation of PK.root.
+func TestVectors(t *testing.T) {
+	for _, test := range []struct {
+		id slhdsa.ID
+		sk string
+	}{
+		{slhdsa.SHA2_128s, "" +
+			"ac379f047faab2004f3ae32350ac9a3d829fff0aa59e956a87f3971c4d58e710" +
+			"0566d240cc519834322eafbcc73c79f5a4b84f02e8bf0cbd54017b2d3c494b57"},
+		{slhdsa.SHAKE_128f, "" +
+			"cd4a308c03d970508572c0815d7488b7f3fd6d2dcc7e5120fa544846aedded81" +
+			"bc435c3e66e4c2e4fbc09779da5f74d44ea0e0df05c2457bcc81f59928433390"},
+	} {
+		t.Run(test.id.String(), func(t *testing.T) {
+			sk, err := hex.DecodeString(test.sk)
+			if err != nil {
+				t.Fatal(err)
+			}
+			pkg := &akp.OneAsymmetricKey{PrivateKey: sk}
+			pkg.PrivateKeyAlgorithm.Algorithm = oids[test.id]
+			priv, _, _, err := Unpack(pkg)
+			if err != nil {
+				t.Fatalf("cannot unpack SLH-DSA key: %v", err)
+			}
+			pkg2, err := Pack(priv, nil)
+			if err != nil {
+				t.Fatalf("cannot pack SLH-DSA key: %v", err)
+			}
+			if got := hex.EncodeToString(pkg2.PrivateKey); got != test.sk {
+				t.Errorf("packed private key is %s; expected %s", got, test.sk)
+			}
+			sk[len(sk)-1] ^= 1
+			if _, _, _, err = Unpack(pkg); err == nil {
+				t.Error("Unpack accepted a wrong PK.root")
+			}
+		})
+	}
+}
+
+func TestUnpack(t *testing.T) {
+	pub, priv := generateKey(t, slhdsa.SHA2_128f)
+	otherPub, _ := generateKey(t, slhdsa.SHA2_128f)
+	t.Run("PublicKeyMismatch", func(t *testing.T) {
+		pkg, err := Pack(priv, pub)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pkg.PublicKey, _ = packPublicKey(otherPub)
+		if _, _, _, err = Unpack(pkg); err == nil {
+			t.Error("Unpack accepted a mismatching public key")
+		}
+	})
+	t.Run("BadLength", func(t *testing.T) {
+		pkg, err := Pack(priv, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pkg.PrivateKey = pkg.PrivateKey[1:]
+		if _, _, _, err = Unpack(pkg); err == nil {
+			t.Error("Unpack accepted a short private key")
+		}
+	})
+	t.Run("WrongParameterSet", func(t *testing.T) {
+		pkg, err := Pack(priv, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pkg.PrivateKeyAlgorithm.Algorithm = oids[slhdsa.SHAKE_128f]
+		if _, _, _, err = Unpack(pkg); err == nil {
+			t.Error("Unpack accepted a key of another parameter set")
+		}
+	})
+	t.Run("WrongAlgorithm", func(t *testing.T) {
+		pkg, err := Pack(priv, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pkg.PrivateKeyAlgorithm.Algorithm = asn1.ObjectIdentifier{1, 2, 3}
+		if _, _, _, err = Unpack(pkg); err != ErrNotSLHDSA {
+			t.Errorf("Unpack returned %v; expected %v", err, ErrNotSLHDSA)
+		}
+	})
+}
+
+func TestSigner(t *testing.T) {
+	pub, priv := generateKey(t, slhdsa.SHAKE_128f)
+	encoded, err := akp.Encode(*priv, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := akp.DecodeSigner(encoded)
+	if err != nil {
+		t.Fatalf("cannot decode signer: %v", err)
+	}
+	message := []byte("hello, world")
+	sig, err := signer.Sign(rand.Reader, message, crypto.Hash(0))
+	if err != nil {
+		t.Fatalf("cannot sign: %v", err)
+	}
+	if !slhdsa.Verify(pub, slhdsa.NewMessage(message), sig, nil) {
+		t.Error("cannot verify signature")
+	}
+	var pkg akp.OneAsymmetricKey
+	if _, err = asn1.Unmarshal(encoded, &pkg); err != nil {
+		t.Fatal(err)
+	}
+	if c := pkg.Capabilities(); c != akp.CanSign {
+		t.Errorf("capabilities are %v; expected %v", c, akp.CanSign)
+	}
+}
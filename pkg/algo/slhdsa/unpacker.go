@@ -0,0 +1,90 @@
+package slhdsakp
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/cloudflare/circl/sign/slhdsa"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+)
+
+type unpacker struct{}
+
+// Unpacker is the singleton SLH-DSA unpacker instance.
+var Unpacker unpacker
+
+// Unpack unpacks an SLH-DSA private key.
+//
+// PK.root in the private key must be the root recomputed from SK.seed and
+// PK.seed (FIPS 205, algorithm 18), and the public key, if present,
+// must match the private key.
+func (unpacker unpacker) Unpack(pkg *akp.OneAsymmetricKey) (
+	priv interface{}, pub interface{}, extras []interface{}, err error,
+) {
+	id := idOf(pkg.PrivateKeyAlgorithm.Algorithm)
+	if id == 0 {
+		return nil, nil, nil, akp.ErrSkip
+	}
+	if len(pkg.PrivateKeyAlgorithm.Parameters.FullBytes) > 0 {
+		return nil, nil, nil, errors.New("SLH-DSA key has parameters")
+	}
+	size := id.Scheme().PrivateKeySize()
+	if len(pkg.PrivateKey) != size {
+		return nil, nil, nil, fmt.Errorf(
+			"SLH-DSA private key is %d bytes; expected %d",
+			len(pkg.PrivateKey), size)
+	}
+	// SK.seed, SK.prf and PK.seed are the random input of key generation.
+	n := size / 4
+	expectedPub, privKey, err := slhdsa.GenerateKey(
+		bytes.NewReader(pkg.PrivateKey[:3*n]), id)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	root, err := expectedPub.MarshalBinary()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if !bytes.Equal(root[n:], pkg.PrivateKey[3*n:]) {
+		return nil, nil, nil, errors.New(
+			"SLH-DSA private key has a wrong PK.root")
+	}
+	if pkg.PublicKey.Bytes == nil {
+		return &privKey, nil, nil, nil
+	}
+	pubKey := &slhdsa.PublicKey{ID: id}
+	if err = pubKey.UnmarshalBinary(pkg.PublicKey.RightAlign()); err != nil {
+		return nil, nil, nil, fmt.Errorf("cannot unmarshal public key: %v", err)
+	}
+	if !pubKey.Equal(expectedPub) {
+		return nil, nil, nil, errors.New(
+			"SLH-DSA public key does not match private key")
+	}
+	return &privKey, pubKey, nil, nil
+}
+
+// ErrNotSLHDSA means the unpacked key is not an SLH-DSA key.
+var ErrNotSLHDSA = errors.New("not an SLH-DSA key")
+
+// Unpack unpacks a key package into an SLH-DSA key pair.
+func Unpack(pkg *akp.OneAsymmetricKey) (
+	priv *slhdsa.PrivateKey, pub *slhdsa.PublicKey, extras []interface{},
+	err error,
+) {
+	priv, pub, extras, err = akp.UnpackWith[
+		*slhdsa.PrivateKey, *slhdsa.PublicKey](Unpacker, pkg)
+	var mismatch *akp.TypeMismatchError
+	if errors.As(err, &mismatch) || err == akp.ErrSkip {
+		return nil, nil, nil, ErrNotSLHDSA
+	}
+	return priv, pub, extras, err
+}
+
+// Capabilities reports that SLH-DSA keys can sign.
+func (unpacker unpacker) Capabilities(
+	pkg *akp.OneAsymmetricKey,
+) akp.Capability {
+	return akp.CanSign
+}
@@ -0,0 +1,187 @@
+package sm2kp
+
+import (
+	"crypto"
+	"encoding/asn1"
+	"errors"
+	"io"
+	"math/big"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/internal/ecurve"
+	"github.com/harmony-one/asym-key-pkgs/pkg/internal/sm3"
+)
+
+// PublicKey is an SM2 public key.
+type PublicKey struct {
+	X, Y *big.Int
+}
+
+// Equal reports whether pub and x are the same public key.
+func (pub *PublicKey) Equal(x crypto.PublicKey) bool {
+	xx, ok := x.(*PublicKey)
+	return ok && pub.X.Cmp(xx.X) == 0 && pub.Y.Cmp(xx.Y) == 0
+}
+
+// PrivateKey is an SM2 private key.
+type PrivateKey struct {
+	PublicKey
+	D *big.Int
+}
+
+// GenerateKey generates an SM2 key pair.
+func GenerateKey(rand io.Reader) (*PrivateKey, error) {
+	// d must be in [1, n - 2], so that 1 + d is invertible.
+	nMinus1 := new(big.Int).Sub(sm2p256.Params().N, big.NewInt(1))
+	d, err := ecurve.RandScalar(rand, nMinus1)
+	if err != nil {
+		return nil, err
+	}
+	return newPrivateKey(d)
+}
+
+// newPrivateKey returns the private key with the given scalar,
+// with its public key computed.
+func newPrivateKey(d *big.Int) (*PrivateKey, error) {
+	nMinus1 := new(big.Int).Sub(sm2p256.Params().N, big.NewInt(1))
+	if d.Sign() <= 0 || d.Cmp(nMinus1) >= 0 {
+		return nil, errors.New("SM2 private key out of range")
+	}
+	priv := &PrivateKey{D: d}
+	priv.X, priv.Y = sm2p256.ScalarBaseMult(d.Bytes())
+	return priv, nil
+}
+
+// Public returns the public key.
+func (priv *PrivateKey) Public() crypto.PublicKey {
+	return &priv.PublicKey
+}
+
+// Equal reports whether priv and x are the same private key.
+func (priv *PrivateKey) Equal(x crypto.PrivateKey) bool {
+	xx, ok := x.(*PrivateKey)
+	return ok && priv.D.Cmp(xx.D) == 0
+}
+
+// DefaultUID is the default user ID of SM2 signatures
+// (GM/T 0009-2012, section 10).
+const DefaultUID = "1234567812345678"
+
+// SignerOpts are the options of SM2 signatures.
+type SignerOpts struct {
+	// UID is the user ID of the signer; DefaultUID if nil.
+	UID []byte
+}
+
+// HashFunc returns 0: SM2 hashes the message with SM3 itself.
+func (opts *SignerOpts) HashFunc() crypto.Hash {
+	return 0
+}
+
+func uidOf(opts crypto.SignerOpts) []byte {
+	if o, ok := opts.(*SignerOpts); ok && o.UID != nil {
+		return o.UID
+	}
+	return []byte(DefaultUID)
+}
+
+// digest returns e = SM3(Z_A || M), where
+// Z_A = SM3(ENTL_A || ID_A || a || b || x_G || y_G || x_A || y_A)
+// (GB/T 32918.2-2016, sections 5.5 and 6.1).
+func (pub *PublicKey) digest(uid, message []byte) (*big.Int, error) {
+	if len(uid) >= 1<<13 {
+		return nil, errors.New("SM2 user ID too long")
+	}
+	params := sm2p256.Params()
+	h := sm3.New()
+	h.Write([]byte{byte(len(uid) >> 5), byte(len(uid) << 3)})
+	h.Write(uid)
+	for _, v := range []*big.Int{
+		sm2p256.A(), params.B, params.Gx, params.Gy, pub.X, pub.Y,
+	} {
+		h.Write(v.FillBytes(make([]byte, privKeyLen)))
+	}
+	za := h.Sum(nil)
+	h.Reset()
+	h.Write(za)
+	h.Write(message)
+	return new(big.Int).SetBytes(h.Sum(nil)), nil
+}
+
+// asn1Signature is the SM2 signature structure of GM/T 0009-2012.
+type asn1Signature struct {
+	R, S *big.Int
+}
+
+// Sign signs the message (GB/T 32918.2-2016, section 6.1),
+// reading the random k from rand.
+//
+// The message must not be hashed: opts.HashFunc() must be zero.
+// A *SignerOpts may set the user ID.
+// The signature is an ASN.1 SEQUENCE of r and s.
+func (priv *PrivateKey) Sign(
+	rand io.Reader, message []byte, opts crypto.SignerOpts,
+) ([]byte, error) {
+	if opts.HashFunc() != 0 {
+		return nil, errors.New("SM2 signs unhashed messages only")
+	}
+	e, err := priv.digest(uidOf(opts), message)
+	if err != nil {
+		return nil, err
+	}
+	n := sm2p256.Params().N
+	dInv := new(big.Int).Add(priv.D, big.NewInt(1))
+	dInv.ModInverse(dInv, n)
+	for {
+		k, err := ecurve.RandScalar(rand, n)
+		if err != nil {
+			return nil, err
+		}
+		x1, _ := sm2p256.ScalarBaseMult(k.Bytes())
+		r := new(big.Int).Add(e, x1)
+		r.Mod(r, n)
+		if rk := new(big.Int).Add(r, k); r.Sign() == 0 || rk.Cmp(n) == 0 {
+			continue
+		}
+		// s = (1 + d)⁻¹ (k - rd) mod n
+		s := new(big.Int).Mul(r, priv.D)
+		s.Sub(k, s)
+		s.Mul(s, dInv)
+		s.Mod(s, n)
+		if s.Sign() == 0 {
+			continue
+		}
+		return asn1.Marshal(asn1Signature{r, s})
+	}
+}
+
+// Verify reports whether sig is a valid SM2 signature of the message under
+// the public key with the given user ID, or DefaultUID if nil
+// (GB/T 32918.2-2016, section 7.1).
+func Verify(pub *PublicKey, message, sig, uid []byte) bool {
+	var rs asn1Signature
+	rest, err := asn1.Unmarshal(sig, &rs)
+	if err != nil || len(rest) > 0 {
+		return false
+	}
+	n := sm2p256.Params().N
+	r, s := rs.R, rs.S
+	if r.Sign() <= 0 || r.Cmp(n) >= 0 || s.Sign() <= 0 || s.Cmp(n) >= 0 {
+		return false
+	}
+	t := new(big.Int).Add(r, s)
+	if t.Mod(t, n).Sign() == 0 {
+		return false
+	}
+	if uid == nil {
+		uid = []byte(DefaultUID)
+	}
+	e, err := pub.digest(uid, message)
+	if err != nil {
+		return false
+	}
+	x1, y1 := sm2p256.ScalarBaseMult(s.Bytes())
+	x2, y2 := sm2p256.ScalarMult(pub.X, pub.Y, t.Bytes())
+	x1, _ = sm2p256.Add(x1, y1, x2, y2)
+	e.Add(e, x1)
+	return e.Mod(e, n).Cmp(r) == 0
+}
@@ -0,0 +1,84 @@
+package sm2kp
+
+import (
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"math/big"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+	"github.com/harmony-one/asym-key-pkgs/pkg/internal/ecurve"
+)
+
+type packer struct{}
+
+// Packer is the singleton packer instance.
+var Packer packer
+
+func (packer packer) Pack(
+	priv interface{}, pub interface{}, options ...interface{},
+) (pkg *akp.OneAsymmetricKey, err error) {
+	var (
+		privKey *PrivateKey
+		pubKey  *PublicKey
+		ok      bool
+	)
+	if privKey, ok = priv.(*PrivateKey); !ok {
+		return nil, akp.ErrSkip
+	}
+	if pub == nil {
+		return Pack(privKey, nil, options...)
+	}
+	if pubKey, ok = pub.(*PublicKey); !ok {
+		return nil, akp.ErrSkip
+	}
+	return Pack(privKey, pubKey, options...)
+}
+
+// Pack packs the given SM2 key pair into a key package,
+// as an id-ecPublicKey key with the SM2 named curve.
+//
+// A PointFormat option selects the encoding of the public key.
+func Pack(
+	privKey *PrivateKey, pubKey *PublicKey, options ...interface{},
+) (pkg *akp.OneAsymmetricKey, err error) {
+	nMinus1 := new(big.Int).Sub(sm2p256.Params().N, big.NewInt(1))
+	if privKey.D == nil || privKey.D.Sign() <= 0 ||
+		privKey.D.Cmp(nMinus1) >= 0 {
+		return nil, errors.New("SM2 private key out of range")
+	}
+	format := Uncompressed
+	for _, option := range options {
+		if f, ok := option.(PointFormat); ok {
+			format = f
+		}
+	}
+	params, err := asn1.Marshal(curveOID)
+	if err != nil {
+		return nil, err
+	}
+	pkg = &akp.OneAsymmetricKey{
+		Version: akp.V1,
+		PrivateKeyAlgorithm: pkix.AlgorithmIdentifier{
+			Algorithm:  algorithmOID,
+			Parameters: asn1.RawValue{FullBytes: params},
+		},
+	}
+	pkg.PrivateKey, err = asn1.Marshal(ecurve.ECPrivateKey{
+		Version:    ecurve.ECPrivateKeyVersion,
+		PrivateKey: privKey.D.FillBytes(make([]byte, privKeyLen)),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if pubKey != nil {
+		pkg.PublicKey = packPublicKey(pubKey, format)
+		pkg.Version = akp.V2
+	}
+	return pkg, nil
+}
+
+func packPublicKey(pubKey *PublicKey, format PointFormat) asn1.BitString {
+	point := ecurve.MarshalPoint(sm2p256, pubKey.X, pubKey.Y, format)
+	return asn1.BitString{Bytes: point, BitLength: 8 * len(point)}
+}
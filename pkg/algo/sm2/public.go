@@ -0,0 +1,33 @@
+package sm2kp
+
+import (
+	"encoding/asn1"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+)
+
+// DerivePublicKey returns the public portion of an SM2 private key.
+func (packer packer) DerivePublicKey(priv interface{}) (
+	pub interface{}, err error,
+) {
+	privKey, ok := priv.(*PrivateKey)
+	if !ok {
+		return nil, akp.ErrSkip
+	}
+	if privKey.X == nil {
+		if privKey, err = newPrivateKey(privKey.D); err != nil {
+			return nil, err
+		}
+	}
+	return &privKey.PublicKey, nil
+}
+
+// PackPublicKey packs an SM2 public key as an uncompressed point
+// (RFC 5480, section 2.2).
+func (packer packer) PackPublicKey(pub interface{}) (asn1.BitString, error) {
+	pubKey, ok := pub.(*PublicKey)
+	if !ok {
+		return asn1.BitString{}, akp.ErrSkip
+	}
+	return packPublicKey(pubKey, Uncompressed), nil
+}
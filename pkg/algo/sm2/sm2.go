@@ -0,0 +1,71 @@
+// Package sm2kp implements SM2 (GB/T 32918-2016) signature keys,
+// as id-ecPublicKey keys with the SM2 named curve and an RFC 5915
+// ECPrivateKey, as OpenSSL writes them.
+//
+// Keys are *PrivateKey and *PublicKey values on the SM2 curve,
+// a pure-Go implementation whose scalar multiplication is constant-time;
+// the arithmetic modulo n that completes a signature uses math/big.
+// Unpack also accepts key packages whose algorithm OID is the SM2 curve
+// OID, and explicit curve parameters equal to those of the SM2 curve.
+package sm2kp
+
+import (
+	"crypto/elliptic"
+	"encoding/asn1"
+	"math/big"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+	"github.com/harmony-one/asym-key-pkgs/pkg/internal/ecurve"
+)
+
+// id-ecPublicKey in RFC 5480
+var algorithmOID = asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}
+
+// sm2p256v1 in GM/T 0006-2012
+var curveOID = asn1.ObjectIdentifier{1, 2, 156, 10197, 1, 301}
+
+func init() {
+	akp.Packers.Register(Packer, &PrivateKey{})
+	akp.Unpackers.Register(Unpacker, algorithmOID, curveOID)
+	ecurve.Register(curveOID, sm2p256)
+}
+
+// PointFormat is the encoding of public keys, given as a Pack option.
+type PointFormat = ecurve.PointFormat
+
+// Point formats
+const (
+	Uncompressed = ecurve.Uncompressed // the default
+	Compressed   = ecurve.Compressed
+)
+
+func hexInt(s string) *big.Int {
+	n, ok := new(big.Int).SetString(s, 16)
+	if !ok {
+		panic("bad hex integer " + s)
+	}
+	return n
+}
+
+// sm2p256 is the curve of GB/T 32918.5-2017, with a = -3.
+var sm2p256 = ecurve.New(&elliptic.CurveParams{
+	Name:    "SM2",
+	BitSize: 256,
+	P: hexInt("FFFFFFFEFFFFFFFFFFFFFFFFFFFFFFFF" +
+		"FFFFFFFF00000000FFFFFFFFFFFFFFFF"),
+	N: hexInt("FFFFFFFEFFFFFFFFFFFFFFFFFFFFFFFF" +
+		"7203DF6B21C6052B53BBF40939D54123"),
+	B: hexInt("28E9FA9E9D9F5E344D5A9E4BCF6509A7" +
+		"F39789F515AB8F92DDBCBD414D940E93"),
+	Gx: hexInt("32C4AE2C1F1981195F9904466A39C994" +
+		"8FE30BBFF2660BE1715A4589334C74C7"),
+	Gy: hexInt("BC3736A2F4F6779C59BDCEE36B692153" +
+		"D0A9877CC62A474002DF32E52139F0A0"),
+}, big.NewInt(-3))
+
+// P256 returns the SM2 curve.
+func P256() elliptic.Curve {
+	return sm2p256
+}
+
+const privKeyLen = 32
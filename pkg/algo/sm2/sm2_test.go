@@ -0,0 +1,342 @@
+package sm2kp
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"encoding/asn1"
+	"encoding/hex"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+	"github.com/harmony-one/asym-key-pkgs/pkg/internal/ecurve"
+)
+
+// vector.pem holds the private key of the example of GB/T 32918.2-2016,
+// annex A.2, as an id-ecPublicKey key with the SM2 curve and without its
+// public key, converted by OpenSSL 3.0 from DER.
+// The other testdata files were generated by OpenSSL 3.0:
+//
+//	openssl pkey -in vector.pem -pubout -out vector_pub.pem
+//	printf 'hello, world' | openssl dgst -sm3 -sign vector.pem \
+//		-sigopt distid:1234567812345678 -out message.sig
+//	openssl genpkey -algorithm SM2 -out openssl.pem
+//	openssl pkey -in openssl.pem -pubout -out openssl_pub.pem
+
+func readPEM(t *testing.T, filename string) []byte {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		t.Fatalf("%s: no PEM block", filename)
+	}
+	return block.Bytes
+}
+
+func readPackage(t *testing.T, filename string) *akp.OneAsymmetricKey {
+	var pkg akp.OneAsymmetricKey
+	if _, err := asn1.Unmarshal(readPEM(t, filename), &pkg); err != nil {
+		t.Fatal(err)
+	}
+	return &pkg
+}
+
+func generateKey(t *testing.T) *PrivateKey {
+	priv, err := GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("cannot generate SM2 key pair: %v", err)
+	}
+	return priv
+}
+
+func fromHex(t *testing.T, s string) *big.Int {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return new(big.Int).SetBytes(b)
+}
+
+func TestRoundTrip(t *testing.T) {
+	priv := generateKey(t)
+	subtest := func(t *testing.T, pub *PublicKey, options ...interface{}) {
+		pkg, err := Packer.Pack(priv, pub, options...)
+		if err != nil {
+			t.Fatalf("cannot pack SM2 key pair: %v", err)
+		}
+		priv2, pub2, extras, err := Unpack(pkg)
+		if err != nil {
+			t.Fatalf("cannot unpack SM2 key pair: %v", err)
+		}
+		if !priv.Equal(priv2) {
+			t.Error("reconstructed key is different from the original")
+		}
+		if pub == nil && pub2 != nil || pub != nil && !pub.Equal(pub2) {
+			t.Errorf("expected public key %v but got %v", pub, pub2)
+		}
+		// A compressed public key comes back as the Compressed extra.
+		if !reflect.DeepEqual(extras, options) {
+			t.Errorf("extras are %+v; expected %+v", extras, options)
+		}
+		pkg2, err := Pack(priv2, pub2, extras...)
+		if err != nil {
+			t.Fatalf("cannot repack SM2 key pair: %v", err)
+		}
+		if !reflect.DeepEqual(pkg, pkg2) {
+			t.Errorf("repacked key package %+v is different from %+v",
+				pkg2, pkg)
+		}
+	}
+	t.Run("WithoutPublic", func(t *testing.T) { subtest(t, nil) })
+	t.Run("WithPublic", func(t *testing.T) {
+		subtest(t, &priv.PublicKey)
+	})
+	t.Run("Compressed", func(t *testing.T) {
+		subtest(t, &priv.PublicKey, Compressed)
+	})
+}
+
+func TestVectors(t *testing.T) {
+	der := readPEM(t, "testdata/vector.pem")
+	priv, pub, _, err := akp.Decode(der)
+	if err != nil {
+		t.Fatalf("cannot decode SM2 key: %v", err)
+	}
+	privKey, ok := priv.(*PrivateKey)
+	if !ok {
+		t.Fatalf("decoded key is a %T; expected *PrivateKey", priv)
+	}
+	if pub != nil {
+		t.Errorf("no public key was expected, but got %v", pub)
+	}
+	expected := &PublicKey{
+		X: fromHex(t, "09F9DF311E5421A150DD7D161E4BC5C6"+
+			"72179FAD1833FC076BB08FF356F35020"),
+		Y: fromHex(t, "CCEA490CE26775A52DC6EA718CC1AA60"+
+			"0AED05FBF35E084A6632F6072DA9AD13"),
+	}
+	if !expected.Equal(privKey.Public()) {
+		t.Errorf("public key is %v; expected %v", privKey.Public(), expected)
+	}
+	encoded, err := akp.Encode(privKey, nil)
+	if err != nil {
+		t.Fatalf("cannot encode SM2 key: %v", err)
+	}
+	if !bytes.Equal(encoded, der) {
+		t.Errorf("encoded key is %x; expected %x", encoded, der)
+	}
+
+	// GB/T 32918.2-2016, annex A.2
+	k := fromHex(t, "59276E27D506861A16680F3AD9C02DCC"+
+		"EF3CC1FA3CDBE4CE6D54B80DEAC1BC21")
+	sig, err := privKey.Sign(bytes.NewReader(k.Bytes()),
+		[]byte("message digest"), &SignerOpts{})
+	if err != nil {
+		t.Fatalf("cannot sign: %v", err)
+	}
+	expectedSig, _ := asn1.Marshal(asn1Signature{
+		R: fromHex(t, "F5A03B0648D2C4630EEAC513E1BB81A1"+
+			"5944DA3827D5B74143AC7EACEEE720B3"),
+		S: fromHex(t, "B1B6AA29DF212FD8763182BC0D421CA1"+
+			"BB9038FD1F7F42D4840B69C485BBC1AA"),
+	})
+	if !bytes.Equal(sig, expectedSig) {
+		t.Errorf("signature is %x; expected %x", sig, expectedSig)
+	}
+}
+
+func TestOpenSSL(t *testing.T) {
+	priv, _, _, err := Unpack(readPackage(t, "testdata/vector.pem"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkg, err := Pack(priv, &priv.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	spki, err := pkg.MarshalPublicKeyInfo()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := readPEM(t, "testdata/vector_pub.pem"); !bytes.Equal(
+		spki, expected) {
+		t.Errorf("SubjectPublicKeyInfo is %x; expected %x", spki, expected)
+	}
+	sig, err := os.ReadFile("testdata/message.sig")
+	if err != nil {
+		t.Fatal(err)
+	}
+	message := []byte("hello, world")
+	if !Verify(&priv.PublicKey, message, sig, nil) {
+		t.Error("cannot verify OpenSSL signature")
+	}
+	if Verify(&priv.PublicKey, message, sig, []byte("ALICE123@YAHOO.COM")) {
+		t.Error("OpenSSL signature verified with another user ID")
+	}
+
+	// The key generated by OpenSSL embeds its public key.
+	priv, pub, _, err := Unpack(readPackage(t, "testdata/openssl.pem"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pub == nil || !priv.PublicKey.Equal(pub) {
+		t.Errorf("public key is %v; expected %v", pub, &priv.PublicKey)
+	}
+	if pkg, err = Pack(priv, pub); err != nil {
+		t.Fatal(err)
+	}
+	if spki, err = pkg.MarshalPublicKeyInfo(); err != nil {
+		t.Fatal(err)
+	}
+	if expected := readPEM(t, "testdata/openssl_pub.pem"); !bytes.Equal(
+		spki, expected) {
+		t.Errorf("SubjectPublicKeyInfo is %x; expected %x", spki, expected)
+	}
+}
+
+func TestSigner(t *testing.T) {
+	encoded, err := akp.Encode(generateKey(t), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := akp.DecodeSigner(encoded)
+	if err != nil {
+		t.Fatalf("cannot decode signer: %v", err)
+	}
+	pub := signer.Public().(*PublicKey)
+	message := []byte("hello, world")
+	uid := []byte("ALICE123@YAHOO.COM")
+	for _, test := range []struct {
+		name string
+		opts crypto.SignerOpts
+		uid  []byte
+	}{
+		{"DefaultUID", crypto.Hash(0), nil},
+		{"UID", &SignerOpts{UID: uid}, uid},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			sig, err := signer.Sign(rand.Reader, message, test.opts)
+			if err != nil {
+				t.Fatalf("cannot sign: %v", err)
+			}
+			if !Verify(pub, message, sig, test.uid) {
+				t.Error("cannot verify signature")
+			}
+			if Verify(pub, []byte("hello, world!"), sig, test.uid) {
+				t.Error("signature of another message verified")
+			}
+		})
+	}
+	if _, err = signer.Sign(rand.Reader, message, crypto.SHA256); err == nil {
+		t.Error("Sign signed a digest")
+	}
+}
+
+func TestUnpack(t *testing.T) {
+	priv := generateKey(t)
+	pkg, err := Pack(priv, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := priv.D.FillBytes(make([]byte, privKeyLen))
+	p256Params, _ := asn1.Marshal( // prime256v1
+		asn1.ObjectIdentifier{1, 2, 840, 10045, 3, 1, 7})
+	for _, test := range []struct {
+		name   string
+		modify func(key *ecurve.ECPrivateKey, pkg *akp.OneAsymmetricKey)
+	}{
+		{"LongKey", func(key *ecurve.ECPrivateKey, pkg *akp.OneAsymmetricKey) {
+			key.PrivateKey = append([]byte{0}, d...)
+		}},
+		{"ZeroKey", func(key *ecurve.ECPrivateKey, pkg *akp.OneAsymmetricKey) {
+			key.PrivateKey = make([]byte, len(d))
+		}},
+		{"KeyOutOfRange", func(
+			key *ecurve.ECPrivateKey, pkg *akp.OneAsymmetricKey,
+		) {
+			// n - 1, for which 1 + d is not invertible
+			n := sm2p256.Params().N
+			key.PrivateKey = new(big.Int).Sub(n, big.NewInt(1)).Bytes()
+		}},
+		{"BadVersion", func(
+			key *ecurve.ECPrivateKey, pkg *akp.OneAsymmetricKey,
+		) {
+			key.Version = 2
+		}},
+		{"ParametersMismatch", func(
+			key *ecurve.ECPrivateKey, pkg *akp.OneAsymmetricKey,
+		) {
+			key.Parameters = asn1.RawValue{
+				Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true,
+				Bytes: p256Params,
+			}
+		}},
+		{"PublicKeyMismatch", func(
+			key *ecurve.ECPrivateKey, pkg *akp.OneAsymmetricKey,
+		) {
+			other := generateKey(t)
+			pkg.PublicKey = packPublicKey(&other.PublicKey, Compressed)
+		}},
+		{"BadPoint", func(
+			key *ecurve.ECPrivateKey, pkg *akp.OneAsymmetricKey,
+		) {
+			pkg.PublicKey = packPublicKey(&priv.PublicKey, Uncompressed)
+			pkg.PublicKey.Bytes[len(pkg.PublicKey.Bytes)-1] ^= 1
+		}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			key := ecurve.ECPrivateKey{
+				Version: ecurve.ECPrivateKeyVersion, PrivateKey: d,
+			}
+			pkg := *pkg
+			test.modify(&key, &pkg)
+			if pkg.PrivateKey, err = asn1.Marshal(key); err != nil {
+				t.Fatal(err)
+			}
+			if _, _, _, err = Unpack(&pkg); err == nil {
+				t.Error("Unpack succeeded")
+			}
+		})
+	}
+	t.Run("CurveAlgorithm", func(t *testing.T) {
+		// Some encoders use the curve OID as the algorithm OID.
+		for _, params := range []asn1.RawValue{
+			{}, {FullBytes: asn1.NullBytes}, pkg.PrivateKeyAlgorithm.Parameters,
+		} {
+			pkg := *pkg
+			pkg.PrivateKeyAlgorithm.Algorithm = curveOID
+			pkg.PrivateKeyAlgorithm.Parameters = params
+			priv2, _, _, err := akp.Unpack(&pkg)
+			if err != nil || !priv.Equal(priv2) {
+				t.Errorf("Unpack returned %v, %v; expected the original key",
+					priv2, err)
+			}
+			if c := pkg.Capabilities(); c != akp.CanSign {
+				t.Errorf("capabilities are %v; expected %v", c, akp.CanSign)
+			}
+		}
+	})
+	t.Run("OtherCurve", func(t *testing.T) {
+		pkg := *pkg
+		pkg.PrivateKeyAlgorithm.Parameters.FullBytes = p256Params
+		if _, _, _, err = Unpacker.Unpack(&pkg); err != akp.ErrSkip {
+			t.Errorf("Unpack returned %v; expected %v", err, akp.ErrSkip)
+		}
+		if _, _, _, err = Unpack(&pkg); err != ErrNotSM2 {
+			t.Errorf("Unpack returned %v; expected %v", err, ErrNotSM2)
+		}
+	})
+	t.Run("WrongAlgorithm", func(t *testing.T) {
+		pkg := *pkg
+		pkg.PrivateKeyAlgorithm.Algorithm = asn1.ObjectIdentifier{1, 2, 3}
+		if _, _, _, err = Unpack(&pkg); err != ErrNotSM2 {
+			t.Errorf("Unpack returned %v; expected %v", err, ErrNotSM2)
+		}
+	})
+}
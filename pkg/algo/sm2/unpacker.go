@@ -0,0 +1,136 @@
+package sm2kp
+
+import (
+	"bytes"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+	"github.com/harmony-one/asym-key-pkgs/pkg/internal/ecurve"
+)
+
+type unpacker struct{}
+
+// Unpacker is the singleton SM2 unpacker instance.
+var Unpacker unpacker
+
+// isSM2Package reports whether the key package is for the SM2 curve,
+// as an id-ecPublicKey key with the curve named or explicit,
+// or as a key whose algorithm OID is the curve OID, without parameters
+// or with the curve OID again.
+func isSM2Package(pkg *akp.OneAsymmetricKey) (bool, error) {
+	alg := pkg.PrivateKeyAlgorithm
+	params := alg.Parameters.FullBytes
+	switch {
+	case alg.Algorithm.Equal(curveOID):
+		if len(params) == 0 || bytes.Equal(params, asn1.NullBytes) {
+			return true, nil
+		}
+	case !alg.Algorithm.Equal(algorithmOID):
+		return false, nil
+	}
+	oid, err := ecurve.ParseParameters(params)
+	if err != nil {
+		return false, err
+	}
+	return oid.Equal(curveOID), nil
+}
+
+// Unpack unpacks an SM2 private key.
+//
+// It skips id-ecPublicKey key packages for other named curves, so that
+// other id-ecPublicKey unpackers may handle them,
+// and fails for explicit parameters of an unknown curve.
+// The public key is taken from the PublicKey field of the key package, or
+// else from the ECPrivateKey, in either point format;
+// it must match the private key.
+// If it is a compressed point, the Compressed point format is returned as an
+// extra, so that repacking keeps the format.
+func (unpacker unpacker) Unpack(pkg *akp.OneAsymmetricKey) (
+	priv interface{}, pub interface{}, extras []interface{}, err error,
+) {
+	ok, err := isSM2Package(pkg)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if !ok {
+		return nil, nil, nil, akp.ErrSkip
+	}
+	var ecPrivKey ecurve.ECPrivateKey
+	rest, err := asn1.Unmarshal(pkg.PrivateKey, &ecPrivKey)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf(
+			"cannot unmarshal private key: %v", err)
+	}
+	if len(rest) > 0 {
+		return nil, nil, nil, errors.New("extra data after ECPrivateKey")
+	}
+	if ecPrivKey.Version != ecurve.ECPrivateKeyVersion {
+		return nil, nil, nil, fmt.Errorf(
+			"unsupported ECPrivateKey version %d", ecPrivKey.Version)
+	}
+	if params := ecPrivKey.Parameters.Bytes; params != nil {
+		oid, err := ecurve.ParseParameters(params)
+		if err != nil || !oid.Equal(curveOID) {
+			return nil, nil, nil, errors.New(
+				"ECPrivateKey parameters do not match the algorithm")
+		}
+	}
+	if len(ecPrivKey.PrivateKey) > privKeyLen {
+		return nil, nil, nil, errors.New("SM2 private key too long")
+	}
+	privKey, err := newPrivateKey(new(big.Int).SetBytes(ecPrivKey.PrivateKey))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	bits := pkg.PublicKey
+	if bits.Bytes == nil {
+		bits = ecPrivKey.PublicKey
+	}
+	if bits.Bytes == nil {
+		return privKey, nil, nil, nil
+	}
+	pubKey := &PublicKey{}
+	var format PointFormat
+	pubKey.X, pubKey.Y, format, err = ecurve.UnmarshalPoint(
+		sm2p256, bits.RightAlign())
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("cannot unmarshal public key: %v", err)
+	}
+	if !pubKey.Equal(&privKey.PublicKey) {
+		return nil, nil, nil, errors.New(
+			"SM2 public key does not match private key")
+	}
+	if format == Compressed {
+		extras = append(extras, Compressed)
+	}
+	return privKey, pubKey, extras, nil
+}
+
+// ErrNotSM2 means the unpacked key is not an SM2 key.
+var ErrNotSM2 = errors.New("not an SM2 key")
+
+// Unpack unpacks a key package into an SM2 key pair.
+func Unpack(pkg *akp.OneAsymmetricKey) (
+	priv *PrivateKey, pub *PublicKey, extras []interface{}, err error,
+) {
+	priv, pub, extras, err = akp.UnpackWith[*PrivateKey, *PublicKey](
+		Unpacker, pkg)
+	var mismatch *akp.TypeMismatchError
+	if errors.As(err, &mismatch) || err == akp.ErrSkip {
+		return nil, nil, nil, ErrNotSM2
+	}
+	return priv, pub, extras, err
+}
+
+// Capabilities reports that SM2 keys can sign.
+func (unpacker unpacker) Capabilities(
+	pkg *akp.OneAsymmetricKey,
+) akp.Capability {
+	if ok, err := isSM2Package(pkg); err != nil || !ok {
+		return 0
+	}
+	return akp.CanSign
+}
@@ -0,0 +1,87 @@
+package x448kp
+
+import (
+	"errors"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+	"github.com/harmony-one/asym-key-pkgs/pkg/internal/jwkfield"
+)
+
+const (
+	jwkKeyType = "OKP"
+	jwkCurve   = "X448" // RFC 8037, section 2
+)
+
+// JWKThumbprintMembers returns the required members of an X448 JWK
+// (RFC 8037, section 2).
+func (packer packer) JWKThumbprintMembers(pub interface{}) (
+	map[string]string, error,
+) {
+	pubKey, ok := pub.(*PublicKey)
+	if !ok {
+		return nil, akp.ErrSkip
+	}
+	return map[string]string{
+		"kty": jwkKeyType,
+		"crv": jwkCurve,
+		"x":   jwkfield.Bytes(pubKey.Bytes()),
+	}, nil
+}
+
+// JWKKeyTypes returns the JWK key type of X448 keys.
+func (packer packer) JWKKeyTypes() []string {
+	return []string{jwkKeyType}
+}
+
+// MarshalJWK returns the members of an X448 private JWK
+// (RFC 8037, section 2), whose "d" is the scalar.
+func (packer packer) MarshalJWK(priv interface{}) (
+	map[string]interface{}, error,
+) {
+	privKey, ok := priv.(*PrivateKey)
+	if !ok {
+		return nil, akp.ErrSkip
+	}
+	return map[string]interface{}{
+		"kty": jwkKeyType,
+		"crv": jwkCurve,
+		"x":   jwkfield.Bytes(privKey.public.Bytes()),
+		"d":   jwkfield.Bytes(privKey.Bytes()),
+	}, nil
+}
+
+// UnmarshalJWK converts the members of an X448 private JWK into an X448
+// key pair.
+//
+// It skips JWKs for other curves, such as Ed448.
+// The public key is recomputed and must match the "x" member.
+func (packer packer) UnmarshalJWK(members map[string]interface{}) (
+	priv interface{}, pub interface{}, err error,
+) {
+	if crv, _ := members["crv"].(string); crv != jwkCurve {
+		return nil, nil, akp.ErrSkip
+	}
+	x, err := jwkfield.GetBytes(members, "x")
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, ok := members["d"]; !ok {
+		return nil, nil, errors.New("JWK has no private key")
+	}
+	d, err := jwkfield.GetBytes(members, "d")
+	if err != nil {
+		return nil, nil, err
+	}
+	privKey, err := NewPrivateKey(d)
+	if err != nil {
+		return nil, nil, err
+	}
+	pubKey, err := NewPublicKey(x)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !pubKey.Equal(&privKey.public) {
+		return nil, nil, errors.New("public key does not match private key")
+	}
+	return privKey, pubKey, nil
+}
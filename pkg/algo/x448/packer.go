@@ -0,0 +1,53 @@
+package x448kp
+
+import (
+	"crypto/x509/pkix"
+	"encoding/asn1"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+)
+
+type packer struct{}
+
+// Packer is the singleton packer instance.
+var Packer packer
+
+func (packer packer) Pack(
+	priv interface{}, pub interface{}, options ...interface{},
+) (pkg *akp.OneAsymmetricKey, err error) {
+	privKey, ok := priv.(*PrivateKey)
+	if !ok {
+		return nil, akp.ErrSkip
+	}
+	if pub == nil {
+		return Pack(privKey, nil, options...)
+	}
+	pubKey, ok := pub.(*PublicKey)
+	if !ok {
+		return nil, akp.ErrSkip
+	}
+	return Pack(privKey, pubKey, options...)
+}
+
+// Pack packs the given X448 key pair into a key package.
+func Pack(
+	privKey *PrivateKey, pubKey *PublicKey, options ...interface{},
+) (pkg *akp.OneAsymmetricKey, err error) {
+	pkg = &akp.OneAsymmetricKey{
+		Version:             akp.V1,
+		PrivateKeyAlgorithm: pkix.AlgorithmIdentifier{Algorithm: algorithmOID},
+	}
+	// RFC 8410, section 7: CurvePrivateKey ::= OCTET STRING
+	if pkg.PrivateKey, err = asn1.Marshal(privKey.secret[:]); err != nil {
+		return nil, err
+	}
+	if pubKey != nil {
+		pkg.PublicKey = packPublicKey(pubKey)
+		pkg.Version = akp.V2
+	}
+	return pkg, nil
+}
+
+func packPublicKey(pubKey *PublicKey) asn1.BitString {
+	return asn1.BitString{Bytes: pubKey.Bytes(), BitLength: 8 * KeySize}
+}
@@ -0,0 +1,77 @@
+package x448kp
+
+import (
+	"encoding/asn1"
+	"errors"
+	"fmt"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+)
+
+type unpacker struct{}
+
+// Unpacker is the singleton X448 unpacker instance.
+var Unpacker unpacker
+
+// Unpack unpacks an X448 private key,
+// and derives the public key from it.
+//
+// If the key package contains the public key, it must match.
+func (unpacker unpacker) Unpack(pkg *akp.OneAsymmetricKey) (
+	priv interface{}, pub interface{}, extras []interface{}, err error,
+) {
+	if !pkg.PrivateKeyAlgorithm.Algorithm.Equal(algorithmOID) {
+		return nil, nil, nil, akp.ErrSkip
+	}
+	if len(pkg.PrivateKeyAlgorithm.Parameters.FullBytes) > 0 {
+		return nil, nil, nil, errors.New("X448 key has parameters")
+	}
+	var secret []byte
+	rest, err := asn1.Unmarshal(pkg.PrivateKey, &secret)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf(
+			"cannot unmarshal private key: %v", err)
+	}
+	if len(rest) > 0 {
+		return nil, nil, nil, errors.New("extra data after X448 private key")
+	}
+	privKey, err := NewPrivateKey(secret)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if pkg.PublicKey.Bytes == nil {
+		return privKey, nil, nil, nil
+	}
+	pubKey, err := NewPublicKey(pkg.PublicKey.RightAlign())
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if !pubKey.Equal(&privKey.public) {
+		return nil, nil, nil, errors.New(
+			"X448 public key does not match private key")
+	}
+	return privKey, pubKey, nil, nil
+}
+
+// ErrNotX448 means the unpacked key is not an X448 key.
+var ErrNotX448 = errors.New("not an X448 key")
+
+// Unpack unpacks a key package into an X448 key pair.
+func Unpack(pkg *akp.OneAsymmetricKey) (
+	priv *PrivateKey, pub *PublicKey, extras []interface{}, err error,
+) {
+	priv, pub, extras, err = akp.UnpackWith[*PrivateKey, *PublicKey](
+		Unpacker, pkg)
+	var mismatch *akp.TypeMismatchError
+	if errors.As(err, &mismatch) || err == akp.ErrSkip {
+		return nil, nil, nil, ErrNotX448
+	}
+	return priv, pub, extras, err
+}
+
+// Capabilities reports that X448 keys can agree on shared secrets.
+func (unpacker unpacker) Capabilities(
+	pkg *akp.OneAsymmetricKey,
+) akp.Capability {
+	return akp.CanAgree
+}
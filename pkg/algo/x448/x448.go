@@ -0,0 +1,121 @@
+// Package x448kp implements X448 key agreement keys (RFC 7748),
+// as specified in RFC 8410.
+//
+// Keys are *PrivateKey and *PublicKey values,
+// which wrap the keys of github.com/cloudflare/circl/dh/x448.
+//
+// The private key is a CurvePrivateKey,
+// i.e. an OCTET STRING holding the 56-byte scalar;
+// the public key is the 56-byte u-coordinate.
+// The AlgorithmIdentifier has no parameters.
+package x448kp
+
+import (
+	"crypto"
+	"crypto/subtle"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/cloudflare/circl/dh/x448"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+)
+
+// id-X448 in RFC 8410
+var algorithmOID = asn1.ObjectIdentifier{1, 3, 101, 111}
+
+func init() {
+	akp.Packers.Register(Packer, &PrivateKey{})
+	akp.Unpackers.Register(Unpacker, algorithmOID)
+}
+
+// KeySize is the size of X448 private and public keys.
+const KeySize = x448.Size
+
+// PublicKey is an X448 public key.
+type PublicKey struct {
+	key x448.Key
+}
+
+// NewPublicKey returns the X448 public key with the given u-coordinate.
+func NewPublicKey(b []byte) (*PublicKey, error) {
+	if len(b) != KeySize {
+		return nil, fmt.Errorf("X448 public key is %d bytes; expected %d",
+			len(b), KeySize)
+	}
+	pub := &PublicKey{}
+	copy(pub.key[:], b)
+	return pub, nil
+}
+
+// Bytes returns the u-coordinate of the public key.
+func (pub *PublicKey) Bytes() []byte {
+	return append([]byte(nil), pub.key[:]...)
+}
+
+// Equal reports whether pub and x are the same public key.
+func (pub *PublicKey) Equal(x crypto.PublicKey) bool {
+	xx, ok := x.(*PublicKey)
+	return ok && subtle.ConstantTimeCompare(pub.key[:], xx.key[:]) == 1
+}
+
+// PrivateKey is an X448 private key.
+type PrivateKey struct {
+	secret x448.Key
+	public PublicKey
+}
+
+// GenerateKey generates an X448 private key.
+func GenerateKey(rand io.Reader) (*PrivateKey, error) {
+	var secret [KeySize]byte
+	if _, err := io.ReadFull(rand, secret[:]); err != nil {
+		return nil, err
+	}
+	return NewPrivateKey(secret[:])
+}
+
+// NewPrivateKey returns the X448 private key with the given scalar,
+// and computes its public key.
+func NewPrivateKey(b []byte) (*PrivateKey, error) {
+	if len(b) != KeySize {
+		return nil, fmt.Errorf("X448 private key is %d bytes; expected %d",
+			len(b), KeySize)
+	}
+	priv := &PrivateKey{}
+	copy(priv.secret[:], b)
+	x448.KeyGen(&priv.public.key, &priv.secret)
+	return priv, nil
+}
+
+// Bytes returns the scalar of the private key.
+func (priv *PrivateKey) Bytes() []byte {
+	return append([]byte(nil), priv.secret[:]...)
+}
+
+// Public returns the public key.
+func (priv *PrivateKey) Public() crypto.PublicKey {
+	pub := priv.public
+	return &pub
+}
+
+// Equal reports whether priv and x are the same private key.
+func (priv *PrivateKey) Equal(x crypto.PrivateKey) bool {
+	xx, ok := x.(*PrivateKey)
+	return ok &&
+		subtle.ConstantTimeCompare(priv.secret[:], xx.secret[:]) == 1
+}
+
+// SharedSecret computes the X448 shared secret with a peer's public key
+// (RFC 7748, section 6.2).
+//
+// It fails if the peer's public key is a low-order point,
+// which results in the all-zero shared secret.
+func (priv *PrivateKey) SharedSecret(peer *PublicKey) ([]byte, error) {
+	var shared x448.Key
+	if !x448.Shared(&shared, &priv.secret, &peer.key) {
+		return nil, errors.New("X448 public key is a low-order point")
+	}
+	return shared[:], nil
+}
@@ -0,0 +1,273 @@
+package x448kp
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/asn1"
+	"encoding/hex"
+	"encoding/pem"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+)
+
+func generateKey(t *testing.T) *PrivateKey {
+	priv, err := GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("cannot generate X448 key: %v", err)
+	}
+	return priv
+}
+
+func TestRoundTrip(t *testing.T) {
+	priv := generateKey(t)
+	subtest := func(t *testing.T, pub *PublicKey) {
+		pkg, err := Packer.Pack(priv, pub)
+		if err != nil {
+			t.Fatalf("cannot pack X448 key pair: %v", err)
+		}
+		priv2, pub2, extras, err := Unpack(pkg)
+		if err != nil {
+			t.Fatalf("cannot unpack X448 key pair: %v", err)
+		}
+		if !priv.Equal(priv2) {
+			t.Error("reconstructed key is different from the original")
+		}
+		if pub == nil && pub2 != nil || pub != nil && !pub.Equal(pub2) {
+			t.Errorf("expected public key %v but got %v", pub, pub2)
+		}
+		if len(extras) > 0 {
+			t.Errorf("no extras were expected, but got some: %+v", extras)
+		}
+		pkg2, err := Pack(priv2, pub2, extras...)
+		if err != nil {
+			t.Fatalf("cannot repack X448 key pair: %v", err)
+		}
+		if !reflect.DeepEqual(pkg, pkg2) {
+			t.Errorf("repacked key package %+v is different from %+v",
+				pkg2, pkg)
+		}
+	}
+	t.Run("WithoutPublic", func(t *testing.T) { subtest(t, nil) })
+	t.Run("WithPublic", func(t *testing.T) {
+		subtest(t, priv.Public().(*PublicKey))
+	})
+}
+
+// RFC 8410 has no X448 example, so the testdata files hold Alice's key
+// pair of the X448 Diffie-Hellman example of RFC 7748, section 6.2,
+// in the RFC 8410 encoding. They were generated by OpenSSL 3.0:
+//
+//	echo 3046020100300506032b656f043a0438<Alice's private key> |
+//		xxd -r -p | openssl pkey -inform DER -out rfc7748.pem
+//	openssl pkey -in rfc7748.pem -pubout -out rfc7748_pub.pem
+//
+// OpenSSL derives Alice's public key from her private key.
+
+const (
+	alicePublicKey = "9b08f7cc31b7e3e67d22d5aea121074a273bd2b83de09c63faa7" +
+		"3d2c22c5d9bbc836647241d953d40c5b12da88120d53177f80e532c41fa0"
+	bobPublicKey = "3eb7a829b0cd20f5bcfc0b599b6feccf6da4627107bdb0d4f345b4" +
+		"3027d8b972fc3e34fb4232a13ca706dcb57aec3dae07bdc1c67bf33609"
+	sharedSecret = "07fff4181ac6cc95ec1c16a94a0f74d12da232ce40a77552281d28" +
+		"2bb60c0b56fd2464c335543936521c24403085d59a449a5037514a879d"
+)
+
+func readPEM(t *testing.T, filename string) []byte {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		t.Fatalf("%s: no PEM block", filename)
+	}
+	return block.Bytes
+}
+
+func mustPublicKey(t *testing.T, s string) *PublicKey {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub, err := NewPublicKey(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pub
+}
+
+func TestVectors(t *testing.T) {
+	der := readPEM(t, "testdata/rfc7748.pem")
+	priv, pub, extras, err := akp.Decode(der)
+	if err != nil {
+		t.Fatalf("cannot decode X448 key: %v", err)
+	}
+	if pub != nil || len(extras) > 0 {
+		t.Errorf("unexpected public key %v or extras %+v", pub, extras)
+	}
+	privKey := priv.(*PrivateKey)
+	expectedPub := mustPublicKey(t, alicePublicKey)
+	if !expectedPub.Equal(privKey.Public()) {
+		t.Errorf("derived public key is %x; expected %x",
+			privKey.Public().(*PublicKey).Bytes(), expectedPub.Bytes())
+	}
+	encoded, err := akp.Encode(priv, nil)
+	if err != nil {
+		t.Fatalf("cannot encode X448 key: %v", err)
+	}
+	if !bytes.Equal(encoded, der) {
+		t.Errorf("re-encoded key is %x; expected %x", encoded, der)
+	}
+	pkg, err := Pack(privKey, expectedPub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	spki, err := pkg.MarshalPublicKeyInfo()
+	if err != nil {
+		t.Fatalf("cannot marshal SubjectPublicKeyInfo: %v", err)
+	}
+	if expected := readPEM(t, "testdata/rfc7748_pub.pem"); !bytes.Equal(
+		spki, expected) {
+		t.Errorf("SubjectPublicKeyInfo is %x; expected %x", spki, expected)
+	}
+	secret, err := privKey.SharedSecret(mustPublicKey(t, bobPublicKey))
+	if err != nil {
+		t.Fatalf("cannot compute shared secret: %v", err)
+	}
+	if hex.EncodeToString(secret) != sharedSecret {
+		t.Errorf("shared secret is %x; expected %s", secret, sharedSecret)
+	}
+}
+
+func TestSharedSecret(t *testing.T) {
+	alice, bob := generateKey(t), generateKey(t)
+	s1, err := alice.SharedSecret(bob.Public().(*PublicKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s2, err := bob.SharedSecret(alice.Public().(*PublicKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(s1, s2) {
+		t.Errorf("shared secrets %x and %x are different", s1, s2)
+	}
+	// u = 1 is a point of order 4.
+	lowOrder := &PublicKey{}
+	lowOrder.key[0] = 1
+	if _, err = alice.SharedSecret(lowOrder); err == nil {
+		t.Error("SharedSecret accepted a low-order point")
+	}
+}
+
+func TestUnpack(t *testing.T) {
+	priv := generateKey(t)
+	pkg, err := Pack(priv, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other := generateKey(t)
+	for _, test := range []struct {
+		name   string
+		modify func(pkg *akp.OneAsymmetricKey)
+	}{
+		{"ShortKey", func(pkg *akp.OneAsymmetricKey) {
+			pkg.PrivateKey, _ = asn1.Marshal(priv.Bytes()[1:])
+		}},
+		{"RawKey", func(pkg *akp.OneAsymmetricKey) {
+			pkg.PrivateKey = priv.Bytes()
+		}},
+		{"TrailingData", func(pkg *akp.OneAsymmetricKey) {
+			pkg.PrivateKey = append(bytes.Clone(pkg.PrivateKey), 0)
+		}},
+		{"Parameters", func(pkg *akp.OneAsymmetricKey) {
+			pkg.PrivateKeyAlgorithm.Parameters.FullBytes = asn1.NullBytes
+		}},
+		{"ShortPublicKey", func(pkg *akp.OneAsymmetricKey) {
+			pkg.PublicKey = packPublicKey(priv.Public().(*PublicKey))
+			pkg.PublicKey.Bytes = pkg.PublicKey.Bytes[1:]
+			pkg.PublicKey.BitLength -= 8
+		}},
+		{"PublicKeyMismatch", func(pkg *akp.OneAsymmetricKey) {
+			pkg.PublicKey = packPublicKey(other.Public().(*PublicKey))
+		}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			pkg := *pkg
+			test.modify(&pkg)
+			if _, _, _, err = Unpack(&pkg); err == nil {
+				t.Error("Unpack succeeded")
+			}
+		})
+	}
+	t.Run("WrongAlgorithm", func(t *testing.T) {
+		pkg := *pkg
+		pkg.PrivateKeyAlgorithm.Algorithm = asn1.ObjectIdentifier{1, 2, 3}
+		if _, _, _, err = Unpack(&pkg); err != ErrNotX448 {
+			t.Errorf("Unpack returned %v; expected %v", err, ErrNotX448)
+		}
+	})
+}
+
+func TestCapabilities(t *testing.T) {
+	encoded, err := akp.Encode(generateKey(t), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var pkg akp.OneAsymmetricKey
+	if _, err = asn1.Unmarshal(encoded, &pkg); err != nil {
+		t.Fatal(err)
+	}
+	if c := pkg.Capabilities(); c != akp.CanAgree {
+		t.Errorf("capabilities are %v; expected %v", c, akp.CanAgree)
+	}
+}
+
+func TestJWK(t *testing.T) {
+	priv, _, _, err := akp.Decode(readPEM(t, "testdata/rfc7748.pem"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]interface{}{
+		"kty": "OKP",
+		"crv": "X448",
+		"d": "mo9JJdFRn1d1z0awS1gA1O6e6LrovFVl1JjCjdnJuvV0qUGXRIlzkQBjgqbxJ6s" +
+			"dmsLYwKWYcms",
+		"x": "mwj3zDG34-Z9ItWuoSEHSic70rg94Jxj-qc9LCLF2bvINmRyQdlT1AxbEtqIEg1" +
+			"TF3-A5TLEH6A",
+	}
+	members, err := akp.Packers.MarshalJWK(priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(members, expected) {
+		t.Errorf("JWK is %+v; expected %+v", members, expected)
+	}
+	priv2, pub2, err := akp.Packers.UnmarshalJWK(members)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !priv.(*PrivateKey).Equal(priv2) {
+		t.Error("JWK round trip returned a different key")
+	}
+	if !mustPublicKey(t, alicePublicKey).Equal(pub2) {
+		t.Error("JWK round trip returned a different public key")
+	}
+	fp, err := akp.KeyFingerprint(priv, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// SHA-256 of {"crv":"X448","kty":"OKP","x":"..."} (RFC 7638).
+	thumbprint := "X7Nqq56_hWB_zjSTTN0UEEsN9OnnjvGJIjV7MjEnCko"
+	if fp.JWKThumbprintString() != thumbprint {
+		t.Errorf("JWK thumbprint is %q; expected %q",
+			fp.JWKThumbprintString(), thumbprint)
+	}
+	members["x"] = members["d"]
+	if _, _, err = akp.Packers.UnmarshalJWK(members); err == nil {
+		t.Error("mismatching public key was accepted")
+	}
+}
@@ -0,0 +1,100 @@
+package xmsskp
+
+import (
+	"crypto/sha256"
+	"crypto/sha3"
+	"encoding/binary"
+	"hash"
+)
+
+// Hash function domain separators, as toByte(x, 32) prefixes
+// (RFC 8391, section 5.1, and NIST SP 800-208, section 5)
+const (
+	prefixF         = 0
+	prefixH         = 1
+	prefixHMsg      = 2
+	prefixPRF       = 3
+	prefixPRFKeygen = 4
+)
+
+// hasher computes the keyed hash functions of a parameter set.
+type hasher struct {
+	sha2  hash.Hash
+	shake *sha3.SHAKE
+	buf   []byte
+}
+
+func newHasher(params Params) *hasher {
+	if params.shake() {
+		return &hasher{shake: sha3.NewSHAKE128()}
+	}
+	return &hasher{sha2: sha256.New()}
+}
+
+// hash writes the n-byte hash of toByte(prefix, 32) || key || m to out,
+// which may overlap with the input.
+func (hasher *hasher) hash(out []byte, prefix byte, key []byte, m ...[]byte) {
+	var p [32]byte
+	p[31] = prefix
+	if hasher.shake != nil {
+		hasher.shake.Reset()
+		hasher.shake.Write(p[:])
+		hasher.shake.Write(key)
+		for _, b := range m {
+			hasher.shake.Write(b)
+		}
+		hasher.shake.Read(out[:n])
+		return
+	}
+	hasher.sha2.Reset()
+	hasher.sha2.Write(p[:])
+	hasher.sha2.Write(key)
+	for _, b := range m {
+		hasher.sha2.Write(b)
+	}
+	hasher.buf = hasher.sha2.Sum(hasher.buf[:0])
+	copy(out, hasher.buf)
+}
+
+// prf writes PRF(key, adrs) to out.
+func (hasher *hasher) prf(out, key []byte, adrs *address) {
+	hasher.hash(out, prefixPRF, key, adrs.bytes())
+}
+
+// address is a hash function address (RFC 8391, section 2.5).
+// The layer and tree addresses are always 0 in single-tree XMSS.
+type address [8]uint32
+
+// Address types
+const (
+	otsAddress   = 0
+	lTreeAddress = 1
+	treeAddress  = 2
+)
+
+// setType sets the address type, and clears the words that depend on it.
+func (adrs *address) setType(t uint32) {
+	adrs[3] = t
+	adrs[4], adrs[5], adrs[6], adrs[7] = 0, 0, 0, 0
+}
+
+// setOTS sets the OTS address, or the L-tree address.
+func (adrs *address) setOTS(i uint32) { adrs[4] = i }
+
+func (adrs *address) setChain(i uint32) { adrs[5] = i }
+
+func (adrs *address) setHash(i uint32) { adrs[6] = i }
+
+func (adrs *address) setTreeHeight(i uint32) { adrs[5] = i }
+
+func (adrs *address) setTreeIndex(i uint32) { adrs[6] = i }
+
+func (adrs *address) setKeyAndMask(i uint32) { adrs[7] = i }
+
+func (adrs *address) bytes() []byte {
+	b := make([]byte, 0, 32)
+	for _, word := range adrs {
+		b = binary.BigEndian.AppendUint32(b, word)
+	}
+	return b
+}
@@ -0,0 +1,250 @@
+package xmsskp
+
+import (
+	"bytes"
+	"crypto"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sync"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+)
+
+// PrivateKey is an XMSS private key.
+//
+// It implements akp.StatefulKey; a PrivateKey is safe for concurrent use.
+type PrivateKey struct {
+	params  Params
+	skSeed  []byte
+	skPRF   []byte
+	root    []byte
+	pubSeed []byte
+	index   uint64 // next signature index
+	limit   uint64 // end of the usable signature indices
+
+	mu    sync.Mutex
+	nodes [][]byte // nodes of the tree by height, computed on demand
+}
+
+// GenerateKey generates an XMSS private key with the given parameter set.
+// This computes the whole tree.
+func GenerateKey(rand io.Reader, params Params) (*PrivateKey, error) {
+	if params.height() == 0 {
+		return nil, fmt.Errorf("unknown XMSS parameter set %d", params)
+	}
+	seeds := make([]byte, 3*n)
+	if _, err := io.ReadFull(rand, seeds); err != nil {
+		return nil, err
+	}
+	priv := &PrivateKey{
+		params:  params,
+		skSeed:  seeds[:n],
+		skPRF:   seeds[n : 2*n],
+		pubSeed: seeds[2*n:],
+		limit:   1 << params.height(),
+	}
+	priv.nodes = newTree(params, priv.pubSeed).build(priv.skSeed)
+	priv.root = priv.nodes[params.height()]
+	return priv, nil
+}
+
+// newPrivateKey checks the given key material and returns a private key,
+// whose limit is the number of signatures of the tree if 0.
+func newPrivateKey(
+	params Params, skSeed, skPRF, root, pubSeed []byte, index, limit uint64,
+) (*PrivateKey, error) {
+	h := params.height()
+	if h == 0 {
+		return nil, fmt.Errorf("unknown XMSS parameter set %d", params)
+	}
+	for _, b := range [][]byte{skSeed, skPRF, root, pubSeed} {
+		if len(b) != n {
+			return nil, errors.New("XMSS key has a wrong seed or root size")
+		}
+	}
+	if limit == 0 {
+		limit = 1 << h
+	}
+	if limit > 1<<h || index > limit {
+		return nil, errors.New("XMSS key has a signature index out of range")
+	}
+	return &PrivateKey{
+		params:  params,
+		skSeed:  slices.Clone(skSeed),
+		skPRF:   slices.Clone(skPRF),
+		root:    slices.Clone(root),
+		pubSeed: slices.Clone(pubSeed),
+		index:   index,
+		limit:   limit,
+	}, nil
+}
+
+// Params returns the parameter set of the key.
+func (priv *PrivateKey) Params() Params {
+	return priv.params
+}
+
+// Public returns the public key.
+func (priv *PrivateKey) Public() crypto.PublicKey {
+	data := binary.BigEndian.AppendUint32(nil, uint32(priv.params))
+	data = append(data, priv.root...)
+	return &PublicKey{data: append(data, priv.pubSeed...)}
+}
+
+// Equal reports whether priv and x are the same key with the same state.
+func (priv *PrivateKey) Equal(x crypto.PrivateKey) bool {
+	xx, ok := x.(*PrivateKey)
+	if !ok {
+		return false
+	}
+	if priv == xx {
+		return true
+	}
+	priv.mu.Lock()
+	defer priv.mu.Unlock()
+	xx.mu.Lock()
+	defer xx.mu.Unlock()
+	return priv.params == xx.params &&
+		bytes.Equal(priv.skSeed, xx.skSeed) &&
+		bytes.Equal(priv.skPRF, xx.skPRF) &&
+		bytes.Equal(priv.root, xx.root) &&
+		bytes.Equal(priv.pubSeed, xx.pubSeed) &&
+		priv.index == xx.index && priv.limit == xx.limit
+}
+
+// Remaining returns the number of signatures that the key can make.
+func (priv *PrivateKey) Remaining() uint64 {
+	priv.mu.Lock()
+	defer priv.mu.Unlock()
+	return priv.limit - priv.index
+}
+
+// Reserve splits off the next count signature indices of the key.
+func (priv *PrivateKey) Reserve(count uint64) (akp.StatefulKey, error) {
+	priv.mu.Lock()
+	defer priv.mu.Unlock()
+	if count == 0 || count > priv.limit-priv.index {
+		return nil, fmt.Errorf("cannot reserve %d of %d remaining signatures",
+			count, priv.limit-priv.index)
+	}
+	reserved := &PrivateKey{
+		params:  priv.params,
+		skSeed:  priv.skSeed,
+		skPRF:   priv.skPRF,
+		root:    priv.root,
+		pubSeed: priv.pubSeed,
+		index:   priv.index,
+		limit:   priv.index + count,
+		nodes:   priv.nodes,
+	}
+	priv.index += count
+	return reserved, nil
+}
+
+// Sign signs the message with the next signature index
+// (RFC 8391, algorithm 12).
+//
+// The message must not be hashed: opts.HashFunc() must be zero.
+// The randomness of XMSS signatures is derived from SK_PRF,
+// so rand is not used.
+func (priv *PrivateKey) Sign(
+	rand io.Reader, message []byte, opts crypto.SignerOpts,
+) ([]byte, error) {
+	if opts.HashFunc() != 0 {
+		return nil, errors.New("XMSS signs unhashed messages only")
+	}
+	priv.mu.Lock()
+	defer priv.mu.Unlock()
+	if priv.index >= priv.limit {
+		return nil, akp.ErrKeyExhausted
+	}
+	t := newTree(priv.params, priv.pubSeed)
+	h := priv.params.height()
+	if priv.nodes == nil {
+		nodes := t.build(priv.skSeed)
+		if !bytes.Equal(nodes[h], priv.root) {
+			return nil, errors.New("XMSS private key does not match its root")
+		}
+		priv.nodes = nodes
+	}
+	idx := uint32(priv.index)
+	priv.index++
+	sig := binary.BigEndian.AppendUint32(nil, idx)
+	var idxBytes [n]byte
+	binary.BigEndian.PutUint32(idxBytes[n-4:], idx)
+	r := make([]byte, n)
+	t.hasher.hash(r, prefixPRF, priv.skPRF, idxBytes[:])
+	sig = append(sig, r...)
+	m := make([]byte, n)
+	t.hasher.hash(m, prefixHMsg, r, priv.root, idxBytes[:], message)
+	var adrs address
+	adrs.setType(otsAddress)
+	adrs.setOTS(idx)
+	sig = append(sig, t.wotsSign(priv.skSeed, m, &adrs)...)
+	for j := 0; j < h; j++ {
+		i := int(idx>>j) ^ 1
+		sig = append(sig, priv.nodes[j][i*n:(i+1)*n]...)
+	}
+	return sig, nil
+}
+
+// publicKeySize is the size of an XMSS public key: OID || root || SEED.
+const publicKeySize = 4 + 2*n
+
+// PublicKey is an XMSS public key.
+type PublicKey struct {
+	data []byte
+}
+
+// ParsePublicKey parses an XMSS public key (RFC 8391, section 4.1.7).
+func ParsePublicKey(data []byte) (*PublicKey, error) {
+	if len(data) != publicKeySize {
+		return nil, fmt.Errorf("XMSS public key is %d bytes; expected %d",
+			len(data), publicKeySize)
+	}
+	if params := Params(binary.BigEndian.Uint32(data)); params.height() == 0 {
+		return nil, fmt.Errorf("unknown XMSS parameter set %d", params)
+	}
+	return &PublicKey{data: slices.Clone(data)}, nil
+}
+
+// Params returns the parameter set of the key.
+func (pub *PublicKey) Params() Params {
+	return Params(binary.BigEndian.Uint32(pub.data))
+}
+
+// Bytes returns the encoding of the public key (RFC 8391, section 4.1.7).
+func (pub *PublicKey) Bytes() []byte {
+	return slices.Clone(pub.data)
+}
+
+// Equal reports whether pub and x are the same public key.
+func (pub *PublicKey) Equal(x crypto.PublicKey) bool {
+	xx, ok := x.(*PublicKey)
+	return ok && bytes.Equal(pub.data, xx.data)
+}
+
+// Verify reports whether sig is a valid XMSS signature of the message under
+// the public key (RFC 8391, algorithm 14).
+func Verify(pub *PublicKey, message, sig []byte) bool {
+	params := pub.Params()
+	h := params.height()
+	if len(sig) != 4+n+wotsLen*n+h*n {
+		return false
+	}
+	idx := binary.BigEndian.Uint32(sig)
+	if idx>>h != 0 {
+		return false
+	}
+	root, pubSeed := pub.data[4:4+n], pub.data[4+n:]
+	r, wotsSig, auth := sig[4:4+n], sig[4+n:4+n+wotsLen*n], sig[4+n+wotsLen*n:]
+	var idxBytes [n]byte
+	binary.BigEndian.PutUint32(idxBytes[n-4:], idx)
+	t := newTree(params, pubSeed)
+	m := make([]byte, n)
+	t.hasher.hash(m, prefixHMsg, r, root, idxBytes[:], message)
+	return bytes.Equal(t.rootFromSig(idx, wotsSig, auth, m), root)
+}
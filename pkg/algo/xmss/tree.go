@@ -0,0 +1,186 @@
+package xmsskp
+
+// tree computes the hashes of the XMSS trees with a given SEED.
+type tree struct {
+	params  Params
+	pubSeed []byte
+	hasher  *hasher
+}
+
+func newTree(params Params, pubSeed []byte) *tree {
+	return &tree{params: params, pubSeed: pubSeed, hasher: newHasher(params)}
+}
+
+// chain applies the steps start, ..., start+steps-1 of a WOTS+ hash chain
+// to x, in place (RFC 8391, algorithm 2).
+func (t *tree) chain(x []byte, start, steps int, adrs *address) {
+	key := make([]byte, n)
+	mask := make([]byte, n)
+	for i := start; i < start+steps; i++ {
+		adrs.setHash(uint32(i))
+		adrs.setKeyAndMask(0)
+		t.hasher.prf(key, t.pubSeed, adrs)
+		adrs.setKeyAndMask(1)
+		t.hasher.prf(mask, t.pubSeed, adrs)
+		for j := range mask {
+			mask[j] ^= x[j]
+		}
+		t.hasher.hash(x, prefixF, key, mask)
+	}
+}
+
+// wotsSecret returns the secret key of chain i of the WOTS+ key pair at
+// the OTS address of adrs (NIST SP 800-208, algorithm 10').
+func (t *tree) wotsSecret(skSeed []byte, i int, adrs *address) []byte {
+	adrs.setChain(uint32(i))
+	adrs.setHash(0)
+	adrs.setKeyAndMask(0)
+	sk := make([]byte, n)
+	t.hasher.hash(sk, prefixPRFKeygen, skSeed, t.pubSeed, adrs.bytes())
+	return sk
+}
+
+// wotsPublicKey returns the WOTS+ public key at the OTS address of adrs
+// (RFC 8391, algorithm 4).
+func (t *tree) wotsPublicKey(skSeed []byte, adrs *address) []byte {
+	pk := make([]byte, 0, wotsLen*n)
+	for i := 0; i < wotsLen; i++ {
+		x := t.wotsSecret(skSeed, i, adrs)
+		t.chain(x, 0, w-1, adrs)
+		pk = append(pk, x...)
+	}
+	return pk
+}
+
+// digits returns the base-w digits of the message and of its checksum
+// (RFC 8391, algorithm 5).
+func digits(m []byte) []int {
+	d := make([]int, 0, wotsLen)
+	sum := 0
+	for _, b := range m[:n] {
+		d = append(d, int(b>>4), int(b&15))
+		sum += 2*(w-1) - int(b>>4) - int(b&15)
+	}
+	// The checksum is 12 bits; shifted, it is 3 base-w digits of 2 bytes.
+	sum <<= 4
+	return append(d, sum>>12&15, sum>>8&15, sum>>4&15)
+}
+
+// wotsSign returns the WOTS+ signature of the n-byte message with the key
+// pair at the OTS address of adrs (RFC 8391, algorithm 5).
+func (t *tree) wotsSign(skSeed, m []byte, adrs *address) []byte {
+	sig := make([]byte, 0, wotsLen*n)
+	for i, d := range digits(m) {
+		x := t.wotsSecret(skSeed, i, adrs)
+		t.chain(x, 0, d, adrs)
+		sig = append(sig, x...)
+	}
+	return sig
+}
+
+// wotsPublicKeyFromSig returns the WOTS+ public key that the signature of
+// the n-byte message is valid for (RFC 8391, algorithm 6).
+func (t *tree) wotsPublicKeyFromSig(sig, m []byte, adrs *address) []byte {
+	pk := append([]byte(nil), sig[:wotsLen*n]...)
+	for i, d := range digits(m) {
+		adrs.setChain(uint32(i))
+		t.chain(pk[i*n:(i+1)*n], d, w-1-d, adrs)
+	}
+	return pk
+}
+
+// randHash writes the hash of the left and right nodes to out
+// (RFC 8391, algorithm 7).
+func (t *tree) randHash(out, left, right []byte, adrs *address) {
+	key := make([]byte, n)
+	masks := make([]byte, 2*n)
+	adrs.setKeyAndMask(0)
+	t.hasher.prf(key, t.pubSeed, adrs)
+	adrs.setKeyAndMask(1)
+	t.hasher.prf(masks[:n], t.pubSeed, adrs)
+	adrs.setKeyAndMask(2)
+	t.hasher.prf(masks[n:], t.pubSeed, adrs)
+	for j := 0; j < n; j++ {
+		masks[j] ^= left[j]
+		masks[n+j] ^= right[j]
+	}
+	t.hasher.hash(out, prefixH, key, masks)
+}
+
+// lTree compresses a WOTS+ public key, in place, into its first n bytes
+// (RFC 8391, algorithm 8).
+func (t *tree) lTree(pk []byte, adrs *address) []byte {
+	node := func(i int) []byte { return pk[i*n : (i+1)*n] }
+	height := uint32(0)
+	for l := wotsLen; l > 1; l = (l + 1) / 2 {
+		adrs.setTreeHeight(height)
+		for i := 0; i < l/2; i++ {
+			adrs.setTreeIndex(uint32(i))
+			t.randHash(node(i), node(2*i), node(2*i+1), adrs)
+		}
+		if l%2 == 1 {
+			copy(node(l/2), node(l-1))
+		}
+		height++
+	}
+	return node(0)
+}
+
+// leaf returns leaf i of the tree: the compressed WOTS+ public key i.
+func (t *tree) leaf(skSeed []byte, i uint32) []byte {
+	var adrs address
+	adrs.setType(otsAddress)
+	adrs.setOTS(i)
+	pk := t.wotsPublicKey(skSeed, &adrs)
+	adrs.setType(lTreeAddress)
+	adrs.setOTS(i)
+	return t.lTree(pk, &adrs)
+}
+
+// build computes all the nodes of the tree, by height
+// (RFC 8391, algorithm 9).
+func (t *tree) build(skSeed []byte) [][]byte {
+	h := t.params.height()
+	nodes := make([][]byte, h+1)
+	nodes[0] = make([]byte, 0, n<<h)
+	for i := uint32(0); i < 1<<h; i++ {
+		nodes[0] = append(nodes[0], t.leaf(skSeed, i)...)
+	}
+	var adrs address
+	adrs.setType(treeAddress)
+	for j := 1; j <= h; j++ {
+		below := nodes[j-1]
+		nodes[j] = make([]byte, n<<(h-j))
+		adrs.setTreeHeight(uint32(j - 1))
+		for i := 0; i < 1<<(h-j); i++ {
+			adrs.setTreeIndex(uint32(i))
+			t.randHash(nodes[j][i*n:(i+1)*n],
+				below[2*i*n:(2*i+1)*n], below[(2*i+1)*n:(2*i+2)*n], &adrs)
+		}
+	}
+	return nodes
+}
+
+// rootFromSig returns the root that the WOTS+ signature of the n-byte
+// message with key pair idx and its authentication path are valid for
+// (RFC 8391, algorithm 13).
+func (t *tree) rootFromSig(idx uint32, sig, auth, m []byte) []byte {
+	var adrs address
+	adrs.setType(otsAddress)
+	adrs.setOTS(idx)
+	pk := t.wotsPublicKeyFromSig(sig, m, &adrs)
+	adrs.setType(lTreeAddress)
+	adrs.setOTS(idx)
+	node := t.lTree(pk, &adrs)
+	adrs.setType(treeAddress)
+	for k := 0; k < t.params.height(); k++ {
+		adrs.setTreeHeight(uint32(k))
+		adrs.setTreeIndex(idx >> (k + 1))
+		if idx>>k&1 == 0 {
+			t.randHash(node, node, auth[k*n:(k+1)*n], &adrs)
+		} else {
+			t.randHash(node, auth[k*n:(k+1)*n], node, &adrs)
+		}
+	}
+	return node
+}
@@ -0,0 +1,82 @@
+package xmsskp
+
+import (
+	"encoding/asn1"
+	"errors"
+	"fmt"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+)
+
+type unpacker struct{}
+
+// Unpacker is the singleton XMSS unpacker instance.
+var Unpacker unpacker
+
+// Unpack unpacks an XMSS private key, including its state.
+//
+// The public key, if present, must match the private key.
+// The root of the private key is only checked against SK_SEED and SEED
+// when the key first signs, which takes computing the tree.
+func (unpacker unpacker) Unpack(pkg *akp.OneAsymmetricKey) (
+	priv interface{}, pub interface{}, extras []interface{}, err error,
+) {
+	if !pkg.PrivateKeyAlgorithm.Algorithm.Equal(algorithmOID) {
+		return nil, nil, nil, akp.ErrSkip
+	}
+	if len(pkg.PrivateKeyAlgorithm.Parameters.FullBytes) > 0 {
+		return nil, nil, nil, errors.New("XMSS key has parameters")
+	}
+	var key asn1PrivateKey
+	rest, err := asn1.Unmarshal(pkg.PrivateKey, &key)
+	if err == nil && len(rest) > 0 {
+		err = errors.New("trailing data")
+	}
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf(
+			"cannot unmarshal private key: %v", err)
+	}
+	if key.Type < 0 || key.Index < 0 || key.Limit < 0 {
+		return nil, nil, nil, errors.New("XMSS key has a negative field")
+	}
+	privKey, err := newPrivateKey(Params(key.Type), key.SKSeed, key.SKPRF,
+		key.Root, key.PubSeed, uint64(key.Index), uint64(key.Limit))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if pkg.PublicKey.Bytes == nil {
+		return privKey, nil, nil, nil
+	}
+	pubKey, err := ParsePublicKey(pkg.PublicKey.RightAlign())
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if !pubKey.Equal(privKey.Public()) {
+		return nil, nil, nil, errors.New(
+			"XMSS public key does not match private key")
+	}
+	return privKey, pubKey, nil, nil
+}
+
+// ErrNotXMSS means the unpacked key is not an XMSS key.
+var ErrNotXMSS = errors.New("not an XMSS key")
+
+// Unpack unpacks a key package into an XMSS key pair.
+func Unpack(pkg *akp.OneAsymmetricKey) (
+	priv *PrivateKey, pub *PublicKey, extras []interface{}, err error,
+) {
+	priv, pub, extras, err = akp.UnpackWith[*PrivateKey, *PublicKey](
+		Unpacker, pkg)
+	var mismatch *akp.TypeMismatchError
+	if errors.As(err, &mismatch) || err == akp.ErrSkip {
+		return nil, nil, nil, ErrNotXMSS
+	}
+	return priv, pub, extras, err
+}
+
+// Capabilities reports that XMSS keys can sign.
+func (unpacker unpacker) Capabilities(
+	pkg *akp.OneAsymmetricKey,
+) akp.Capability {
+	return akp.CanSign
+}
@@ -0,0 +1,82 @@
+// Package xmsskp implements stateful XMSS hash-based signature keys
+// (RFC 8391), as specified in RFC 9802,
+// for the single-tree XMSS parameter sets of RFC 8391 with n = 32.
+//
+// Keys are *PrivateKey and *PublicKey values.
+// A private key makes one signature per index, and each index must only be
+// used once; see akp.StatefulKey and akp.StatefulSigner for keeping saved
+// keys from reusing indices.
+//
+// The public key is the XMSS public key of RFC 8391, section 4.1.7.
+// RFC 9802 does not specify private keys; the private key here holds the
+// values of RFC 8391, section 4.1.11:
+//
+//	XMSSPrivateKey ::= SEQUENCE {
+//	  type    INTEGER,      -- XMSS algorithm type
+//	  index   INTEGER,      -- next signature index
+//	  skSeed  OCTET STRING, -- SK_SEED
+//	  skPRF   OCTET STRING, -- SK_PRF
+//	  root    OCTET STRING,
+//	  pubSeed OCTET STRING, -- SEED
+//	  limit   INTEGER OPTIONAL } -- end of the reserved indices
+//
+// The WOTS+ private keys are derived from SK_SEED with PRF_keygen,
+// as in NIST SP 800-208, section 7.2.1.
+// The tree is computed in full when the key first signs,
+// so keys of height 16 or more are slow to use.
+package xmsskp
+
+import (
+	"encoding/asn1"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+)
+
+// id-alg-xmss-hashsig in the PKIX algorithm arc
+var algorithmOID = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 6, 34}
+
+func init() {
+	akp.Packers.Register(Packer, &PrivateKey{})
+	akp.Unpackers.Register(Unpacker, algorithmOID)
+}
+
+// Params is an XMSS algorithm type (RFC 8391, section 5.3),
+// which identifies a parameter set.
+type Params uint32
+
+// XMSS parameter sets
+const (
+	XMSS_SHA2_10_256  Params = 0x00000001
+	XMSS_SHA2_16_256  Params = 0x00000002
+	XMSS_SHA2_20_256  Params = 0x00000003
+	XMSS_SHAKE_10_256 Params = 0x00000007
+	XMSS_SHAKE_16_256 Params = 0x00000008
+	XMSS_SHAKE_20_256 Params = 0x00000009
+)
+
+// height returns the tree height of the parameter set, or 0 if unknown.
+func (params Params) height() int {
+	switch params {
+	case XMSS_SHA2_10_256, XMSS_SHAKE_10_256:
+		return 10
+	case XMSS_SHA2_16_256, XMSS_SHAKE_16_256:
+		return 16
+	case XMSS_SHA2_20_256, XMSS_SHAKE_20_256:
+		return 20
+	}
+	return 0
+}
+
+// shake reports whether the parameter set uses SHAKE128 rather than SHA-256.
+func (params Params) shake() bool {
+	return params >= XMSS_SHAKE_10_256
+}
+
+// WOTS+ parameters of all parameter sets (RFC 8391, section 3.1.1)
+const (
+	n       = 32
+	w       = 16
+	len1    = 64
+	len2    = 3
+	wotsLen = len1 + len2
+)
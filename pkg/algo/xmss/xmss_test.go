@@ -0,0 +1,233 @@
+package xmsskp
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"encoding/asn1"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+)
+
+// keys caches a generated key per parameter set,
+// since generating one computes a whole tree.
+var keys sync.Map
+
+func generateKey(t *testing.T, params Params) *PrivateKey {
+	if priv, ok := keys.Load(params); ok {
+		reserved, err := priv.(*PrivateKey).Reserve(16)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return reserved.(*PrivateKey)
+	}
+	priv, err := GenerateKey(rand.Reader, params)
+	if err != nil {
+		t.Fatalf("cannot generate XMSS key: %v", err)
+	}
+	keys.Store(params, priv)
+	return generateKey(t, params)
+}
+
+func sign(t *testing.T, signer crypto.Signer, message []byte) []byte {
+	sig, err := signer.Sign(rand.Reader, message, crypto.Hash(0))
+	if err != nil {
+		t.Fatalf("cannot sign: %v", err)
+	}
+	return sig
+}
+
+func TestSign(t *testing.T) {
+	for _, params := range []Params{XMSS_SHA2_10_256, XMSS_SHAKE_10_256} {
+		priv := generateKey(t, params)
+		pub := priv.Public().(*PublicKey)
+		message := []byte("hello, world")
+		sigs := [][]byte{sign(t, priv, message), sign(t, priv, message)}
+		for i, sig := range sigs {
+			if !Verify(pub, message, sig) {
+				t.Errorf("cannot verify signature %d", i)
+			}
+		}
+		if bytes.Equal(sigs[0][4:], sigs[1][4:]) {
+			t.Error("signatures with different indices are the same")
+		}
+		if _, err := priv.Sign(rand.Reader, message, crypto.SHA256); err ==
+			nil {
+			t.Error("Sign signed a digest")
+		}
+		if Verify(pub, []byte("hello, world!"), sigs[0]) {
+			t.Error("signature of another message verified")
+		}
+		for _, i := range []int{3, 4, 100, len(sigs[0]) - 1} {
+			sig := bytes.Clone(sigs[0])
+			sig[i] ^= 1
+			if Verify(pub, message, sig) {
+				t.Errorf("signature with byte %d flipped verified", i)
+			}
+		}
+		if Verify(pub, message, sigs[0][:len(sigs[0])-1]) {
+			t.Error("truncated signature verified")
+		}
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	priv := generateKey(t, XMSS_SHA2_10_256)
+	pub := priv.Public().(*PublicKey)
+	for _, pub := range []*PublicKey{nil, pub} {
+		pkg, err := Packer.Pack(priv, pub)
+		if err != nil {
+			t.Fatalf("cannot pack XMSS key pair: %v", err)
+		}
+		priv2, pub2, extras, err := Unpack(pkg)
+		if err != nil {
+			t.Fatalf("cannot unpack XMSS key pair: %v", err)
+		}
+		if !priv.Equal(priv2) {
+			t.Error("reconstructed key is different from the original")
+		}
+		if pub == nil && pub2 != nil || pub != nil && !pub.Equal(pub2) {
+			t.Errorf("expected public key %v but got %v", pub, pub2)
+		}
+		if len(extras) > 0 {
+			t.Errorf("no extras were expected, but got some: %+v", extras)
+		}
+	}
+}
+
+func TestReserve(t *testing.T) {
+	priv := generateKey(t, XMSS_SHA2_10_256)
+	pub := priv.Public().(*PublicKey)
+	reserved, err := priv.Reserve(2)
+	if err != nil {
+		t.Fatalf("cannot reserve signatures: %v", err)
+	}
+	if r := priv.Remaining(); r != 14 {
+		t.Errorf("key has %d remaining signatures; expected 14", r)
+	}
+	message := []byte("hello, world")
+	for i := 0; i < 2; i++ {
+		if !Verify(pub, message, sign(t, reserved, message)) {
+			t.Error("cannot verify signature of reserved key")
+		}
+	}
+	if _, err = reserved.Sign(rand.Reader, message, crypto.Hash(0)); err !=
+		akp.ErrKeyExhausted {
+		t.Errorf("Sign returned %v; expected %v", err, akp.ErrKeyExhausted)
+	}
+	if _, err = priv.Reserve(15); err == nil {
+		t.Error("Reserve reserved more signatures than remaining")
+	}
+}
+
+func TestStatefulSigner(t *testing.T) {
+	priv := generateKey(t, XMSS_SHA2_10_256)
+	pub := priv.Public().(*PublicKey)
+	filename := filepath.Join(t.TempDir(), "xmss.der")
+	if err := akp.Save(filename, priv, pub); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := akp.LoadSigner(filename); err != akp.ErrStatefulKey {
+		t.Errorf("LoadSigner returned %v; expected %v",
+			err, akp.ErrStatefulKey)
+	}
+	signer, err := akp.OpenStatefulSigner(filename, 3)
+	if err != nil {
+		t.Fatalf("cannot open stateful signer: %v", err)
+	}
+	defer signer.Close() // nolint
+	message := []byte("hello, world")
+	for i := 0; i < 4; i++ {
+		if !Verify(pub, message, sign(t, signer, message)) {
+			t.Errorf("cannot verify signature %d", i)
+		}
+	}
+	saved, _, _, err := akp.Load(filename)
+	if err != nil {
+		t.Fatalf("cannot load XMSS key: %v", err)
+	}
+	if index := saved.(*PrivateKey).index; index != priv.index+6 {
+		t.Errorf("saved index is %d after two batches; expected %d",
+			index, priv.index+6)
+	}
+}
+
+func TestUnpack(t *testing.T) {
+	priv := generateKey(t, XMSS_SHA2_10_256)
+	pkg, err := Pack(priv, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	valid := asn1PrivateKey{int(priv.params), 0, priv.skSeed, priv.skPRF,
+		priv.root, priv.pubSeed, 0}
+	for _, test := range []struct {
+		name   string
+		modify func(key *asn1PrivateKey)
+	}{
+		{"UnknownType", func(key *asn1PrivateKey) { key.Type = 4 }},
+		{"ShortSeed", func(key *asn1PrivateKey) { key.SKSeed = key.SKSeed[1:] }},
+		{"IndexOutOfRange", func(key *asn1PrivateKey) { key.Index = 1025 }},
+		{"LimitOutOfRange", func(key *asn1PrivateKey) { key.Limit = 1025 }},
+		{"NegativeIndex", func(key *asn1PrivateKey) { key.Index = -1 }},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			key := valid
+			test.modify(&key)
+			pkg := *pkg
+			if pkg.PrivateKey, err = asn1.Marshal(key); err != nil {
+				t.Fatal(err)
+			}
+			if _, _, _, err = Unpack(&pkg); err == nil {
+				t.Error("Unpack succeeded")
+			}
+		})
+	}
+	t.Run("WrongRoot", func(t *testing.T) {
+		key := valid
+		key.Root = bytes.Clone(key.Root)
+		key.Root[0] ^= 1
+		pkg := *pkg
+		if pkg.PrivateKey, err = asn1.Marshal(key); err != nil {
+			t.Fatal(err)
+		}
+		priv, _, _, err := Unpack(&pkg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = priv.Sign(rand.Reader, nil, crypto.Hash(0)); err == nil {
+			t.Error("key with a wrong root signed")
+		}
+	})
+	t.Run("PublicKeyMismatch", func(t *testing.T) {
+		other := generateKey(t, XMSS_SHAKE_10_256)
+		pkg := *pkg
+		pkg.PublicKey = packPublicKey(other.Public().(*PublicKey))
+		if _, _, _, err = Unpack(&pkg); err == nil {
+			t.Error("Unpack accepted a mismatching public key")
+		}
+	})
+	t.Run("WrongAlgorithm", func(t *testing.T) {
+		pkg := *pkg
+		pkg.PrivateKeyAlgorithm.Algorithm = asn1.ObjectIdentifier{1, 2, 3}
+		if _, _, _, err = Unpack(&pkg); err != ErrNotXMSS {
+			t.Errorf("Unpack returned %v; expected %v", err, ErrNotXMSS)
+		}
+	})
+}
+
+func TestCapabilities(t *testing.T) {
+	encoded, err := akp.Encode(generateKey(t, XMSS_SHA2_10_256), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var pkg akp.OneAsymmetricKey
+	if _, err = asn1.Unmarshal(encoded, &pkg); err != nil {
+		t.Fatal(err)
+	}
+	if c := pkg.Capabilities(); c != akp.CanSign {
+		t.Errorf("capabilities are %v; expected %v", c, akp.CanSign)
+	}
+}
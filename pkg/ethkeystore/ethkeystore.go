@@ -0,0 +1,356 @@
+// Package ethkeystore converts between key packages and Ethereum-style JSON
+// keystores (Web3 Secret Storage, version 3).
+//
+// A keystore holds a secp256k1 private key,
+// which is packed by package secp256k1kp.
+// The keystore "id" maps onto the localKeyId attribute of the key package.
+//
+// The "address" member, if any, is checked on decryption,
+// and is written on encryption.
+package ethkeystore
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"golang.org/x/crypto/scrypt"
+	"golang.org/x/crypto/sha3"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+	"github.com/harmony-one/asym-key-pkgs/pkg/algo/secp256k1"
+)
+
+// Scrypt parameters, the same as go-ethereum uses.
+const (
+	// StandardScryptN is the N parameter of scrypt for regular use.
+	StandardScryptN = 1 << 18
+
+	// StandardScryptP is the P parameter of scrypt for regular use.
+	StandardScryptP = 1
+
+	// LightScryptN is the N parameter of scrypt for use on constrained
+	// devices.
+	LightScryptN = 1 << 12
+
+	// LightScryptP is the P parameter of scrypt for use on constrained
+	// devices.
+	LightScryptP = 6
+)
+
+const (
+	version       = 3
+	cipherName    = "aes-128-ctr"
+	scryptR       = 8
+	derivedLen    = 32
+	privKeyLen    = 32
+	maxScryptMem  = 1 << 30 // bytes
+	maxScryptWork = 1 << 34 // bytes mixed, 128 * N * r * p
+	maxPBKDF2C    = 1 << 24
+)
+
+// ErrIncorrectPassword means the keystore could not be decrypted with the
+// given password.
+var ErrIncorrectPassword = errors.New("incorrect password")
+
+type keystoreJSON struct {
+	Address string     `json:"address,omitempty"`
+	Crypto  cryptoJSON `json:"crypto"`
+	ID      string     `json:"id"`
+	Version int        `json:"version"`
+}
+
+type cryptoJSON struct {
+	Cipher       string           `json:"cipher"`
+	CipherText   string           `json:"ciphertext"`
+	CipherParams cipherParamsJSON `json:"cipherparams"`
+	KDF          string           `json:"kdf"`
+	KDFParams    json.RawMessage  `json:"kdfparams"`
+	MAC          string           `json:"mac"`
+}
+
+type cipherParamsJSON struct {
+	IV string `json:"iv"`
+}
+
+type scryptParamsJSON struct {
+	DKLen int    `json:"dklen"`
+	N     int    `json:"n"`
+	P     int    `json:"p"`
+	R     int    `json:"r"`
+	Salt  string `json:"salt"`
+}
+
+type pbkdf2ParamsJSON struct {
+	C     int    `json:"c"`
+	DKLen int    `json:"dklen"`
+	PRF   string `json:"prf"`
+	Salt  string `json:"salt"`
+}
+
+// Decrypt decrypts a JSON keystore into a key package.
+func Decrypt(data []byte, password string) (*akp.OneAsymmetricKey, error) {
+	var ks keystoreJSON
+	if err := json.Unmarshal(data, &ks); err != nil {
+		return nil, err
+	}
+	if ks.Version != version {
+		return nil, fmt.Errorf("unsupported keystore version %d", ks.Version)
+	}
+	if ks.Crypto.Cipher != cipherName {
+		return nil, fmt.Errorf("unsupported cipher %q", ks.Crypto.Cipher)
+	}
+	cipherText, err := hex.DecodeString(ks.Crypto.CipherText)
+	if err != nil {
+		return nil, fmt.Errorf("bad ciphertext: %v", err)
+	}
+	iv, err := hex.DecodeString(ks.Crypto.CipherParams.IV)
+	if err != nil || len(iv) != aes.BlockSize {
+		return nil, errors.New("bad cipher IV")
+	}
+	mac, err := hex.DecodeString(ks.Crypto.MAC)
+	if err != nil {
+		return nil, fmt.Errorf("bad MAC: %v", err)
+	}
+	derivedKey, err := deriveKey(&ks.Crypto, password)
+	if err != nil {
+		return nil, err
+	}
+	if !hmac.Equal(computeMAC(derivedKey, cipherText), mac) {
+		return nil, ErrIncorrectPassword
+	}
+	d, err := crypt(derivedKey, iv, cipherText)
+	if err != nil {
+		return nil, err
+	}
+	pkg, err := pack(d)
+	if err != nil {
+		return nil, err
+	}
+	if ks.ID != "" {
+		pkg.SetLocalKeyID([]byte(ks.ID))
+	}
+	if ks.Address != "" {
+		address, err := Address(pkg)
+		if err != nil {
+			return nil, err
+		}
+		expected := strings.TrimPrefix(strings.ToLower(ks.Address), "0x")
+		if address != expected {
+			return nil, errors.New("address does not match private key")
+		}
+	}
+	return pkg, nil
+}
+
+// Encrypt encrypts a secp256k1 key package into a JSON keystore,
+// with a key derived by scrypt with the given N and P parameters
+// (StandardScryptN and StandardScryptP if 0).
+//
+// The keystore "id" is taken from the localKeyId attribute if that holds a
+// UUID, or generated randomly otherwise.
+func Encrypt(
+	pkg *akp.OneAsymmetricKey, password string, scryptN, scryptP int,
+) ([]byte, error) {
+	d, err := unpack(pkg)
+	if err != nil {
+		return nil, err
+	}
+	if scryptN == 0 {
+		scryptN = StandardScryptN
+	}
+	if scryptP == 0 {
+		scryptP = StandardScryptP
+	}
+	salt := make([]byte, 32)
+	iv := make([]byte, aes.BlockSize)
+	if _, err = rand.Read(salt); err != nil {
+		return nil, err
+	}
+	if _, err = rand.Read(iv); err != nil {
+		return nil, err
+	}
+	params := scryptParamsJSON{
+		DKLen: derivedLen, N: scryptN, P: scryptP, R: scryptR,
+		Salt: hex.EncodeToString(salt),
+	}
+	ks := keystoreJSON{
+		Crypto: cryptoJSON{
+			Cipher:       cipherName,
+			CipherParams: cipherParamsJSON{IV: hex.EncodeToString(iv)},
+			KDF:          "scrypt",
+		},
+		Version: version,
+	}
+	if ks.Crypto.KDFParams, err = json.Marshal(params); err != nil {
+		return nil, err
+	}
+	derivedKey, err := deriveKey(&ks.Crypto, password)
+	if err != nil {
+		return nil, err
+	}
+	cipherText, err := crypt(derivedKey, iv, d)
+	if err != nil {
+		return nil, err
+	}
+	ks.Crypto.CipherText = hex.EncodeToString(cipherText)
+	ks.Crypto.MAC = hex.EncodeToString(computeMAC(derivedKey, cipherText))
+	if id, ok := pkg.LocalKeyID(); ok && isUUID(string(id)) {
+		ks.ID = string(id)
+	} else if ks.ID, err = newUUID(); err != nil {
+		return nil, err
+	}
+	if ks.Address, err = Address(pkg); err != nil {
+		return nil, err
+	}
+	return json.Marshal(ks)
+}
+
+// Address returns the Ethereum address of a secp256k1 key package,
+// as lower-case hex digits without the 0x prefix.
+func Address(pkg *akp.OneAsymmetricKey) (string, error) {
+	// The address is that of the private key, whatever public key the key
+	// package holds; Unpack checks that they match anyway.
+	priv, _, _, err := secp256k1kp.Unpack(pkg)
+	if err != nil {
+		return "", err
+	}
+	pub := &priv.PublicKey
+	var point [2 * privKeyLen]byte
+	pub.X.FillBytes(point[:privKeyLen])
+	pub.Y.FillBytes(point[privKeyLen:])
+	h := sha3.NewLegacyKeccak256()
+	h.Write(point[:]) // nolint: hash.Hash.Write never fails
+	return hex.EncodeToString(h.Sum(nil)[12:]), nil
+}
+
+func deriveKey(c *cryptoJSON, password string) ([]byte, error) {
+	switch c.KDF {
+	case "scrypt":
+		var params scryptParamsJSON
+		if err := json.Unmarshal(c.KDFParams, &params); err != nil {
+			return nil, fmt.Errorf("bad scrypt parameters: %v", err)
+		}
+		salt, err := hex.DecodeString(params.Salt)
+		if err != nil {
+			return nil, fmt.Errorf("bad scrypt salt: %v", err)
+		}
+		if params.DKLen != derivedLen {
+			return nil, fmt.Errorf("unsupported dklen %d", params.DKLen)
+		}
+		if params.N <= 0 || params.R <= 0 || params.P <= 0 {
+			return nil, errors.New("bad scrypt parameters")
+		}
+		// RFC 7914, section 2 requires r * p < 2^30.
+		r, p := uint64(params.R), uint64(params.P)
+		if r*p >= 1<<30 || uint64(params.N) > maxScryptMem/128/r ||
+			uint64(params.N)*r*p > maxScryptWork/128 {
+			return nil, errors.New("scrypt parameters too large")
+		}
+		return scrypt.Key([]byte(password), salt, params.N, params.R,
+			params.P, params.DKLen)
+	case "pbkdf2":
+		var params pbkdf2ParamsJSON
+		if err := json.Unmarshal(c.KDFParams, &params); err != nil {
+			return nil, fmt.Errorf("bad pbkdf2 parameters: %v", err)
+		}
+		salt, err := hex.DecodeString(params.Salt)
+		if err != nil {
+			return nil, fmt.Errorf("bad pbkdf2 salt: %v", err)
+		}
+		if params.PRF != "hmac-sha256" {
+			return nil, fmt.Errorf("unsupported pbkdf2 PRF %q", params.PRF)
+		}
+		if params.DKLen != derivedLen {
+			return nil, fmt.Errorf("unsupported dklen %d", params.DKLen)
+		}
+		if params.C <= 0 || params.C > maxPBKDF2C {
+			return nil, fmt.Errorf("bad pbkdf2 iteration count %d", params.C)
+		}
+		return pbkdf2.Key(sha256.New, password, salt, params.C, params.DKLen)
+	default:
+		return nil, fmt.Errorf("unsupported KDF %q", c.KDF)
+	}
+}
+
+// computeMAC computes the keystore MAC,
+// the Keccak-256 digest of the second half of the derived key and the
+// ciphertext.
+func computeMAC(derivedKey, cipherText []byte) []byte {
+	h := sha3.NewLegacyKeccak256()
+	h.Write(derivedKey[16:32]) // nolint: hash.Hash.Write never fails
+	h.Write(cipherText)        // nolint: hash.Hash.Write never fails
+	return h.Sum(nil)
+}
+
+// crypt encrypts or decrypts (these are the same in CTR mode) the private
+// key with the first half of the derived key.
+func crypt(derivedKey, iv, data []byte) ([]byte, error) {
+	block, err := aes.NewCipher(derivedKey[:16])
+	if err != nil {
+		return nil, err
+	}
+	out := make([]byte, len(data))
+	cipher.NewCTR(block, iv).XORKeyStream(out, data)
+	return out, nil
+}
+
+func pack(d []byte) (*akp.OneAsymmetricKey, error) {
+	if len(d) != privKeyLen {
+		return nil, fmt.Errorf("private key is %d bytes; expected %d",
+			len(d), privKeyLen)
+	}
+	curve := secp256k1kp.S256()
+	privKey := &ecdsa.PrivateKey{D: new(big.Int).SetBytes(d)}
+	privKey.Curve = curve
+	privKey.X, privKey.Y = curve.ScalarBaseMult(d)
+	return secp256k1kp.Pack(privKey, nil)
+}
+
+func unpack(pkg *akp.OneAsymmetricKey) ([]byte, error) {
+	privKey, _, _, err := secp256k1kp.Unpack(pkg)
+	if err != nil {
+		return nil, err
+	}
+	return privKey.D.FillBytes(make([]byte, privKeyLen)), nil
+}
+
+func newUUID() (string, error) {
+	var u [16]byte
+	if _, err := rand.Read(u[:]); err != nil {
+		return "", err
+	}
+	u[6] = u[6]&0x0f | 0x40 // version 4
+	u[8] = u[8]&0x3f | 0x80 // RFC 4122 variant
+	return fmt.Sprintf("%x-%x-%x-%x-%x", u[:4], u[4:6], u[6:8], u[8:10],
+		u[10:]), nil
+}
+
+func isUUID(s string) bool {
+	if len(s) != 36 {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		switch i {
+		case 8, 13, 18, 23:
+			if s[i] != '-' {
+				return false
+			}
+		default:
+			if !strings.ContainsRune("0123456789abcdefABCDEF", rune(s[i])) {
+				return false
+			}
+		}
+	}
+	return true
+}
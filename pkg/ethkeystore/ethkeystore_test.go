@@ -0,0 +1,164 @@
+package ethkeystore
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+)
+
+// Test vectors from the Web3 Secret Storage Definition.
+const (
+	testPassword = "testpassword"
+	testKey      = "7a28b5ba57c53603b0b07b56bba752f7784bf506fa95edc395f5cf6c7514fe9d"
+	testAddress  = "008aeeda4d805471df9b2a5b0f38a0c3bcba786b"
+
+	pbkdf2Keystore = `{
+		"crypto": {
+			"cipher": "aes-128-ctr",
+			"cipherparams": {"iv": "6087dab2f9fdbbfaddc31a909735c1e6"},
+			"ciphertext": "5318b4d5bcd28de64ee5559e671353e16f075ecae9f99c7a79a38af5f869aa46",
+			"kdf": "pbkdf2",
+			"kdfparams": {
+				"c": 262144,
+				"dklen": 32,
+				"prf": "hmac-sha256",
+				"salt": "ae3cd4e7013836a3df6bd7241b12db061dbe2c6785853cce422d148a624ce0bd"
+			},
+			"mac": "517ead924a9d0dc3124507e3393d175ce3ff7c1e96529c6c555ce9e51205e9b2"
+		},
+		"id": "3198bc9c-6672-5ab3-d995-4942343ae5b6",
+		"version": 3
+	}`
+
+	scryptKeystore = `{
+		"crypto": {
+			"cipher": "aes-128-ctr",
+			"cipherparams": {"iv": "83dbcc02d8ccb40e466191a123791e0e"},
+			"ciphertext": "d172bf743a674da9cdad04534d56926ef8358534d458fffccd4e6ad2fbde479c",
+			"kdf": "scrypt",
+			"kdfparams": {
+				"dklen": 32,
+				"n": 262144,
+				"p": 8,
+				"r": 1,
+				"salt": "ab0c7876052600dd703518d6fc3fe8984592145b591fc8fb5c6d43190334ba19"
+			},
+			"mac": "2103ac29920d71da29f15d75b4a16dbe95cfd7ff8faea1056c33131d846e3097"
+		},
+		"id": "3198bc9c-6672-5ab3-d995-4942343ae5b6",
+		"version": 3
+	}`
+)
+
+func checkKey(t *testing.T, data []byte) {
+	pkg, err := Decrypt(data, testPassword)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d, err := unpack(pkg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hex.EncodeToString(d) != testKey {
+		t.Errorf("private key is %x; expected %s", d, testKey)
+	}
+	id, _ := pkg.LocalKeyID()
+	if string(id) != "3198bc9c-6672-5ab3-d995-4942343ae5b6" {
+		t.Errorf("localKeyId is %q", id)
+	}
+}
+
+func TestDecrypt(t *testing.T) {
+	t.Run("pbkdf2", func(t *testing.T) {
+		checkKey(t, []byte(pbkdf2Keystore))
+	})
+	t.Run("scrypt", func(t *testing.T) {
+		checkKey(t, []byte(scryptKeystore))
+	})
+	t.Run("incorrect password", func(t *testing.T) {
+		_, err := Decrypt([]byte(pbkdf2Keystore), "wrong")
+		if err != ErrIncorrectPassword {
+			t.Errorf("got error %v; expected %v", err, ErrIncorrectPassword)
+		}
+	})
+}
+
+func TestEncrypt(t *testing.T) {
+	d, _ := hex.DecodeString(testKey)
+	pkg, err := pack(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkg.SetLocalKeyID([]byte("3198bc9c-6672-5ab3-d995-4942343ae5b6"))
+	data, err := Encrypt(pkg, testPassword, LightScryptN, LightScryptP)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ks keystoreJSON
+	if err = json.Unmarshal(data, &ks); err != nil {
+		t.Fatal(err)
+	}
+	if ks.Version != 3 || ks.Crypto.KDF != "scrypt" ||
+		ks.Address != testAddress {
+		t.Errorf("unexpected keystore %s", data)
+	}
+	checkKey(t, data)
+
+	pkg.SetLocalKeyID([]byte("not a UUID"))
+	data, err = Encrypt(pkg, testPassword, LightScryptN, LightScryptP)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = json.Unmarshal(data, &ks); err != nil {
+		t.Fatal(err)
+	}
+	if !isUUID(ks.ID) || ks.ID[14] != '4' {
+		t.Errorf("generated id %q is not a version 4 UUID", ks.ID)
+	}
+	pkg2, err := Decrypt(data, testPassword)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(pkg2.PrivateKey, pkg.PrivateKey) {
+		t.Error("private key did not round-trip")
+	}
+}
+
+func TestAddress(t *testing.T) {
+	var ks map[string]interface{}
+	if err := json.Unmarshal([]byte(pbkdf2Keystore), &ks); err != nil {
+		t.Fatal(err)
+	}
+	ks["address"] = "0x" + testAddress
+	data, _ := json.Marshal(ks)
+	pkg, err := Decrypt(data, testPassword)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if address, err := Address(pkg); err != nil || address != testAddress {
+		t.Errorf("Address returned %q, %v; expected %q",
+			address, err, testAddress)
+	}
+	ks["address"] = "0000000000000000000000000000000000000000"
+	data, _ = json.Marshal(ks)
+	if _, err = Decrypt(data, testPassword); err == nil {
+		t.Error("mismatching address was accepted")
+	}
+}
+
+func TestScryptParameters(t *testing.T) {
+	for _, p := range []float64{0, -1, 1 << 20, 1 << 30} {
+		var ks map[string]interface{}
+		if err := json.Unmarshal([]byte(scryptKeystore), &ks); err != nil {
+			t.Fatal(err)
+		}
+		c := ks["crypto"].(map[string]interface{})
+		c["kdfparams"].(map[string]interface{})["p"] = p
+		data, _ := json.Marshal(ks)
+		if _, err := Decrypt(data, testPassword); err == nil ||
+			err == ErrIncorrectPassword {
+			t.Errorf("Decrypt returned %v for p = %v", err, p)
+		}
+	}
+}
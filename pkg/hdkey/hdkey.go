@@ -0,0 +1,240 @@
+// Package hdkey implements hierarchical deterministic key derivation:
+// BIP-32 for secp256k1 keys, and SLIP-10 for Ed25519 and P-256 keys.
+//
+// An ExtendedKey is a private key with its chain code and its derivation
+// path from the master key.
+// It packs into a key package of the key's algorithm, as packages
+// secp256k1kp, ed25519kp and eckp pack it,
+// with the chain code and the derivation path in the attributes of the key
+// package; see OIDChainCode and OIDDerivationPath.
+// DeriveKeyPackage rebuilds the key package of any descendant of a packaged
+// key from the key package and a path.
+//
+// SLIP-10 agrees with BIP-32 on secp256k1, except for the invalid keys that
+// BIP-32 skips, which occur with a probability below 2^-127.
+// Ed25519 keys only have hardened children.
+package hdkey
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/algo/secp256k1"
+	"github.com/harmony-one/asym-key-pkgs/pkg/internal/ecurve"
+)
+
+// Curve is the curve of an extended key.
+type Curve int
+
+// Curves
+const (
+	Secp256k1 Curve = iota + 1 // BIP-32
+	Ed25519                    // SLIP-10, hardened children only
+	P256                       // SLIP-10 nist256p1
+)
+
+func (curve Curve) String() string {
+	switch curve {
+	case Secp256k1:
+		return "secp256k1"
+	case Ed25519:
+		return "Ed25519"
+	case P256:
+		return "P-256"
+	}
+	return fmt.Sprintf("Curve(%d)", int(curve))
+}
+
+// hmacKey returns the HMAC key of master key generation
+// (SLIP-10, "Master key generation").
+func (curve Curve) hmacKey() []byte {
+	switch curve {
+	case Secp256k1:
+		return []byte("Bitcoin seed")
+	case Ed25519:
+		return []byte("ed25519 seed")
+	case P256:
+		return []byte("Nist256p1 seed")
+	}
+	return nil
+}
+
+// ellipticCurve returns the Weierstrass curve, or nil for Ed25519.
+func (curve Curve) ellipticCurve() elliptic.Curve {
+	switch curve {
+	case Secp256k1:
+		return secp256k1kp.S256()
+	case P256:
+		return elliptic.P256()
+	}
+	return nil
+}
+
+// KeySize is the size of private keys and chain codes.
+const KeySize = 32
+
+// MaxDepth is the maximum depth of extended keys, below the master key.
+const MaxDepth = 255
+
+// ErrHardenedOnly means a non-hardened child of an Ed25519 key was
+// requested.
+var ErrHardenedOnly = errors.New("Ed25519 keys only have hardened children")
+
+// ExtendedKey is a private key with its chain code.
+type ExtendedKey struct {
+	Curve Curve
+
+	// Key is the private key: the big-endian scalar of a secp256k1 or P-256
+	// key, or the seed of an Ed25519 key.
+	Key []byte
+
+	ChainCode []byte
+
+	// Path is the derivation path of the key from the master key.
+	Path Path
+}
+
+// NewMaster generates the master key of the given curve from the seed
+// (BIP-32, "Master key generation"; SLIP-10).
+func NewMaster(curve Curve, seed []byte) (*ExtendedKey, error) {
+	key := curve.hmacKey()
+	if key == nil {
+		return nil, fmt.Errorf("unsupported curve %v", curve)
+	}
+	if len(seed) < 16 || len(seed) > 64 {
+		return nil, fmt.Errorf("seed is %d bytes; expected 16 to 64",
+			len(seed))
+	}
+	i := hmacSHA512(key, seed)
+	for !curve.isValid(i[:KeySize]) {
+		i = hmacSHA512(key, i)
+	}
+	return &ExtendedKey{
+		Curve: curve, Key: i[:KeySize], ChainCode: i[KeySize:],
+	}, nil
+}
+
+func hmacSHA512(key []byte, data ...[]byte) []byte {
+	mac := hmac.New(sha512.New, key)
+	for _, d := range data {
+		mac.Write(d)
+	}
+	return mac.Sum(nil)
+}
+
+// isValid reports whether the private key is in [1, n - 1].
+func (curve Curve) isValid(key []byte) bool {
+	c := curve.ellipticCurve()
+	if c == nil {
+		return true
+	}
+	k := new(big.Int).SetBytes(key)
+	return k.Sign() > 0 && k.Cmp(c.Params().N) < 0
+}
+
+// Child derives the child key with the given index,
+// which is hardened if it is at least Hardened
+// (BIP-32, "Private parent key → private child key"; SLIP-10).
+func (k *ExtendedKey) Child(index uint32) (*ExtendedKey, error) {
+	if len(k.Path) >= MaxDepth {
+		return nil, fmt.Errorf("extended key is at the maximum depth %d",
+			MaxDepth)
+	}
+	if len(k.Key) != KeySize || len(k.ChainCode) != KeySize {
+		return nil, errors.New("extended key has a wrong size")
+	}
+	var data []byte
+	switch {
+	case index >= Hardened:
+		data = append([]byte{0}, k.Key...)
+	case k.Curve == Ed25519:
+		return nil, ErrHardenedOnly
+	default:
+		point, err := k.publicPoint()
+		if err != nil {
+			return nil, err
+		}
+		data = point
+	}
+	data = binary.BigEndian.AppendUint32(data, index)
+	i := hmacSHA512(k.ChainCode, data)
+	child := &ExtendedKey{
+		Curve: k.Curve,
+		Path:  append(slices.Clip(k.Path), index),
+	}
+	c := k.Curve.ellipticCurve()
+	if c == nil {
+		child.Key, child.ChainCode = i[:KeySize], i[KeySize:]
+		return child, nil
+	}
+	n := c.Params().N
+	for {
+		il := new(big.Int).SetBytes(i[:KeySize])
+		if il.Cmp(n) < 0 {
+			il.Add(il, new(big.Int).SetBytes(k.Key))
+			if il.Mod(il, n).Sign() != 0 {
+				child.Key = il.FillBytes(make([]byte, KeySize))
+				child.ChainCode = i[KeySize:]
+				return child, nil
+			}
+		}
+		// SLIP-10: retry with I = HMAC-SHA512(c, 0x01 || IR || ser32(i)).
+		data = append([]byte{1}, i[KeySize:]...)
+		data = binary.BigEndian.AppendUint32(data, index)
+		i = hmacSHA512(k.ChainCode, data)
+	}
+}
+
+// Derive derives the descendant key at the given path below the key.
+func (k *ExtendedKey) Derive(path Path) (*ExtendedKey, error) {
+	var err error
+	for _, index := range path {
+		if k, err = k.Child(index); err != nil {
+			return nil, err
+		}
+	}
+	return k, nil
+}
+
+// publicPoint returns the compressed public point of a secp256k1 or P-256
+// key.
+func (k *ExtendedKey) publicPoint() ([]byte, error) {
+	priv, err := k.PrivateKey()
+	if err != nil {
+		return nil, err
+	}
+	pub := priv.(*ecdsa.PrivateKey).PublicKey
+	return ecurve.MarshalPoint(pub.Curve, pub.X, pub.Y, ecurve.Compressed), nil
+}
+
+// PrivateKey returns the private key: an *ecdsa.PrivateKey on
+// secp256k1kp.S256() or elliptic.P256(), or an ed25519.PrivateKey.
+func (k *ExtendedKey) PrivateKey() (interface{}, error) {
+	if len(k.Key) != KeySize {
+		return nil, errors.New("extended key has a wrong size")
+	}
+	switch k.Curve {
+	case Ed25519:
+		return ed25519.NewKeyFromSeed(k.Key), nil
+	case P256:
+		return ecdsa.ParseRawPrivateKey(elliptic.P256(), k.Key)
+	case Secp256k1:
+		if !k.Curve.isValid(k.Key) {
+			return nil, errors.New("secp256k1 private key out of range")
+		}
+		curve := secp256k1kp.S256()
+		priv := &ecdsa.PrivateKey{D: new(big.Int).SetBytes(k.Key)}
+		priv.Curve = curve
+		priv.X, priv.Y = curve.ScalarBaseMult(k.Key)
+		return priv, nil
+	}
+	return nil, fmt.Errorf("unsupported curve %v", k.Curve)
+}
@@ -0,0 +1,345 @@
+package hdkey
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ed25519"
+	"encoding/asn1"
+	"encoding/hex"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+	"github.com/harmony-one/asym-key-pkgs/pkg/algo/secp256k1"
+)
+
+func mustDecodeHex(t *testing.T, s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+const vectorSeed = "000102030405060708090a0b0c0d0e0f"
+
+type vector struct {
+	path      string
+	chainCode string
+	key       string
+}
+
+// Test vector 1 of BIP-32, and test vectors 1 of SLIP-10 for ed25519 and
+// nist256p1, which derive keys from the same seed.
+var vectors = map[Curve][]vector{
+	Secp256k1: {
+		{"m",
+			"873dff81c02f525623fd1fe5167eac3a55a049de3d314bb42ee227ffed37d508",
+			"e8f32e723decf4051aefac8e2c93c9c5b214313817cdb01a1494b917c8436b35"},
+		{"m/0H",
+			"47fdacbd0f1097043b78c63c20c34ef4ed9a111d980047ad16282c7ae6236141",
+			"edb2e14f9ee77d26dd93b4ecede8d16ed408ce149b6cd80b0715a2d911a0afea"},
+		{"m/0H/1",
+			"2a7857631386ba23dacac34180dd1983734e444fdbf774041578e9b6adb37c19",
+			"3c6cb8d0f6a264c91ea8b5030fadaa8e538b020f0a387421a12de9319dc93368"},
+		{"m/0H/1/2H",
+			"04466b9cc8e161e966409ca52986c584f07e9dc81f735db683c3ff6ec7b1503f",
+			"cbce0d719ecf7431d88e6a89fa1483e02e35092af60c042b1df2ff59fa424dca"},
+		{"m/0H/1/2H/2",
+			"cfb71883f01676f587d023cc53a35bc7f88f724b1f8c2892ac1275ac822a3edd",
+			"0f479245fb19a38a1954c5c7c0ebab2f9bdfd96a17563ef28a6a4b1a2a764ef4"},
+		{"m/0H/1/2H/2/1000000000",
+			"c783e67b921d2beb8f6b389cc646d7263b4145701dadd2161548a8b078e65e9e",
+			"471b76e389e528d6de6d816857e012c5455051cad6660850e58372a6c3e6e7c8"},
+	},
+	Ed25519: {
+		{"m",
+			"90046a93de5380a72b5e45010748567d5ea02bbf6522f979e05c0d8d8ca9fffb",
+			"2b4be7f19ee27bbf30c667b642d5f4aa69fd169872f8fc3059c08ebae2eb19e7"},
+		{"m/0H",
+			"8b59aa11380b624e81507a27fedda59fea6d0b779a778918a2fd3590e16e9c69",
+			"68e0fe46dfb67e368c75379acec591dad19df3cde26e63b93a8e704f1dade7a3"},
+		{"m/0H/1H",
+			"a320425f77d1b5c2505a6b1b27382b37368ee640e3557c315416801243552f14",
+			"b1d0bad404bf35da785a64ca1ac54b2617211d2777696fbffaf208f746ae84f2"},
+		{"m/0H/1H/2H",
+			"2e69929e00b5ab250f49c3fb1c12f252de4fed2c1db88387094a0f8c4c9ccd6c",
+			"92a5b23c0b8a99e37d07df3fb9966917f5d06e02ddbd909c7e184371463e9fc9"},
+		{"m/0H/1H/2H/2H",
+			"8f6d87f93d750e0efccda017d662a1b31a266e4a6f5993b15f5c1f07f74dd5cc",
+			"30d1dc7e5fc04c31219ab25a27ae00b50f6fd66622f6e9c913253d6511d1e662"},
+		{"m/0H/1H/2H/2H/1000000000H",
+			"68789923a0cac2cd5a29172a475fe9e0fb14cd6adb5ad98a3fa70333e7afa230",
+			"8f94d394a8e8fd6b1bc2f3f49f5c47e385281d5c17e65324b0f62483e37e8793"},
+	},
+	P256: {
+		{"m",
+			"beeb672fe4621673f722f38529c07392fecaa61015c80c34f29ce8b41b3cb6ea",
+			"612091aaa12e22dd2abef664f8a01a82cae99ad7441b7ef8110424915c268bc2"},
+		{"m/0H",
+			"3460cea53e6a6bb5fb391eeef3237ffd8724bf0a40e94943c98b83825342ee11",
+			"6939694369114c67917a182c59ddb8cafc3004e63ca5d3b84403ba8613debc0c"},
+		{"m/0H/1",
+			"4187afff1aafa8445010097fb99d23aee9f599450c7bd140b6826ac22ba21d0c",
+			"284e9d38d07d21e4e281b645089a94f4cf5a5a81369acf151a1c3a57f18b2129"},
+		{"m/0H/1/2H",
+			"98c7514f562e64e74170cc3cf304ee1ce54d6b6da4f880f313e8204c2a185318",
+			"694596e8a54f252c960eb771a3c41e7e32496d03b954aeb90f61635b8e092aa7"},
+		{"m/0H/1/2H/2",
+			"ba96f776a5c3907d7fd48bde5620ee374d4acfd540378476019eab70790c63a0",
+			"5996c37fd3dd2679039b23ed6f70b506c6b56b3cb5e424681fb0fa64caf82aaa"},
+		{"m/0H/1/2H/2/1000000000",
+			"b9b7b82d326bb9cb5b5b121066feea4eb93d5241103c9e7a18aad40f1dde8059",
+			"21c4f269ef0a5fd1badf47eeacebeeaa3de22eb8e5b0adcd0f27dd99d34d0119"},
+	},
+}
+
+func TestVectors(t *testing.T) {
+	seed := mustDecodeHex(t, vectorSeed)
+	for _, curve := range []Curve{Secp256k1, Ed25519, P256} {
+		t.Run(curve.String(), func(t *testing.T) {
+			master, err := NewMaster(curve, seed)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, v := range vectors[curve] {
+				path, err := ParsePath(v.path)
+				if err != nil {
+					t.Fatal(err)
+				}
+				k, err := master.Derive(path)
+				if err != nil {
+					t.Fatalf("cannot derive %s: %v", v.path, err)
+				}
+				if !slices.Equal(k.Path, path) {
+					t.Errorf("%s: path is %v", v.path, k.Path)
+				}
+				if cc := hex.EncodeToString(k.ChainCode); cc != v.chainCode {
+					t.Errorf("%s: chain code is %s; expected %s",
+						v.path, cc, v.chainCode)
+				}
+				if key := hex.EncodeToString(k.Key); key != v.key {
+					t.Errorf("%s: key is %s; expected %s", v.path, key, v.key)
+				}
+			}
+		})
+	}
+}
+
+func TestPackage(t *testing.T) {
+	seed := mustDecodeHex(t, vectorSeed)
+	for _, curve := range []Curve{Secp256k1, Ed25519, P256} {
+		t.Run(curve.String(), func(t *testing.T) {
+			master, err := NewMaster(curve, seed)
+			if err != nil {
+				t.Fatal(err)
+			}
+			pkg, err := master.Pack(Provisional)
+			if err != nil {
+				t.Fatalf("cannot pack master key: %v", err)
+			}
+			// Encode the master key package, as it would be stored.
+			encoded, err := asn1.Marshal(*pkg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var masterPkg akp.OneAsymmetricKey
+			if _, err = asn1.Unmarshal(encoded, &masterPkg); err != nil {
+				t.Fatal(err)
+			}
+			master2, err := Unpack(&masterPkg)
+			if err != nil {
+				t.Fatalf("cannot unpack master key: %v", err)
+			}
+			if !reflect.DeepEqual(master2, master) {
+				t.Errorf("unpacked master key %+v is different from %+v",
+					master2, master)
+			}
+			last := vectors[curve][len(vectors[curve])-1]
+			path, err := ParsePath(last.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			childPkg, err := DeriveKeyPackage(&masterPkg, path)
+			if err != nil {
+				t.Fatalf("cannot derive key package: %v", err)
+			}
+			child, err := Unpack(childPkg)
+			if err != nil {
+				t.Fatalf("cannot unpack child key: %v", err)
+			}
+			if child.Curve != curve || !slices.Equal(child.Path, path) ||
+				hex.EncodeToString(child.ChainCode) != last.chainCode ||
+				hex.EncodeToString(child.Key) != last.key {
+				t.Errorf("child key is %+v; expected %s", child, last.path)
+			}
+			// The child key package is a plain key package too.
+			priv, _, _, err := akp.Unpack(childPkg)
+			if err != nil {
+				t.Fatalf("cannot unpack child key package: %v", err)
+			}
+			expected, err := child.PrivateKey()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !expected.(interface{ Equal(crypto.PrivateKey) bool }).Equal(
+				priv) {
+				t.Error("child key package holds a different key")
+			}
+		})
+	}
+}
+
+func TestDeriveRelative(t *testing.T) {
+	master, err := NewMaster(Secp256k1, mustDecodeHex(t, vectorSeed))
+	if err != nil {
+		t.Fatal(err)
+	}
+	account, err := master.Derive(Path{44 + Hardened, 60 + Hardened})
+	if err != nil {
+		t.Fatal(err)
+	}
+	accountPkg, err := account.Pack(Provisional)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkg, err := DeriveKeyPackage(accountPkg, Path{Hardened, 0, 7})
+	if err != nil {
+		t.Fatal(err)
+	}
+	path, err := ParsePath("m/44'/60'/0'/0/7")
+	if err != nil {
+		t.Fatal(err)
+	}
+	k, err := master.Derive(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected, err := k.Pack(Provisional)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(pkg, expected) {
+		t.Errorf("derived key package %+v is different from %+v",
+			pkg, expected)
+	}
+}
+
+func TestEd25519HardenedOnly(t *testing.T) {
+	master, err := NewMaster(Ed25519, mustDecodeHex(t, vectorSeed))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = master.Child(0); err != ErrHardenedOnly {
+		t.Errorf("Child returned %v; expected %v", err, ErrHardenedOnly)
+	}
+	// SLIP-10: the public key of the master key in test vector 1.
+	priv, err := master.PrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub := priv.(ed25519.PrivateKey).Public().(ed25519.PublicKey)
+	expected := mustDecodeHex(t, "a4b2856bfec510abab89753fac1ac0e1"+
+		"112364e7d250545963f135f2a33188ed")
+	if !bytes.Equal(pub, expected) {
+		t.Errorf("public key is %x; expected %x", pub, expected)
+	}
+}
+
+func TestParsePath(t *testing.T) {
+	for _, test := range []struct {
+		s        string
+		expected Path
+	}{
+		{"m", Path{}},
+		{"m/0", Path{0}},
+		{"m/44'/60h/0H/0/2147483647", Path{
+			44 + Hardened, 60 + Hardened, Hardened, 0, Hardened - 1,
+		}},
+	} {
+		path, err := ParsePath(test.s)
+		if err != nil {
+			t.Errorf("cannot parse %q: %v", test.s, err)
+			continue
+		}
+		if !reflect.DeepEqual(path, test.expected) {
+			t.Errorf("%q parsed as %v; expected %v", test.s, path, test.expected)
+		}
+	}
+	if s := (Path{44 + Hardened, 0}).String(); s != "m/44'/0" {
+		t.Errorf("path is formatted as %q", s)
+	}
+	for _, s := range []string{
+		"", "44'/0", "m/", "m//0", "m/'", "m/2147483648", "m/-1", "m/+1",
+		"m/0x10", "m/1''",
+	} {
+		if _, err := ParsePath(s); err == nil {
+			t.Errorf("ParsePath accepted %q", s)
+		}
+	}
+}
+
+func TestPackErrors(t *testing.T) {
+	master, err := NewMaster(Secp256k1, mustDecodeHex(t, vectorSeed))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = master.Pack(); err != ErrProvisional {
+		t.Errorf("Pack without the Provisional option returned %v; "+
+			"expected %v", err, ErrProvisional)
+	}
+	unknown := *master
+	unknown.Curve = Curve(42)
+	if _, err = unknown.Pack(Provisional); err == nil {
+		t.Error("Pack accepted an unknown curve")
+	}
+}
+
+func TestUnpack(t *testing.T) {
+	master, err := NewMaster(P256, mustDecodeHex(t, vectorSeed))
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkg, err := master.Pack(Provisional)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range []struct {
+		name   string
+		modify func(pkg *akp.OneAsymmetricKey)
+	}{
+		{"ShortChainCode", func(pkg *akp.OneAsymmetricKey) {
+			pkg.SetAttribute(OIDChainCode, asn1.RawValue{
+				Class: asn1.ClassUniversal, Tag: asn1.TagOctetString,
+				Bytes: master.ChainCode[1:],
+			})
+		}},
+		{"BadPath", func(pkg *akp.OneAsymmetricKey) {
+			path, _ := asn1.Marshal([]int64{-1})
+			pkg.SetAttribute(OIDDerivationPath, asn1.RawValue{FullBytes: path})
+		}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			pkg := *pkg
+			pkg.Attributes = append([]akp.Attribute(nil), pkg.Attributes...)
+			test.modify(&pkg)
+			if _, err = Unpack(&pkg); err == nil {
+				t.Error("Unpack succeeded")
+			}
+		})
+	}
+	t.Run("NoChainCode", func(t *testing.T) {
+		priv, err := secp256k1kp.GenerateKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		pkg, err := secp256k1kp.Pack(priv, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = Unpack(pkg); err != ErrNotHD {
+			t.Errorf("Unpack returned %v; expected %v", err, ErrNotHD)
+		}
+	})
+}
@@ -0,0 +1,230 @@
+package hdkey
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+	"github.com/harmony-one/asym-key-pkgs/pkg/algo/ec"
+	"github.com/harmony-one/asym-key-pkgs/pkg/algo/ed25519"
+	"github.com/harmony-one/asym-key-pkgs/pkg/algo/secp256k1"
+)
+
+// Attribute types
+//
+// There are no registered attribute types for extended keys,
+// and this module has no private enterprise number to register them under,
+// so these are provisional OIDs under the example arc 2.999 (ITU-T X.660),
+// which no other implementation recognizes and which may change.
+// Pack refuses to use them unless it is given the Provisional option.
+var (
+	// OIDChainCode is the attribute type of the chain code of an extended
+	// key, whose value is an OCTET STRING.
+	OIDChainCode = asn1.ObjectIdentifier{2, 999, 32, 1}
+
+	// OIDDerivationPath is the attribute type of the derivation path of an
+	// extended key from its master key, whose value is a SEQUENCE OF INTEGER
+	// with the child indices, hardened ones including the Hardened offset.
+	OIDDerivationPath = asn1.ObjectIdentifier{2, 999, 32, 2}
+)
+
+// ErrNotHD means the key package does not hold an extended key.
+var ErrNotHD = errors.New("not an extended key package")
+
+// provisional is the type of the Provisional option.
+type provisional struct{}
+
+// Provisional is the Pack option that accepts the provisional attribute
+// types of extended keys.
+var Provisional provisional
+
+// ErrProvisional means an extended key was packed without the Provisional
+// option.
+var ErrProvisional = errors.New(
+	"extended key attribute types are provisional; " +
+		"pack with the Provisional option")
+
+// Pack packs the extended key into a key package,
+// with its chain code and derivation path in the attributes.
+//
+// As the attribute types are provisional, it fails with ErrProvisional
+// unless the Provisional option is given.
+func (k *ExtendedKey) Pack(options ...interface{}) (
+	*akp.OneAsymmetricKey, error,
+) {
+	allowed := false
+	for _, option := range options {
+		if _, ok := option.(provisional); ok {
+			allowed = true
+		}
+	}
+	if !allowed {
+		return nil, ErrProvisional
+	}
+	if len(k.ChainCode) != KeySize {
+		return nil, errors.New("chain code has a wrong size")
+	}
+	if len(k.Path) > MaxDepth {
+		return nil, fmt.Errorf("derivation path is deeper than %d", MaxDepth)
+	}
+	priv, err := k.PrivateKey()
+	if err != nil {
+		return nil, err
+	}
+	var pkg *akp.OneAsymmetricKey
+	switch priv := priv.(type) {
+	case ed25519.PrivateKey:
+		pkg, err = ed25519kp.Pack(priv, nil)
+	case *ecdsa.PrivateKey:
+		if k.Curve == Secp256k1 {
+			pkg, err = secp256k1kp.Pack(priv, nil)
+		} else {
+			pkg, err = eckp.Pack(priv, nil)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported curve %v", k.Curve)
+	}
+	if err != nil {
+		return nil, err
+	}
+	pkg.SetAttribute(OIDChainCode, asn1.RawValue{
+		Class: asn1.ClassUniversal, Tag: asn1.TagOctetString,
+		Bytes: k.ChainCode,
+	})
+	indices := make([]int64, len(k.Path))
+	for i, index := range k.Path {
+		indices[i] = int64(index)
+	}
+	path, err := asn1.Marshal(indices)
+	if err != nil {
+		return nil, err
+	}
+	pkg.SetAttribute(OIDDerivationPath, asn1.RawValue{FullBytes: path})
+	return pkg, nil
+}
+
+// Unpack unpacks an extended key from a key package.
+//
+// The key package must have a chain code attribute.
+// A key package without a derivation path attribute holds a master key.
+func Unpack(pkg *akp.OneAsymmetricKey) (*ExtendedKey, error) {
+	chainCode, err := chainCodeOf(pkg)
+	if err != nil {
+		return nil, err
+	}
+	path, err := pathOf(pkg)
+	if err != nil {
+		return nil, err
+	}
+	k := &ExtendedKey{ChainCode: chainCode, Path: path}
+	edKey, _, _, err := ed25519kp.Unpack(pkg)
+	if err != ed25519kp.ErrNotEd25519 {
+		if err != nil {
+			return nil, err
+		}
+		k.Curve, k.Key = Ed25519, edKey.Seed()
+		return k, nil
+	}
+	priv, _, _, err := secp256k1kp.Unpack(pkg)
+	k.Curve = Secp256k1
+	if err == secp256k1kp.ErrNotSecp256k1 {
+		priv, _, _, err = eckp.Unpack(pkg)
+		k.Curve = P256
+		if err == eckp.ErrNotEC {
+			return nil, ErrNotHD
+		}
+		if err == nil && priv.Curve != elliptic.P256() {
+			return nil, fmt.Errorf("extended keys on %s are not supported",
+				priv.Curve.Params().Name)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	k.Key = priv.D.FillBytes(make([]byte, KeySize))
+	return k, nil
+}
+
+func chainCodeOf(pkg *akp.OneAsymmetricKey) ([]byte, error) {
+	attr := pkg.Attribute(OIDChainCode)
+	if attr == nil {
+		return nil, ErrNotHD
+	}
+	if len(attr.Values) != 1 {
+		return nil, errors.New("chain code attribute has multiple values")
+	}
+	var chainCode []byte
+	der, err := asn1.Marshal(attr.Values[0])
+	if err != nil {
+		return nil, err
+	}
+	rest, err := asn1.Unmarshal(der, &chainCode)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse chain code: %w", err)
+	} else if len(rest) > 0 {
+		return nil, errors.New("trailing data after chain code")
+	}
+	if len(chainCode) != KeySize {
+		return nil, fmt.Errorf("chain code is %d bytes; expected %d",
+			len(chainCode), KeySize)
+	}
+	return chainCode, nil
+}
+
+func pathOf(pkg *akp.OneAsymmetricKey) (Path, error) {
+	attr := pkg.Attribute(OIDDerivationPath)
+	if attr == nil {
+		return nil, nil
+	}
+	if len(attr.Values) != 1 {
+		return nil, errors.New("derivation path attribute has multiple values")
+	}
+	var indices []int64
+	der, err := asn1.Marshal(attr.Values[0])
+	if err != nil {
+		return nil, err
+	}
+	rest, err := asn1.Unmarshal(der, &indices)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse derivation path: %w", err)
+	} else if len(rest) > 0 {
+		return nil, errors.New("trailing data after derivation path")
+	}
+	if len(indices) > MaxDepth {
+		return nil, fmt.Errorf("derivation path is deeper than %d", MaxDepth)
+	}
+	var path Path
+	for _, index := range indices {
+		if index < 0 || index > math.MaxUint32 {
+			return nil, fmt.Errorf("bad index %d in derivation path", index)
+		}
+		path = append(path, uint32(index))
+	}
+	return path, nil
+}
+
+// DeriveKeyPackage derives the key package of the descendant key at the
+// given path below the extended key in the key package.
+//
+// The derivation path attribute of the derived key package is the full path
+// from the master key, i.e. the path of the given key package followed by
+// the given path.
+// As the given key package already has the provisional attribute types,
+// so has the derived one: the Provisional option is implied.
+func DeriveKeyPackage(
+	pkg *akp.OneAsymmetricKey, path Path,
+) (*akp.OneAsymmetricKey, error) {
+	k, err := Unpack(pkg)
+	if err != nil {
+		return nil, err
+	}
+	if k, err = k.Derive(path); err != nil {
+		return nil, err
+	}
+	return k.Pack(Provisional)
+}
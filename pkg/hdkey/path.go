@@ -0,0 +1,52 @@
+package hdkey
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Hardened is the offset of the indices of hardened children.
+const Hardened uint32 = 1 << 31
+
+// Path is a derivation path: the indices of the children from a key to a
+// descendant.
+type Path []uint32
+
+// ParsePath parses a derivation path in the notation of BIP-32,
+// e.g. "m/44'/60'/0'/0/0", where a hardened index is marked by ' or h or H.
+func ParsePath(s string) (Path, error) {
+	elems := strings.Split(s, "/")
+	if elems[0] != "m" {
+		return nil, errors.New(`derivation path does not start with "m"`)
+	}
+	path := make(Path, 0, len(elems)-1)
+	for _, elem := range elems[1:] {
+		offset := uint32(0)
+		if n := len(elem); n > 0 && strings.IndexByte("'hH", elem[n-1]) >= 0 {
+			elem, offset = elem[:n-1], Hardened
+		}
+		index, err := strconv.ParseUint(elem, 10, 31)
+		if err != nil {
+			return nil, fmt.Errorf("bad index %q in derivation path", elem)
+		}
+		path = append(path, uint32(index)+offset)
+	}
+	return path, nil
+}
+
+// String returns the path in the notation of BIP-32,
+// with hardened indices marked by '.
+func (path Path) String() string {
+	var b strings.Builder
+	b.WriteString("m")
+	for _, index := range path {
+		b.WriteByte('/')
+		b.WriteString(strconv.FormatUint(uint64(index&^Hardened), 10))
+		if index >= Hardened {
+			b.WriteByte('\'')
+		}
+	}
+	return b.String()
+}
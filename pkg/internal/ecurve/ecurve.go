@@ -0,0 +1,245 @@
+// Package ecurve implements short Weierstrass elliptic curves
+// y² = x³ + ax + b over prime fields, for any a.
+//
+// The generic implementation in crypto/elliptic assumes a = -3,
+// which excludes curves such as secp256k1 (a = 0) and most Brainpool curves.
+//
+// ScalarMult and ScalarBaseMult, which take secret scalars when deriving
+// public keys and signing, run in constant time: they use the Montgomery
+// arithmetic of filippo.io/bigmod, complete addition formulas and a fixed
+// window. The other methods use math/big and Jacobian coordinates and are
+// not constant-time; they only take public points.
+package ecurve
+
+import (
+	"crypto/elliptic"
+	"io"
+	"math/big"
+)
+
+// Curve is a short Weierstrass curve, which implements elliptic.Curve.
+//
+// As in crypto/elliptic, the point at infinity is represented as (0, 0).
+type Curve struct {
+	params *elliptic.CurveParams
+	a      *big.Int
+	field  *field
+}
+
+// New returns a curve with the given parameters and coefficient a.
+//
+// The parameters are returned by Params,
+// but the receiver does not use their methods.
+func New(params *elliptic.CurveParams, a *big.Int) *Curve {
+	a = new(big.Int).Mod(a, params.P)
+	return &Curve{params: params, a: a, field: newField(params, a)}
+}
+
+// Params returns the parameters of the curve.
+func (curve *Curve) Params() *elliptic.CurveParams {
+	return curve.params
+}
+
+// A returns the coefficient a of the curve, reduced modulo P.
+func (curve *Curve) A() *big.Int {
+	return new(big.Int).Set(curve.a)
+}
+
+// rhs returns x³ + ax + b.
+func (curve *Curve) rhs(x *big.Int) *big.Int {
+	p := curve.params.P
+	y2 := new(big.Int).Mul(x, x)
+	y2.Add(y2, curve.a)
+	y2.Mul(y2, x)
+	y2.Add(y2, curve.params.B)
+	return y2.Mod(y2, p)
+}
+
+// IsOnCurve reports whether the given (x, y) lies on the curve.
+func (curve *Curve) IsOnCurve(x, y *big.Int) bool {
+	p := curve.params.P
+	if x.Sign() < 0 || x.Cmp(p) >= 0 || y.Sign() < 0 || y.Cmp(p) >= 0 {
+		return false
+	}
+	y2 := new(big.Int).Mul(y, y)
+	return y2.Mod(y2, p).Cmp(curve.rhs(x)) == 0
+}
+
+// jacobian is a point (X/Z², Y/Z³); Z = 0 at infinity.
+type jacobian struct {
+	x, y, z *big.Int
+}
+
+func (curve *Curve) toJacobian(x, y *big.Int) jacobian {
+	z := new(big.Int)
+	if x.Sign() != 0 || y.Sign() != 0 {
+		z.SetInt64(1)
+	}
+	return jacobian{new(big.Int).Set(x), new(big.Int).Set(y), z}
+}
+
+func (curve *Curve) toAffine(pt jacobian) (x, y *big.Int) {
+	if pt.z.Sign() == 0 {
+		return new(big.Int), new(big.Int)
+	}
+	p := curve.params.P
+	zInv := new(big.Int).ModInverse(pt.z, p)
+	zInv2 := new(big.Int).Mul(zInv, zInv)
+	x = new(big.Int).Mul(pt.x, zInv2)
+	x.Mod(x, p)
+	zInv2.Mul(zInv2, zInv)
+	y = new(big.Int).Mul(pt.y, zInv2)
+	y.Mod(y, p)
+	return x, y
+}
+
+func (curve *Curve) double(pt jacobian) jacobian {
+	p := curve.params.P
+	if pt.z.Sign() == 0 || pt.y.Sign() == 0 {
+		return jacobian{new(big.Int), new(big.Int), new(big.Int)}
+	}
+	xx := new(big.Int).Mul(pt.x, pt.x)
+	yy := new(big.Int).Mul(pt.y, pt.y)
+	yy.Mod(yy, p)
+	zz := new(big.Int).Mul(pt.z, pt.z)
+	zz.Mod(zz, p)
+
+	// s = 4xy²
+	s := new(big.Int).Mul(pt.x, yy)
+	s.Lsh(s, 2)
+	s.Mod(s, p)
+
+	// m = 3x² + az⁴
+	m := new(big.Int).Mul(zz, zz)
+	m.Mul(m, curve.a)
+	m.Add(m, xx)
+	m.Add(m, xx)
+	m.Add(m, xx)
+	m.Mod(m, p)
+
+	// x' = m² - 2s
+	x := new(big.Int).Mul(m, m)
+	x.Sub(x, s)
+	x.Sub(x, s)
+	x.Mod(x, p)
+
+	// y' = m(s - x') - 8y⁴
+	y := new(big.Int).Sub(s, x)
+	y.Mul(y, m)
+	yy.Mul(yy, yy)
+	yy.Lsh(yy, 3)
+	y.Sub(y, yy)
+	y.Mod(y, p)
+
+	// z' = 2yz
+	z := new(big.Int).Mul(pt.y, pt.z)
+	z.Lsh(z, 1)
+	z.Mod(z, p)
+	return jacobian{x, y, z}
+}
+
+func (curve *Curve) add(pt1, pt2 jacobian) jacobian {
+	p := curve.params.P
+	if pt1.z.Sign() == 0 {
+		return pt2
+	}
+	if pt2.z.Sign() == 0 {
+		return pt1
+	}
+	z1z1 := new(big.Int).Mul(pt1.z, pt1.z)
+	z1z1.Mod(z1z1, p)
+	z2z2 := new(big.Int).Mul(pt2.z, pt2.z)
+	z2z2.Mod(z2z2, p)
+
+	u1 := new(big.Int).Mul(pt1.x, z2z2)
+	u1.Mod(u1, p)
+	u2 := new(big.Int).Mul(pt2.x, z1z1)
+	u2.Mod(u2, p)
+	s1 := new(big.Int).Mul(pt1.y, pt2.z)
+	s1.Mul(s1, z2z2)
+	s1.Mod(s1, p)
+	s2 := new(big.Int).Mul(pt2.y, pt1.z)
+	s2.Mul(s2, z1z1)
+	s2.Mod(s2, p)
+
+	if u1.Cmp(u2) == 0 {
+		if s1.Cmp(s2) == 0 {
+			return curve.double(pt1)
+		}
+		return jacobian{new(big.Int), new(big.Int), new(big.Int)}
+	}
+
+	h := new(big.Int).Sub(u2, u1)
+	h.Mod(h, p)
+	r := new(big.Int).Sub(s2, s1)
+	r.Mod(r, p)
+	hh := new(big.Int).Mul(h, h)
+	hh.Mod(hh, p)
+	hhh := new(big.Int).Mul(hh, h)
+	hhh.Mod(hhh, p)
+	v := new(big.Int).Mul(u1, hh)
+	v.Mod(v, p)
+
+	// x' = r² - h³ - 2u₁h²
+	x := new(big.Int).Mul(r, r)
+	x.Sub(x, hhh)
+	x.Sub(x, v)
+	x.Sub(x, v)
+	x.Mod(x, p)
+
+	// y' = r(u₁h² - x') - s₁h³
+	y := new(big.Int).Sub(v, x)
+	y.Mul(y, r)
+	s1.Mul(s1, hhh)
+	y.Sub(y, s1)
+	y.Mod(y, p)
+
+	// z' = hz₁z₂
+	z := new(big.Int).Mul(h, pt1.z)
+	z.Mul(z, pt2.z)
+	z.Mod(z, p)
+	return jacobian{x, y, z}
+}
+
+// Add returns the sum of (x1, y1) and (x2, y2).
+func (curve *Curve) Add(x1, y1, x2, y2 *big.Int) (x, y *big.Int) {
+	return curve.toAffine(curve.add(
+		curve.toJacobian(x1, y1), curve.toJacobian(x2, y2)))
+}
+
+// Double returns 2×(x1, y1).
+func (curve *Curve) Double(x1, y1 *big.Int) (x, y *big.Int) {
+	return curve.toAffine(curve.double(curve.toJacobian(x1, y1)))
+}
+
+// ScalarMult returns k×(x1, y1), where k is a big-endian integer.
+//
+// Its timing does not depend on the value of k.
+func (curve *Curve) ScalarMult(x1, y1 *big.Int, k []byte) (x, y *big.Int) {
+	return curve.scalarMult(x1, y1, k)
+}
+
+// ScalarBaseMult returns k×G, where G is the base point of the curve and k is
+// a big-endian integer.
+//
+// Its timing does not depend on the value of k.
+func (curve *Curve) ScalarBaseMult(k []byte) (x, y *big.Int) {
+	return curve.scalarMult(curve.params.Gx, curve.params.Gy, k)
+}
+
+// RandScalar returns a uniformly random integer in [1, n - 1],
+// read from rand by rejection sampling.
+func RandScalar(rand io.Reader, n *big.Int) (*big.Int, error) {
+	b := make([]byte, (n.BitLen()+7)/8)
+	excess := uint(8*len(b) - n.BitLen())
+	k := new(big.Int)
+	for {
+		if _, err := io.ReadFull(rand, b); err != nil {
+			return nil, err
+		}
+		b[0] &= 0xff >> excess
+		if k.SetBytes(b); k.Sign() > 0 && k.Cmp(n) < 0 {
+			return k, nil
+		}
+	}
+}
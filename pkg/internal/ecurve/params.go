@@ -0,0 +1,136 @@
+package ecurve
+
+import (
+	"crypto/elliptic"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// specifiedCurve is the SpecifiedECDomain structure of SEC 1,
+// section C.2, for prime fields.
+type specifiedCurve struct {
+	Version  int
+	FieldID  fieldID
+	Curve    curveCoefficients
+	Base     []byte
+	Order    *big.Int
+	Cofactor *big.Int `asn1:"optional"`
+}
+
+type fieldID struct {
+	FieldType  asn1.ObjectIdentifier
+	Parameters asn1.RawValue
+}
+
+type curveCoefficients struct {
+	A, B []byte
+	Seed asn1.BitString `asn1:"optional"`
+}
+
+// specifiedCurveVersion is ecdpVer1 in SEC 1.
+const specifiedCurveVersion = 1
+
+// prime-field in ANSI X9.62
+var primeFieldOID = asn1.ObjectIdentifier{1, 2, 840, 10045, 1, 1}
+
+type namedCurve struct {
+	oid   asn1.ObjectIdentifier
+	curve elliptic.Curve
+}
+
+// namedCurves are the registered curves, which are only appended to from
+// init functions.
+var namedCurves []namedCurve
+
+// Register registers a named curve,
+// so that ParseParameters recognizes its explicit parameters.
+//
+// It must be called from an init function.
+func Register(oid asn1.ObjectIdentifier, curve elliptic.Curve) {
+	namedCurves = append(namedCurves, namedCurve{oid, curve})
+}
+
+// ErrUnknownCurve means explicit curve parameters do not match any known
+// named curve.
+var ErrUnknownCurve = errors.New("unknown elliptic curve")
+
+// ParseParameters parses the ECParameters of an id-ecPublicKey key
+// (RFC 5480, section 2.1.1) or of an ECPrivateKey (RFC 5915),
+// and returns the OID of the curve.
+//
+// A namedCurve OID is returned as is, whether registered or not.
+// A specifiedCurve (SEC 1, section C.2) must have exactly the prime,
+// coefficients, base point and order of a registered curve,
+// whose OID is returned; otherwise ParseParameters returns ErrUnknownCurve.
+// The seed is ignored, and the cofactor must be 1 if present,
+// as it is for all registered curves.
+// implicitCurve is not supported.
+func ParseParameters(der []byte) (asn1.ObjectIdentifier, error) {
+	var raw asn1.RawValue
+	rest, err := asn1.Unmarshal(der, &raw)
+	if err != nil {
+		return nil, fmt.Errorf("cannot unmarshal EC parameters: %v", err)
+	}
+	if len(rest) > 0 {
+		return nil, errors.New("extra data after EC parameters")
+	}
+	switch {
+	case raw.Class == asn1.ClassUniversal && raw.Tag == asn1.TagOID:
+		var oid asn1.ObjectIdentifier
+		if _, err = asn1.Unmarshal(der, &oid); err != nil {
+			return nil, fmt.Errorf("cannot unmarshal curve OID: %v", err)
+		}
+		return oid, nil
+	case raw.Class == asn1.ClassUniversal && raw.Tag == asn1.TagSequence:
+		var params specifiedCurve
+		if _, err = asn1.Unmarshal(der, &params); err != nil {
+			return nil, fmt.Errorf(
+				"cannot unmarshal explicit EC parameters: %v", err)
+		}
+		return matchCurve(&params)
+	case raw.Class == asn1.ClassUniversal && raw.Tag == asn1.TagNull:
+		return nil, errors.New("implicit EC parameters are not supported")
+	}
+	return nil, errors.New("malformed EC parameters")
+}
+
+// matchCurve returns the OID of the registered curve with the given
+// parameters.
+func matchCurve(params *specifiedCurve) (asn1.ObjectIdentifier, error) {
+	if params.Version != specifiedCurveVersion {
+		return nil, fmt.Errorf(
+			"unsupported explicit EC parameters version %d", params.Version)
+	}
+	if !params.FieldID.FieldType.Equal(primeFieldOID) {
+		return nil, errors.New("EC parameters are not over a prime field")
+	}
+	var p *big.Int
+	rest, err := asn1.Unmarshal(params.FieldID.Parameters.FullBytes, &p)
+	if err != nil || len(rest) > 0 {
+		return nil, errors.New("malformed prime of EC parameters")
+	}
+	if params.Cofactor != nil && params.Cofactor.Cmp(big.NewInt(1)) != 0 {
+		return nil, ErrUnknownCurve
+	}
+	a := new(big.Int).SetBytes(params.Curve.A)
+	b := new(big.Int).SetBytes(params.Curve.B)
+	for _, named := range namedCurves {
+		known := named.curve.Params()
+		if known.P.Cmp(p) != 0 || known.B.Cmp(b) != 0 ||
+			known.N.Cmp(params.Order) != 0 {
+			continue
+		}
+		knownA := coefficientA(named.curve)
+		if new(big.Int).Mod(knownA, p).Cmp(a) != 0 {
+			continue
+		}
+		x, y, _, err := UnmarshalPoint(named.curve, params.Base)
+		if err != nil || x.Cmp(known.Gx) != 0 || y.Cmp(known.Gy) != 0 {
+			continue
+		}
+		return named.oid, nil
+	}
+	return nil, ErrUnknownCurve
+}
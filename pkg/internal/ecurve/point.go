@@ -0,0 +1,116 @@
+package ecurve
+
+import (
+	"crypto/elliptic"
+	"encoding/asn1"
+	"errors"
+	"math/big"
+)
+
+// PointFormat is the encoding of elliptic curve points (SEC 1, section
+// 2.3.3).
+type PointFormat int
+
+// Point formats
+const (
+	Uncompressed PointFormat = iota // 04 || X || Y
+	Compressed                      // 02 or 03 || X
+)
+
+// ECPrivateKey is the RFC 5915 ECPrivateKey structure.
+type ECPrivateKey struct {
+	Version    int
+	PrivateKey []byte
+	Parameters asn1.RawValue  `asn1:"optional,explicit,tag:0"`
+	PublicKey  asn1.BitString `asn1:"optional,explicit,tag:1"`
+}
+
+// ECPrivateKeyVersion is the version of ECPrivateKey structures.
+const ECPrivateKeyVersion = 1
+
+// coefficientA returns the coefficient a of the given curve:
+// the curves of crypto/elliptic all have a = -3.
+func coefficientA(curve elliptic.Curve) *big.Int {
+	if c, ok := curve.(interface{ A() *big.Int }); ok {
+		return c.A()
+	}
+	return big.NewInt(-3)
+}
+
+func byteLen(curve elliptic.Curve) int {
+	return (curve.Params().P.BitLen() + 7) / 8
+}
+
+// MarshalPoint encodes the point (x, y) of the given curve in the given
+// format.
+func MarshalPoint(
+	curve elliptic.Curve, x, y *big.Int, format PointFormat,
+) []byte {
+	n := byteLen(curve)
+	if format == Compressed {
+		b := make([]byte, 1+n)
+		b[0] = byte(2 + y.Bit(0))
+		x.FillBytes(b[1:])
+		return b
+	}
+	b := make([]byte, 1+2*n)
+	b[0] = 4
+	x.FillBytes(b[1 : 1+n])
+	y.FillBytes(b[1+n:])
+	return b
+}
+
+// UnmarshalPoint decodes a point of the given curve,
+// in either format.
+//
+// It fails if the point is not on the curve,
+// or if it is the point at infinity.
+func UnmarshalPoint(curve elliptic.Curve, data []byte) (
+	x, y *big.Int, format PointFormat, err error,
+) {
+	n := byteLen(curve)
+	p := curve.Params().P
+	switch {
+	case len(data) == 1+2*n && data[0] == 4:
+		x = new(big.Int).SetBytes(data[1 : 1+n])
+		y = new(big.Int).SetBytes(data[1+n:])
+		format = Uncompressed
+	case len(data) == 1+n && (data[0] == 2 || data[0] == 3):
+		x = new(big.Int).SetBytes(data[1:])
+		if x.Cmp(p) >= 0 {
+			return nil, nil, 0, errors.New("point not on curve")
+		}
+		// y² = x³ + ax + b
+		y2 := new(big.Int).Mul(x, x)
+		y2.Add(y2, coefficientA(curve))
+		y2.Mul(y2, x)
+		y2.Add(y2, curve.Params().B)
+		y2.Mod(y2, p)
+		if y = new(big.Int).ModSqrt(y2, p); y == nil {
+			return nil, nil, 0, errors.New("point not on curve")
+		}
+		if y.Bit(0) != uint(data[0]&1) {
+			y.Sub(p, y)
+		}
+		format = Compressed
+	default:
+		return nil, nil, 0, errors.New("malformed point")
+	}
+	if !curve.IsOnCurve(x, y) {
+		return nil, nil, 0, errors.New("point not on curve")
+	}
+	if x.Sign() == 0 && y.Sign() == 0 {
+		return nil, nil, 0, errors.New("point at infinity")
+	}
+	return x, y, format, nil
+}
+
+// CompressPoint converts an uncompressed point encoding,
+// as returned by MarshalPoint or crypto/ecdsa, to the compressed one.
+func CompressPoint(point []byte) []byte {
+	n := (len(point) - 1) / 2
+	b := make([]byte, 1+n)
+	b[0] = 2 + point[len(point)-1]&1
+	copy(b[1:], point[1:1+n])
+	return b
+}
@@ -0,0 +1,163 @@
+package ecurve
+
+import (
+	"crypto/elliptic"
+	"math/big"
+
+	"filippo.io/bigmod"
+)
+
+// field is the constant-time arithmetic modulo P of scalar multiplication,
+// with the curve coefficients a and 3b.
+type field struct {
+	p     *big.Int
+	m     *bigmod.Modulus
+	a, b3 *bigmod.Nat
+}
+
+func newField(params *elliptic.CurveParams, a *big.Int) *field {
+	m, err := bigmod.NewModulus(params.P.Bytes())
+	if err != nil {
+		panic("ecurve: bad field modulus: " + err.Error())
+	}
+	f := &field{p: params.P, m: m}
+	f.a = f.element(a)
+	f.b3 = f.element(new(big.Int).Mul(params.B, big.NewInt(3)))
+	return f
+}
+
+// element returns x modulo P.
+func (f *field) element(x *big.Int) *bigmod.Nat {
+	b := new(big.Int).Mod(x, f.p).FillBytes(make([]byte, f.m.Size()))
+	e, err := bigmod.NewNat().SetBytes(b, f.m)
+	if err != nil {
+		panic("ecurve: internal error: " + err.Error())
+	}
+	return e
+}
+
+func (f *field) zero() *bigmod.Nat {
+	return bigmod.NewNat().ExpandFor(f.m)
+}
+
+func (f *field) one() *bigmod.Nat {
+	e := f.zero()
+	e.Bits()[0] = 1
+	return e
+}
+
+func (f *field) copy(x *bigmod.Nat) *bigmod.Nat {
+	e := f.zero()
+	copy(e.Bits(), x.Bits())
+	return e
+}
+
+func (f *field) add(x, y *bigmod.Nat) *bigmod.Nat {
+	return f.copy(x).Add(y, f.m)
+}
+
+func (f *field) sub(x, y *bigmod.Nat) *bigmod.Nat {
+	return f.copy(x).Sub(y, f.m)
+}
+
+func (f *field) mul(x, y *bigmod.Nat) *bigmod.Nat {
+	return f.copy(x).Mul(y, f.m)
+}
+
+// projective is a point (X/Z, Y/Z); the point at infinity is (0, 1, 0).
+type projective struct {
+	x, y, z *bigmod.Nat
+}
+
+// addComplete returns p1 + p2 by the complete addition formulas of
+// Renes, Costello and Batina, "Complete addition formulas for prime order
+// elliptic curves", algorithm 1.
+//
+// The formulas have no exceptional cases when the curve has no point of
+// order two, or when both points lie in a subgroup of odd order,
+// so they also double and take the point at infinity without branching.
+func (f *field) addComplete(p1, p2 projective) projective {
+	t0 := f.mul(p1.x, p2.x)
+	t1 := f.mul(p1.y, p2.y)
+	t2 := f.mul(p1.z, p2.z)
+	t3 := f.mul(f.add(p1.x, p1.y), f.add(p2.x, p2.y))
+	t3 = f.sub(t3, f.add(t0, t1))
+	t4 := f.mul(f.add(p1.x, p1.z), f.add(p2.x, p2.z))
+	t4 = f.sub(t4, f.add(t0, t2))
+	t5 := f.mul(f.add(p1.y, p1.z), f.add(p2.y, p2.z))
+	t5 = f.sub(t5, f.add(t1, t2))
+	z3 := f.add(f.mul(f.a, t4), f.mul(f.b3, t2))
+	x3 := f.sub(t1, z3)
+	z3 = f.add(t1, z3)
+	y3 := f.mul(x3, z3)
+	t1 = f.add(f.add(t0, t0), t0)
+	t2 = f.mul(f.a, t2)
+	t4 = f.mul(f.b3, t4)
+	t1 = f.add(t1, t2)
+	t2 = f.mul(f.a, f.sub(t0, t2))
+	t4 = f.add(t4, t2)
+	y3 = f.add(y3, f.mul(t1, t4))
+	x3 = f.sub(f.mul(x3, t3), f.mul(t5, t4))
+	z3 = f.add(f.mul(z3, t5), f.mul(t3, t1))
+	return projective{x3, y3, z3}
+}
+
+// selectPoint sets out to table[i] without leaking i through timing.
+func (f *field) selectPoint(out *projective, table []projective, i uint8) {
+	*out = projective{f.zero(), f.zero(), f.zero()}
+	for j := range table {
+		// mask is all ones if j == i and zero otherwise.
+		mask := -uint((uint32(uint8(j)^i) - 1) >> 31)
+		for _, c := range [][2]*bigmod.Nat{
+			{out.x, table[j].x}, {out.y, table[j].y}, {out.z, table[j].z},
+		} {
+			dst, src := c[0].Bits(), c[1].Bits()
+			for k := range dst {
+				dst[k] |= src[k] & mask
+			}
+		}
+	}
+}
+
+// scalarMult returns k×(x1, y1), with a fixed window of four bits, complete
+// formulas and constant-time table lookups, so that its timing only depends
+// on the length of k, padded to that of the order of the curve.
+func (curve *Curve) scalarMult(x1, y1 *big.Int, k []byte) (x, y *big.Int) {
+	f := curve.field
+	if n := (curve.params.N.BitLen() + 7) / 8; len(k) < n {
+		k = append(make([]byte, n-len(k)), k...)
+	}
+
+	var table [16]projective
+	table[0] = projective{f.zero(), f.one(), f.zero()}
+	if x1.Sign() == 0 && y1.Sign() == 0 {
+		table[1] = table[0]
+	} else {
+		table[1] = projective{f.element(x1), f.element(y1), f.one()}
+	}
+	for i := 2; i < len(table); i++ {
+		table[i] = f.addComplete(table[i-1], table[1])
+	}
+
+	acc := table[0]
+	var pt projective
+	for _, b := range k {
+		for _, w := range [2]uint8{b >> 4, b & 0xf} {
+			for i := 0; i < 4; i++ {
+				acc = f.addComplete(acc, acc)
+			}
+			f.selectPoint(&pt, table[:], w)
+			acc = f.addComplete(acc, pt)
+		}
+	}
+
+	if acc.z.IsZero() == 1 {
+		return new(big.Int), new(big.Int)
+	}
+	// z⁻¹ = z^(P - 2), which is constant-time unlike ModInverse.
+	e := new(big.Int).Sub(curve.params.P, big.NewInt(2)).Bytes()
+	zInv := f.zero().Exp(acc.z, e, f.m)
+	x = new(big.Int).SetBytes(f.mul(acc.x, zInv).Bytes(f.m))
+	y = new(big.Int).SetBytes(f.mul(acc.y, zInv).Bytes(f.m))
+	return x, y
+}
@@ -0,0 +1,67 @@
+// Package jwkfield encodes and decodes JSON Web Key member values
+// (RFC 7518, section 2, "Base64urlUInt").
+package jwkfield
+
+import (
+	"encoding/base64"
+	"fmt"
+	"math/big"
+)
+
+// Bytes encodes an octet sequence in base64url without padding.
+func Bytes(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// Int encodes a non-negative integer as a Base64urlUInt.
+func Int(n *big.Int) string {
+	b := n.Bytes()
+	if len(b) == 0 {
+		b = []byte{0}
+	}
+	return Bytes(b)
+}
+
+// FixedInt encodes a non-negative integer as a base64url-encoded big-endian
+// octet sequence of the given length, as used for EC coordinates.
+func FixedInt(n *big.Int, size int) string {
+	return Bytes(n.FillBytes(make([]byte, size)))
+}
+
+// GetString returns the string member of the given name.
+func GetString(members map[string]interface{}, name string) (string, error) {
+	v, ok := members[name]
+	if !ok {
+		return "", fmt.Errorf("JWK member %q is missing", name)
+	}
+	s, ok := v.(string)
+	if !ok {
+		return "", fmt.Errorf("JWK member %q is not a string", name)
+	}
+	return s, nil
+}
+
+// GetBytes returns the base64url-decoded member of the given name.
+func GetBytes(members map[string]interface{}, name string) ([]byte, error) {
+	s, err := GetString(members, name)
+	if err != nil {
+		return nil, err
+	}
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("JWK member %q: %v", name, err)
+	}
+	return b, nil
+}
+
+// GetInt returns the Base64urlUInt member of the given name.
+func GetInt(members map[string]interface{}, name string) (*big.Int, error) {
+	b, err := GetBytes(members, name)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) == 0 {
+		return nil, fmt.Errorf("JWK member %q is empty", name)
+	}
+	return new(big.Int).SetBytes(b), nil
+}
@@ -0,0 +1,91 @@
+// Package pqkey implements the private key CHOICE shared by the IETF LAMPS
+// ML-DSA and ML-KEM key formats (draft-ietf-lamps-dilithium-certificates
+// and draft-ietf-lamps-kyber-certificates):
+//
+//	PrivateKey ::= CHOICE {
+//	  seed [0] IMPLICIT OCTET STRING,
+//	  expandedKey OCTET STRING,
+//	  both SEQUENCE {
+//	    seed OCTET STRING,
+//	    expandedKey OCTET STRING } }
+package pqkey
+
+import (
+	"encoding/asn1"
+	"errors"
+	"fmt"
+)
+
+// Format is the CHOICE alternative of a private key.
+type Format int
+
+// Private key formats
+const (
+	SeedOnly     Format = iota + 1 // seed
+	ExpandedOnly                   // expandedKey
+	Both                           // both
+)
+
+func (format Format) String() string {
+	switch format {
+	case SeedOnly:
+		return "seed"
+	case ExpandedOnly:
+		return "expandedKey"
+	case Both:
+		return "both"
+	}
+	return fmt.Sprintf("Format(%d)", int(format))
+}
+
+type both struct {
+	Seed        []byte
+	ExpandedKey []byte
+}
+
+// Marshal encodes a private key in the given format.
+// The seed is ignored for ExpandedOnly, and the expanded key for SeedOnly.
+func Marshal(format Format, seed, expanded []byte) ([]byte, error) {
+	switch format {
+	case SeedOnly:
+		return asn1.MarshalWithParams(seed, "tag:0")
+	case ExpandedOnly:
+		return asn1.Marshal(expanded)
+	case Both:
+		return asn1.Marshal(both{seed, expanded})
+	}
+	return nil, fmt.Errorf("unknown private key format %d", int(format))
+}
+
+// Unmarshal decodes a private key.
+// Either seed or expanded is nil unless the format is Both.
+func Unmarshal(der []byte) (
+	seed, expanded []byte, format Format, err error,
+) {
+	var raw asn1.RawValue
+	rest, err := asn1.Unmarshal(der, &raw)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	if len(rest) > 0 {
+		return nil, nil, 0, errors.New("extra data after private key")
+	}
+	switch {
+	case raw.Class == asn1.ClassContextSpecific && raw.Tag == 0 &&
+		!raw.IsCompound:
+		seed, format = raw.Bytes, SeedOnly
+	case raw.Class == asn1.ClassUniversal && raw.Tag == asn1.TagOctetString:
+		_, err = asn1.Unmarshal(der, &expanded)
+		format = ExpandedOnly
+	case raw.Class == asn1.ClassUniversal && raw.Tag == asn1.TagSequence:
+		var b both
+		_, err = asn1.Unmarshal(der, &b)
+		seed, expanded, format = b.Seed, b.ExpandedKey, Both
+	default:
+		return nil, nil, 0, errors.New("unknown private key format")
+	}
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	return seed, expanded, format, nil
+}
@@ -0,0 +1,139 @@
+// Package sm3 implements the SM3 hash function (GB/T 32905-2016),
+// which SM2 signatures use.
+package sm3
+
+import (
+	"encoding/binary"
+	"hash"
+	"math/bits"
+)
+
+// Size is the size of an SM3 digest.
+const Size = 32
+
+// BlockSize is the block size of SM3.
+const BlockSize = 64
+
+var iv = [8]uint32{
+	0x7380166f, 0x4914b2b9, 0x172442d7, 0xda8a0600,
+	0xa96f30bc, 0x163138aa, 0xe38dee4d, 0xb0fb0e4e,
+}
+
+type digest struct {
+	h   [8]uint32
+	buf [BlockSize]byte
+	n   int    // bytes in buf
+	len uint64 // total bytes written
+}
+
+// New returns a new SM3 hash.
+func New() hash.Hash {
+	d := &digest{}
+	d.Reset()
+	return d
+}
+
+// Sum returns the SM3 digest of the data.
+func Sum(data []byte) [Size]byte {
+	d := digest{h: iv}
+	d.Write(data)
+	var sum [Size]byte
+	d.Sum(sum[:0])
+	return sum
+}
+
+func (d *digest) Reset() {
+	d.h = iv
+	d.n = 0
+	d.len = 0
+}
+
+func (d *digest) Size() int { return Size }
+
+func (d *digest) BlockSize() int { return BlockSize }
+
+func (d *digest) Write(p []byte) (int, error) {
+	written := len(p)
+	d.len += uint64(written)
+	if d.n > 0 {
+		k := copy(d.buf[d.n:], p)
+		d.n += k
+		p = p[k:]
+		if d.n < BlockSize {
+			return written, nil
+		}
+		block(&d.h, d.buf[:])
+		d.n = 0
+	}
+	for len(p) >= BlockSize {
+		block(&d.h, p[:BlockSize])
+		p = p[BlockSize:]
+	}
+	d.n = copy(d.buf[:], p)
+	return written, nil
+}
+
+func (d *digest) Sum(in []byte) []byte {
+	// Pad a copy, so that the caller can keep writing.
+	dd := *d
+	var pad [BlockSize + 8]byte
+	pad[0] = 0x80
+	padLen := BlockSize - (int(dd.len)+8)%BlockSize
+	binary.BigEndian.PutUint64(pad[padLen:], dd.len*8)
+	dd.Write(pad[:padLen+8])
+	for _, v := range dd.h {
+		in = binary.BigEndian.AppendUint32(in, v)
+	}
+	return in
+}
+
+func p0(x uint32) uint32 {
+	return x ^ bits.RotateLeft32(x, 9) ^ bits.RotateLeft32(x, 17)
+}
+
+func p1(x uint32) uint32 {
+	return x ^ bits.RotateLeft32(x, 15) ^ bits.RotateLeft32(x, 23)
+}
+
+// block applies the compression function to the block p
+// (GB/T 32905-2016, section 5.3).
+func block(v *[8]uint32, p []byte) {
+	var w [68]uint32
+	for j := 0; j < 16; j++ {
+		w[j] = binary.BigEndian.Uint32(p[4*j:])
+	}
+	for j := 16; j < 68; j++ {
+		w[j] = p1(w[j-16]^w[j-9]^bits.RotateLeft32(w[j-3], 15)) ^
+			bits.RotateLeft32(w[j-13], 7) ^ w[j-6]
+	}
+	a, b, c, d, e, f, g, h := v[0], v[1], v[2], v[3], v[4], v[5], v[6], v[7]
+	for j := 0; j < 64; j++ {
+		t := uint32(0x79cc4519)
+		if j >= 16 {
+			t = 0x7a879d8a
+		}
+		a12 := bits.RotateLeft32(a, 12)
+		ss1 := bits.RotateLeft32(a12+e+bits.RotateLeft32(t, j%32), 7)
+		ss2 := ss1 ^ a12
+		var ff, gg uint32
+		if j < 16 {
+			ff = a ^ b ^ c
+			gg = e ^ f ^ g
+		} else {
+			ff = a&b | a&c | b&c
+			gg = e&f | ^e&g
+		}
+		tt1 := ff + d + ss2 + (w[j] ^ w[j+4])
+		tt2 := gg + h + ss1 + w[j]
+		d, c, b, a = c, bits.RotateLeft32(b, 9), a, tt1
+		h, g, f, e = g, bits.RotateLeft32(f, 19), e, p0(tt2)
+	}
+	v[0] ^= a
+	v[1] ^= b
+	v[2] ^= c
+	v[3] ^= d
+	v[4] ^= e
+	v[5] ^= f
+	v[6] ^= g
+	v[7] ^= h
+}
@@ -0,0 +1,130 @@
+// Package sshwire implements the SSH wire encoding of data types,
+// as defined in RFC 4251, section 5.
+package sshwire
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/big"
+)
+
+// ErrShort means the input ended before the value being read.
+var ErrShort = errors.New("SSH wire data too short")
+
+// Builder builds an SSH wire-encoded byte string.
+type Builder struct {
+	b []byte
+}
+
+// Bytes returns the bytes built so far.
+func (b *Builder) Bytes() []byte {
+	return b.b
+}
+
+// Uint32 appends a uint32.
+func (b *Builder) Uint32(v uint32) *Builder {
+	b.b = binary.BigEndian.AppendUint32(b.b, v)
+	return b
+}
+
+// String appends a string, i.e. a uint32 length followed by the bytes.
+func (b *Builder) String(s []byte) *Builder {
+	b.Uint32(uint32(len(s)))
+	b.b = append(b.b, s...)
+	return b
+}
+
+// Raw appends the given bytes without a length prefix.
+func (b *Builder) Raw(s []byte) *Builder {
+	b.b = append(b.b, s...)
+	return b
+}
+
+// MPInt appends a multiple precision integer in two's complement format.
+func (b *Builder) MPInt(n *big.Int) *Builder {
+	return b.String(mpintBytes(n))
+}
+
+func mpintBytes(n *big.Int) []byte {
+	switch n.Sign() {
+	case 0:
+		return nil
+	case 1:
+		bytes := n.Bytes()
+		if bytes[0]&0x80 != 0 {
+			bytes = append([]byte{0}, bytes...)
+		}
+		return bytes
+	}
+	// Two's complement of a negative number: invert the bits of |n|-1.
+	bytes := new(big.Int).Sub(new(big.Int).Neg(n), big.NewInt(1)).Bytes()
+	for i := range bytes {
+		bytes[i] ^= 0xff
+	}
+	if len(bytes) == 0 || bytes[0]&0x80 == 0 {
+		bytes = append([]byte{0xff}, bytes...)
+	}
+	return bytes
+}
+
+// Parser parses an SSH wire-encoded byte string.
+type Parser struct {
+	b []byte
+}
+
+// NewParser returns a new parser over the given bytes.
+func NewParser(b []byte) *Parser {
+	return &Parser{b: b}
+}
+
+// Rest returns the unparsed bytes.
+func (p *Parser) Rest() []byte {
+	return p.b
+}
+
+// Empty returns whether all bytes have been parsed.
+func (p *Parser) Empty() bool {
+	return len(p.b) == 0
+}
+
+// Uint32 parses a uint32.
+func (p *Parser) Uint32() (uint32, error) {
+	if len(p.b) < 4 {
+		return 0, ErrShort
+	}
+	v := binary.BigEndian.Uint32(p.b)
+	p.b = p.b[4:]
+	return v, nil
+}
+
+// String parses a string.
+func (p *Parser) String() ([]byte, error) {
+	l, err := p.Uint32()
+	if err != nil {
+		return nil, err
+	}
+	return p.Raw(int(l))
+}
+
+// Raw parses the given number of bytes without a length prefix.
+func (p *Parser) Raw(n int) ([]byte, error) {
+	if n < 0 || len(p.b) < n {
+		return nil, ErrShort
+	}
+	s := p.b[:n:n]
+	p.b = p.b[n:]
+	return s, nil
+}
+
+// MPInt parses a multiple precision integer in two's complement format.
+func (p *Parser) MPInt() (*big.Int, error) {
+	s, err := p.String()
+	if err != nil {
+		return nil, err
+	}
+	n := new(big.Int).SetBytes(s)
+	if len(s) > 0 && s[0]&0x80 != 0 {
+		n.Sub(n, new(big.Int).Lsh(big.NewInt(1), uint(8*len(s))))
+	}
+	return n, nil
+}
@@ -0,0 +1,152 @@
+// Package jwk converts between key packages and private JSON Web Keys
+// (RFC 7517, RFC 7518).
+//
+// Key types are handled by the packers registered with akp.Packers that
+// implement akp.JWKCodec.
+// The "kid" member maps onto the localKeyId attribute of the key package;
+// without one, the RFC 7638 thumbprint of the key serves as "kid".
+package jwk
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+)
+
+// JWK is a JSON Web Key, as a map from member names to values.
+type JWK map[string]interface{}
+
+// KeyID returns the "kid" member of the JWK, or "" if there is none.
+func (jwk JWK) KeyID() string {
+	kid, _ := jwk["kid"].(string)
+	return kid
+}
+
+// Set is a JWK Set (RFC 7517, section 5).
+type Set struct {
+	Keys []JWK `json:"keys"`
+}
+
+// ErrNoPrivateKey means a JWK has no private key members.
+var ErrNoPrivateKey = errors.New("JWK has no private key")
+
+// ErrUnsupportedKeyType means no registered packer can convert a JWK.
+var ErrUnsupportedKeyType = errors.New("unsupported JWK key type")
+
+// FromPackage converts a key package into a private JWK.
+func FromPackage(pkg *akp.OneAsymmetricKey) (JWK, error) {
+	priv, pub, _, err := akp.Unpack(pkg)
+	if err != nil {
+		return nil, err
+	}
+	members, err := akp.Packers.MarshalJWK(priv)
+	if err == akp.ErrSkip {
+		return nil, fmt.Errorf("%T has no JWK format", priv)
+	}
+	if err != nil {
+		return nil, err
+	}
+	jwk := JWK(members)
+	if id, ok := pkg.LocalKeyID(); ok && utf8.Valid(id) {
+		jwk["kid"] = string(id)
+	} else {
+		fp, err := akp.KeyFingerprint(priv, pub)
+		if err != nil {
+			return nil, err
+		}
+		if kid := fp.JWKThumbprintString(); kid != "" {
+			jwk["kid"] = kid
+		}
+	}
+	return jwk, nil
+}
+
+// ToPackage converts a private JWK into a key package.
+//
+// The "kid" member, if any, is kept in the localKeyId attribute.
+func ToPackage(jwk JWK) (*akp.OneAsymmetricKey, error) {
+	if _, ok := jwk["kty"].(string); !ok {
+		return nil, errors.New("JWK has no \"kty\" member")
+	}
+	priv, pub, err := akp.Packers.UnmarshalJWK(jwk)
+	if err == akp.ErrSkip {
+		return nil, fmt.Errorf("%w %q", ErrUnsupportedKeyType, jwk["kty"])
+	}
+	// All private JWKs defined in RFC 7518 and RFC 8037 have a "d" member.
+	if _, ok := jwk["d"]; !ok {
+		return nil, ErrNoPrivateKey
+	}
+	if err != nil {
+		return nil, err
+	}
+	pkg, err := akp.Pack(priv, pub)
+	if err != nil {
+		return nil, err
+	}
+	if kid := jwk.KeyID(); kid != "" {
+		pkg.SetLocalKeyID([]byte(kid))
+	}
+	return pkg, nil
+}
+
+// Marshal converts a key package into a JSON-encoded private JWK.
+func Marshal(pkg *akp.OneAsymmetricKey) ([]byte, error) {
+	jwk, err := FromPackage(pkg)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(jwk)
+}
+
+// Unmarshal converts a JSON-encoded private JWK into a key package.
+func Unmarshal(data []byte) (*akp.OneAsymmetricKey, error) {
+	var jwk JWK
+	if err := json.Unmarshal(data, &jwk); err != nil {
+		return nil, err
+	}
+	return ToPackage(jwk)
+}
+
+// MarshalSet converts key packages into a JSON-encoded JWK Set.
+func MarshalSet(pkgs []*akp.OneAsymmetricKey) ([]byte, error) {
+	set := Set{Keys: make([]JWK, 0, len(pkgs))}
+	for i, pkg := range pkgs {
+		jwk, err := FromPackage(pkg)
+		if err != nil {
+			return nil, fmt.Errorf("key #%d: %v", i, err)
+		}
+		set.Keys = append(set.Keys, jwk)
+	}
+	return json.Marshal(set)
+}
+
+// UnmarshalSet converts a JSON-encoded JWK Set into key packages.
+//
+// As RFC 7517, section 5 requires, keys of unsupported key types are
+// ignored.
+// Public keys, which have no key package, are skipped too,
+// so that a set mixing private and public keys yields its private keys.
+func UnmarshalSet(data []byte) ([]*akp.OneAsymmetricKey, error) {
+	var set Set
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, err
+	}
+	if set.Keys == nil {
+		return nil, errors.New("JWK Set has no \"keys\" member")
+	}
+	var pkgs []*akp.OneAsymmetricKey
+	for i, jwk := range set.Keys {
+		pkg, err := ToPackage(jwk)
+		if errors.Is(err, ErrUnsupportedKeyType) || err == ErrNoPrivateKey {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("key #%d: %v", i, err)
+		}
+		pkgs = append(pkgs, pkg)
+	}
+	return pkgs, nil
+}
@@ -0,0 +1,122 @@
+package jwk
+
+import (
+	"crypto/dsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+	_ "github.com/harmony-one/asym-key-pkgs/pkg/algo/dsa"
+	_ "github.com/harmony-one/asym-key-pkgs/pkg/algo/rsa"
+)
+
+func TestRoundTrip(t *testing.T) {
+	priv, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatalf("cannot generate RSA key pair: %v", err)
+	}
+	pkg, err := akp.Pack(priv, nil)
+	if err != nil {
+		t.Fatalf("cannot pack RSA key pair: %v", err)
+	}
+	t.Run("Thumbprint", func(t *testing.T) {
+		jwk, err := FromPackage(pkg)
+		if err != nil {
+			t.Fatalf("cannot convert key package: %v", err)
+		}
+		fp, err := pkg.Fingerprint()
+		if err != nil {
+			t.Fatalf("cannot fingerprint key package: %v", err)
+		}
+		if jwk.KeyID() != fp.JWKThumbprintString() {
+			t.Errorf("kid is %q; expected thumbprint %q",
+				jwk.KeyID(), fp.JWKThumbprintString())
+		}
+		for _, name := range []string{
+			"kty", "n", "e", "d", "p", "q", "dp", "dq", "qi",
+		} {
+			if _, ok := jwk[name].(string); !ok {
+				t.Errorf("JWK member %q is missing", name)
+			}
+		}
+	})
+	t.Run("KeyID", func(t *testing.T) {
+		pkg := *pkg
+		pkg.SetLocalKeyID([]byte("2026-signing"))
+		encoded, err := Marshal(&pkg)
+		if err != nil {
+			t.Fatalf("cannot marshal JWK: %v", err)
+		}
+		pkg2, err := Unmarshal(encoded)
+		if err != nil {
+			t.Fatalf("cannot unmarshal JWK: %v", err)
+		}
+		if id, _ := pkg2.LocalKeyID(); string(id) != "2026-signing" {
+			t.Errorf("localKeyId is %q; expected %q", id, "2026-signing")
+		}
+		priv2, _, _, err := akp.Unpack(pkg2)
+		if err != nil {
+			t.Fatalf("cannot unpack RSA key pair: %v", err)
+		}
+		if !reflect.DeepEqual(priv, priv2) {
+			t.Errorf("unmarshaled key %+v is different from the original %+v",
+				priv2, priv)
+		}
+	})
+	t.Run("Set", func(t *testing.T) {
+		encoded, err := MarshalSet([]*akp.OneAsymmetricKey{pkg})
+		if err != nil {
+			t.Fatalf("cannot marshal JWK Set: %v", err)
+		}
+		var set Set
+		if err = json.Unmarshal(encoded, &set); err != nil {
+			t.Fatalf("cannot parse JWK Set: %v", err)
+		}
+		set.Keys = append(set.Keys, JWK{"kty": "oct", "k": "AAEC"})
+		public, _ := FromPackage(pkg)
+		delete(public, "d")
+		set.Keys = append(set.Keys, public)
+		encoded, _ = json.Marshal(set)
+		pkgs, err := UnmarshalSet(encoded)
+		if err != nil {
+			t.Fatalf("cannot unmarshal JWK Set: %v", err)
+		}
+		if len(pkgs) != 1 {
+			t.Fatalf("got %d key packages; expected 1", len(pkgs))
+		}
+	})
+	t.Run("PublicOnly", func(t *testing.T) {
+		jwk, _ := FromPackage(pkg)
+		delete(jwk, "d")
+		if _, err := ToPackage(jwk); err != ErrNoPrivateKey {
+			t.Errorf("ToPackage returned %+v; expected %+v", err, ErrNoPrivateKey)
+		}
+	})
+}
+
+func TestUnsupported(t *testing.T) {
+	var priv dsa.PrivateKey
+	err := dsa.GenerateParameters(&priv.Parameters, rand.Reader, dsa.L1024N160)
+	if err != nil {
+		t.Fatalf("cannot generate DSA parameters: %v", err)
+	}
+	if err = dsa.GenerateKey(&priv, rand.Reader); err != nil {
+		t.Fatalf("cannot generate DSA key pair: %v", err)
+	}
+	pkg, err := akp.Pack(&priv, nil)
+	if err != nil {
+		t.Fatalf("cannot pack DSA key pair: %v", err)
+	}
+	if _, err = FromPackage(pkg); err == nil {
+		t.Errorf("DSA key converted to JWK")
+	}
+	_, err = ToPackage(JWK{"kty": "DSA", "d": "AQ"})
+	if !errors.Is(err, ErrUnsupportedKeyType) {
+		t.Errorf("ToPackage returned %+v; expected %+v",
+			err, ErrUnsupportedKeyType)
+	}
+}
@@ -0,0 +1,111 @@
+package legacypem
+
+import (
+	"encoding/asn1"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+)
+
+// File name extensions of the converted files
+const (
+	DERExt = ".der"
+	PEMExt = ".pem"
+)
+
+// ToPKCS8Dir converts every traditional PEM private key file in srcDir into a
+// DER-encoded key package file in dstDir, as written by akp.Save,
+// whose name is that of the PEM file with the extension DERExt.
+//
+// Files without a PEM block are skipped.
+// The password is used for encrypted files.
+// A file that cannot be converted does not stop the conversion of the
+// others; ToPKCS8Dir returns the names of the converted files,
+// and an error joining those of all the files that were not converted.
+func ToPKCS8Dir(srcDir, dstDir string, password []byte) ([]string, error) {
+	convert := func(data []byte) ([]byte, error) {
+		pkg, _, err := Import(data, password)
+		if err != nil {
+			return nil, err
+		}
+		return asn1.Marshal(*pkg)
+	}
+	return convertDir(srcDir, dstDir, DERExt, convert)
+}
+
+// FromPKCS8Dir converts every DER-encoded key package file in srcDir,
+// as written by akp.Save, into a traditional PEM private key file in dstDir,
+// whose name is that of the key package file with the extension PEMExt.
+//
+// If password is not nil, the output files are encrypted with the given
+// cipher.
+// Like ToPKCS8Dir, it returns the names of the converted files,
+// and an error joining those of all the files that were not converted.
+func FromPKCS8Dir(
+	srcDir, dstDir string, password []byte, cipher Cipher,
+) ([]string, error) {
+	convert := func(data []byte) ([]byte, error) {
+		var pkg akp.OneAsymmetricKey
+		rest, err := asn1.Unmarshal(data, &pkg)
+		if err != nil {
+			return nil, err
+		}
+		if len(rest) > 0 {
+			return nil, errors.New("trailing data after key package")
+		}
+		return ExportToMemory(&pkg, password, cipher)
+	}
+	return convertDir(srcDir, dstDir, PEMExt, convert)
+}
+
+func convertDir(
+	srcDir, dstDir, ext string, convert func(data []byte) ([]byte, error),
+) (names []string, err error) {
+	entries, err := os.ReadDir(srcDir)
+	if err != nil {
+		return nil, err
+	}
+	var errs []error
+	written := make(map[string]bool)
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() {
+			continue
+		}
+		name := entry.Name()
+		path := filepath.Join(srcDir, name)
+		data, err := os.ReadFile(path) // nolint
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		converted, err := convert(data)
+		if err == ErrNoPEM {
+			continue
+		}
+		if err != nil {
+			errs = append(errs, &os.PathError{
+				Op: "convert", Path: path, Err: err,
+			})
+			continue
+		}
+		name = strings.TrimSuffix(name, filepath.Ext(name)) + ext
+		if written[name] {
+			errs = append(errs, &os.PathError{
+				Op: "convert", Path: path,
+				Err: errors.New("another file was converted to " + name),
+			})
+			continue
+		}
+		written[name] = true
+		err = os.WriteFile(filepath.Join(dstDir, name), converted, 0600)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		names = append(names, name)
+	}
+	return names, errors.Join(errs...)
+}
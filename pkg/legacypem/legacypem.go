@@ -0,0 +1,272 @@
+// Package legacypem converts between key packages and the OpenSSL
+// "traditional" PEM private key formats:
+// RSA PRIVATE KEY (PKCS #1), DSA PRIVATE KEY and EC PRIVATE KEY (RFC 5915),
+// optionally encrypted with the legacy Proc-Type/DEK-Info headers
+// (RFC 1421).
+package legacypem
+
+import (
+	"crypto/dsa"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+	"github.com/harmony-one/asym-key-pkgs/pkg/internal/ecurve"
+
+	// register packers
+	_ "github.com/harmony-one/asym-key-pkgs/pkg/algo/dsa"
+	_ "github.com/harmony-one/asym-key-pkgs/pkg/algo/ec"
+	_ "github.com/harmony-one/asym-key-pkgs/pkg/algo/rsa"
+	_ "github.com/harmony-one/asym-key-pkgs/pkg/algo/secp256k1"
+)
+
+// PEM block types
+const (
+	RSAPrivateKey = "RSA PRIVATE KEY"
+	DSAPrivateKey = "DSA PRIVATE KEY"
+	ECPrivateKey  = "EC PRIVATE KEY"
+)
+
+// ErrNoPEM means the input contains no PEM block.
+var ErrNoPEM = errors.New("no PEM block found")
+
+// ErrPasswordRequired means the PEM block is encrypted,
+// but no password was given.
+var ErrPasswordRequired = errors.New("PEM block is encrypted; password required")
+
+// Import converts the first private key PEM block in data into a key
+// package.
+//
+// Blocks that are not private keys, such as the EC PARAMETERS block that
+// "openssl ecparam -genkey" writes before the key, are skipped.
+// The password is used only if the block is encrypted.
+// It returns the data following the block.
+func Import(data []byte, password []byte) (
+	pkg *akp.OneAsymmetricKey, rest []byte, err error,
+) {
+	rest = data
+	for {
+		var block *pem.Block
+		if block, rest = pem.Decode(rest); block == nil {
+			return nil, data, ErrNoPEM
+		}
+		if strings.HasSuffix(block.Type, "PRIVATE KEY") {
+			pkg, err = ImportBlock(block, password)
+			return pkg, rest, err
+		}
+	}
+}
+
+// ImportBlock converts a traditional private key PEM block into a key package.
+//
+// The password is used only if the block is encrypted.
+func ImportBlock(block *pem.Block, password []byte) (
+	*akp.OneAsymmetricKey, error,
+) {
+	der := block.Bytes
+	// nolint: the legacy PEM encryption is insecure, but it is the format.
+	if x509.IsEncryptedPEMBlock(block) {
+		if password == nil {
+			return nil, ErrPasswordRequired
+		}
+		var err error
+		// nolint
+		if der, err = x509.DecryptPEMBlock(block, password); err != nil {
+			return nil, err
+		}
+	}
+	var (
+		priv interface{}
+		pub  interface{}
+		err  error
+	)
+	switch block.Type {
+	case RSAPrivateKey:
+		var privKey *rsa.PrivateKey
+		if privKey, err = x509.ParsePKCS1PrivateKey(der); err == nil {
+			priv, pub = privKey, &privKey.PublicKey
+		}
+	case DSAPrivateKey:
+		var privKey *dsa.PrivateKey
+		if privKey, err = parseDSAPrivateKey(der); err == nil {
+			priv, pub = privKey, &privKey.PublicKey
+		}
+	case ECPrivateKey:
+		return importECPrivateKey(der)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block type %q", block.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return akp.Pack(priv, pub)
+}
+
+// Cipher is a legacy PEM encryption algorithm.
+type Cipher = x509.PEMCipher
+
+// Ciphers
+const (
+	DES       Cipher = x509.PEMCipherDES
+	TripleDES Cipher = x509.PEMCipher3DES
+	AES128    Cipher = x509.PEMCipherAES128
+	AES192    Cipher = x509.PEMCipherAES192
+	AES256    Cipher = x509.PEMCipherAES256
+)
+
+// Export converts a key package into a traditional private key PEM block.
+//
+// If password is not nil, the block is encrypted with the given cipher and
+// the Proc-Type/DEK-Info headers; use AES256 unless a reader demands
+// otherwise.
+func Export(
+	pkg *akp.OneAsymmetricKey, password []byte, cipher Cipher,
+) (*pem.Block, error) {
+	priv, _, _, err := akp.Unpack(pkg)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		typ string
+		der []byte
+	)
+	switch privKey := priv.(type) {
+	case *rsa.PrivateKey:
+		typ, der = RSAPrivateKey, x509.MarshalPKCS1PrivateKey(privKey)
+	case *dsa.PrivateKey:
+		typ = DSAPrivateKey
+		der, err = marshalDSAPrivateKey(privKey)
+	case *ecdsa.PrivateKey:
+		typ = ECPrivateKey
+		der, err = exportECPrivateKey(pkg)
+	default:
+		return nil, fmt.Errorf("%T has no traditional PEM format", priv)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if password == nil {
+		return &pem.Block{Type: typ, Bytes: der}, nil
+	}
+	// nolint: the legacy PEM encryption is insecure, but it is the format.
+	return x509.EncryptPEMBlock(rand.Reader, typ, der, password, cipher)
+}
+
+// ExportToMemory is like Export, but returns the PEM encoding.
+func ExportToMemory(
+	pkg *akp.OneAsymmetricKey, password []byte, cipher Cipher,
+) ([]byte, error) {
+	block, err := Export(pkg, password, cipher)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(block), nil
+}
+
+// OpenSSL DSA private key structure.
+type asn1DSAPrivateKey struct {
+	Version int
+	P       *big.Int
+	Q       *big.Int
+	G       *big.Int
+	Y       *big.Int
+	X       *big.Int
+}
+
+func parseDSAPrivateKey(der []byte) (*dsa.PrivateKey, error) {
+	var k asn1DSAPrivateKey
+	rest, err := asn1.Unmarshal(der, &k)
+	if err != nil {
+		return nil, fmt.Errorf("cannot unmarshal DSA private key: %v", err)
+	}
+	if len(rest) > 0 {
+		return nil, errors.New("extra data after DSA private key")
+	}
+	if k.Version != 0 {
+		return nil, fmt.Errorf("unsupported DSA private key version %d",
+			k.Version)
+	}
+	var privKey dsa.PrivateKey
+	privKey.P, privKey.Q, privKey.G = k.P, k.Q, k.G
+	privKey.Y, privKey.X = k.Y, k.X
+	if k.P == nil || k.Q == nil || k.G == nil || k.Y == nil || k.X == nil ||
+		k.P.Sign() <= 0 {
+		return nil, errors.New("incomplete DSA private key")
+	}
+	if new(big.Int).Exp(k.G, k.X, k.P).Cmp(k.Y) != 0 {
+		return nil, errors.New("DSA public key does not match private key")
+	}
+	return &privKey, nil
+}
+
+func marshalDSAPrivateKey(privKey *dsa.PrivateKey) ([]byte, error) {
+	if privKey.P == nil || privKey.Y == nil {
+		return nil, errors.New("DSA parameters are missing")
+	}
+	return asn1.Marshal(asn1DSAPrivateKey{
+		P: privKey.P, Q: privKey.Q, G: privKey.G, Y: privKey.Y, X: privKey.X,
+	})
+}
+
+// ecPublicKeyOID is the id-ecPublicKey algorithm (RFC 5480, section 2.1.1).
+var ecPublicKeyOID = asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}
+
+// importECPrivateKey converts an ECPrivateKey, which names its curve in its
+// parameters, into a key package, through the registered id-ecPublicKey
+// unpackers, so that secp256k1 and the other curves that crypto/x509 does
+// not know are supported.
+func importECPrivateKey(der []byte) (*akp.OneAsymmetricKey, error) {
+	var ecPrivKey ecurve.ECPrivateKey
+	rest, err := asn1.Unmarshal(der, &ecPrivKey)
+	if err != nil {
+		return nil, fmt.Errorf("cannot unmarshal EC private key: %v", err)
+	}
+	if len(rest) > 0 {
+		return nil, errors.New("extra data after EC private key")
+	}
+	if ecPrivKey.Parameters.Bytes == nil {
+		return nil, errors.New("EC private key has no curve parameters")
+	}
+	priv, pub, _, err := akp.Unpack(&akp.OneAsymmetricKey{
+		Version: akp.V1,
+		PrivateKeyAlgorithm: pkix.AlgorithmIdentifier{
+			Algorithm:  ecPublicKeyOID,
+			Parameters: asn1.RawValue{FullBytes: ecPrivKey.Parameters.Bytes},
+		},
+		PrivateKey: der,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return akp.Pack(priv, pub)
+}
+
+// exportECPrivateKey returns the ECPrivateKey of an EC key package,
+// with the curve parameters and the public key, as OpenSSL writes it.
+func exportECPrivateKey(pkg *akp.OneAsymmetricKey) ([]byte, error) {
+	var ecPrivKey ecurve.ECPrivateKey
+	if _, err := asn1.Unmarshal(pkg.PrivateKey, &ecPrivKey); err != nil {
+		return nil, fmt.Errorf("cannot unmarshal EC private key: %v", err)
+	}
+	ecPrivKey.Parameters = asn1.RawValue{
+		Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true,
+		Bytes: pkg.PrivateKeyAlgorithm.Parameters.FullBytes,
+	}
+	if ecPrivKey.PublicKey.Bytes == nil {
+		bits, err := pkg.PublicKeyBits()
+		if err != nil {
+			return nil, err
+		}
+		ecPrivKey.PublicKey = bits
+	}
+	return asn1.Marshal(ecPrivKey)
+}
@@ -0,0 +1,180 @@
+package legacypem
+
+import (
+	"bytes"
+	"crypto/dsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/pem"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+)
+
+func generateKeys(t *testing.T) []interface{} {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatalf("cannot generate RSA key pair: %v", err)
+	}
+	var dsaKey dsa.PrivateKey
+	err = dsa.GenerateParameters(&dsaKey.Parameters, rand.Reader, dsa.L1024N160)
+	if err != nil {
+		t.Fatalf("cannot generate DSA parameters: %v", err)
+	}
+	if err = dsa.GenerateKey(&dsaKey, rand.Reader); err != nil {
+		t.Fatalf("cannot generate DSA key pair: %v", err)
+	}
+	return []interface{}{rsaKey, &dsaKey}
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, priv := range generateKeys(t) {
+		for _, password := range [][]byte{nil, []byte("s3cr3t")} {
+			pkg, err := akp.Pack(priv, nil)
+			if err != nil {
+				t.Fatalf("cannot pack %T: %v", priv, err)
+			}
+			encoded, err := ExportToMemory(pkg, password, AES256)
+			if err != nil {
+				t.Fatalf("cannot export %T: %v", priv, err)
+			}
+			block, _ := pem.Decode(encoded)
+			if _, ok := block.Headers["DEK-Info"]; ok != (password != nil) {
+				t.Errorf("unexpected headers %+v", block.Headers)
+			}
+			if password != nil {
+				_, _, err = Import(encoded, nil)
+				if err != ErrPasswordRequired {
+					t.Errorf("Import returned %+v; expected %+v",
+						err, ErrPasswordRequired)
+				}
+			}
+			pkg2, rest, err := Import(encoded, password)
+			if err != nil {
+				t.Fatalf("cannot import %T: %v", priv, err)
+			}
+			if len(rest) > 0 {
+				t.Errorf("unexpected rest %q", rest)
+			}
+			priv2, _, _, err := akp.Unpack(pkg2)
+			if err != nil {
+				t.Fatalf("cannot unpack %T: %v", priv, err)
+			}
+			if !reflect.DeepEqual(priv, priv2) {
+				t.Errorf("imported key %+v is different from the original %+v",
+					priv2, priv)
+			}
+		}
+	}
+}
+
+func TestDir(t *testing.T) {
+	pemDir, pkcs8Dir, pemDir2 := t.TempDir(), t.TempDir(), t.TempDir()
+	keys := generateKeys(t)
+	for i, priv := range keys {
+		pkg, err := akp.Pack(priv, nil)
+		if err != nil {
+			t.Fatalf("cannot pack %T: %v", priv, err)
+		}
+		encoded, err := ExportToMemory(pkg, []byte("pw"), AES128)
+		if err != nil {
+			t.Fatalf("cannot export %T: %v", priv, err)
+		}
+		name := filepath.Join(pemDir, string(rune('a'+i))+".pem")
+		if err = os.WriteFile(name, encoded, 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	err := os.WriteFile(filepath.Join(pemDir, "README"), []byte("hi"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bad := pem.EncodeToMemory(&pem.Block{Type: "X PRIVATE KEY"})
+	err = os.WriteFile(filepath.Join(pemDir, "bad.pem"), bad, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The unsupported file fails alone.
+	names, err := ToPKCS8Dir(pemDir, pkcs8Dir, []byte("pw"))
+	var pathErr *os.PathError
+	if !errors.As(err, &pathErr) ||
+		pathErr.Path != filepath.Join(pemDir, "bad.pem") {
+		t.Errorf("ToPKCS8Dir returned %v; expected an error for bad.pem", err)
+	}
+	if !reflect.DeepEqual(names, []string{"a.der", "b.der"}) {
+		t.Errorf("unexpected converted files %v", names)
+	}
+	for i, name := range names {
+		priv, _, _, err := akp.Load(filepath.Join(pkcs8Dir, name))
+		if err != nil {
+			t.Fatalf("cannot load %s: %v", name, err)
+		}
+		if !reflect.DeepEqual(priv, keys[i]) {
+			t.Errorf("%s: loaded key is different from the original", name)
+		}
+	}
+	names, err = FromPKCS8Dir(pkcs8Dir, pemDir2, nil, AES256)
+	if err != nil {
+		t.Fatalf("cannot convert from PKCS #8: %v", err)
+	}
+	if !reflect.DeepEqual(names, []string{"a.pem", "b.pem"}) {
+		t.Errorf("unexpected converted files %v", names)
+	}
+}
+
+// The testdata files were generated by OpenSSL 3.0, which writes an
+// EC PARAMETERS block before the EC PRIVATE KEY block:
+//
+//	openssl ecparam -genkey -name <curve> -out <curve>.pem
+//	openssl pkcs8 -topk8 -nocrypt -in <curve>.pem -out <curve>_pkcs8.pem
+
+func TestOpenSSLEC(t *testing.T) {
+	for _, curve := range []string{"prime256v1", "secp256k1"} {
+		t.Run(curve, func(t *testing.T) {
+			data, err := os.ReadFile("testdata/" + curve + ".pem")
+			if err != nil {
+				t.Fatal(err)
+			}
+			pkg, rest, err := Import(data, nil)
+			if err != nil {
+				t.Fatalf("cannot import: %v", err)
+			}
+			if len(rest) > 0 {
+				t.Errorf("unexpected rest %q", rest)
+			}
+			priv, _, _, err := akp.Unpack(pkg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			pkcs8, err := os.ReadFile("testdata/" + curve + "_pkcs8.pem")
+			if err != nil {
+				t.Fatal(err)
+			}
+			block, _ := pem.Decode(pkcs8)
+			expected, _, _, err := akp.Decode(block.Bytes)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(priv, expected) {
+				t.Errorf("imported key %+v is different from %+v",
+					priv, expected)
+			}
+			// Exporting writes the EC PRIVATE KEY block as OpenSSL did.
+			exported, err := Export(pkg, nil, AES256)
+			if err != nil {
+				t.Fatalf("cannot export: %v", err)
+			}
+			_, rest = pem.Decode(data)
+			block, _ = pem.Decode(rest)
+			if exported.Type != block.Type ||
+				!bytes.Equal(exported.Bytes, block.Bytes) {
+				t.Errorf("exported %s %x; expected %s %x", exported.Type,
+					exported.Bytes, block.Type, block.Bytes)
+			}
+		})
+	}
+}
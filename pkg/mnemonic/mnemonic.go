@@ -0,0 +1,159 @@
+// Package mnemonic converts between BIP-39 mnemonic sentences and key
+// packages.
+//
+// A mnemonic encodes 128 to 256 bits of entropy in 12 to 24 words of the
+// English wordlist of BIP-39, with a checksum.
+// The mnemonic and an optional passphrase give a seed, which gives the master
+// key of package hdkey for a chosen curve.
+//
+// A key package made from a mnemonic may record the entropy in its
+// attributes (see StoreEntropy), so that Mnemonic can emit the mnemonic
+// again.
+// The passphrase is never recorded.
+package mnemonic
+
+import (
+	"crypto/pbkdf2"
+	"crypto/sha256"
+	"crypto/sha512"
+	_ "embed"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+//go:embed english.txt
+var english string
+
+// wordList is the English wordlist of BIP-39, in order.
+var wordList = strings.Fields(english)
+
+// wordIndex maps the words of wordList to their indices.
+var wordIndex = func() map[string]int {
+	index := make(map[string]int, len(wordList))
+	for i, word := range wordList {
+		index[word] = i
+	}
+	return index
+}()
+
+// Entropy sizes
+const (
+	MinEntropySize = 16
+	MaxEntropySize = 32
+)
+
+// SeedSize is the size of the seed of a mnemonic.
+const SeedSize = 64
+
+const pbkdf2Iterations = 2048
+
+// ErrChecksum means the checksum of a mnemonic is wrong,
+// e.g. because of a mistyped or misplaced word.
+var ErrChecksum = errors.New("mnemonic checksum mismatch")
+
+func checkEntropySize(n int) error {
+	if n < MinEntropySize || n > MaxEntropySize || n%4 != 0 {
+		return fmt.Errorf(
+			"entropy is %d bytes; expected 16, 20, 24, 28 or 32", n)
+	}
+	return nil
+}
+
+// GenerateEntropy reads entropy of the given size in bytes from rand.
+func GenerateEntropy(rand io.Reader, size int) ([]byte, error) {
+	if err := checkEntropySize(size); err != nil {
+		return nil, err
+	}
+	entropy := make([]byte, size)
+	if _, err := io.ReadFull(rand, entropy); err != nil {
+		return nil, err
+	}
+	return entropy, nil
+}
+
+// FromEntropy returns the mnemonic of the entropy
+// (BIP-39, "Generating the mnemonic").
+func FromEntropy(entropy []byte) (string, error) {
+	if err := checkEntropySize(len(entropy)); err != nil {
+		return "", err
+	}
+	// The checksum is the first len(entropy)/4 bits of SHA-256(entropy).
+	checksum := sha256.Sum256(entropy)
+	data := append(append([]byte(nil), entropy...), checksum[0])
+	words := make([]string, 3*len(entropy)/4)
+	for i := range words {
+		words[i] = wordList[bits11(data, 11*i)]
+	}
+	return strings.Join(words, " "), nil
+}
+
+// bits11 returns the 11 bits of data from the given bit offset.
+func bits11(data []byte, offset int) int {
+	v := 0
+	for i := offset; i < offset+11; i++ {
+		v = v<<1 | int(data[i/8]>>(7-i%8)&1)
+	}
+	return v
+}
+
+// ToEntropy returns the entropy of the mnemonic,
+// after checking its words and its checksum.
+// Words are separated by white space, after NFKD normalization,
+// which turns e.g. ideographic spaces into spaces.
+func ToEntropy(mnemonic string) ([]byte, error) {
+	words := strings.Fields(norm.NFKD.String(mnemonic))
+	if len(words)%3 != 0 || len(words) < 12 || len(words) > 24 {
+		return nil, fmt.Errorf(
+			"mnemonic has %d words; expected 12, 15, 18, 21 or 24",
+			len(words))
+	}
+	data := make([]byte, (11*len(words)+7)/8)
+	for i, word := range words {
+		index, ok := wordIndex[word]
+		if !ok {
+			return nil, fmt.Errorf("word %d of mnemonic is not in the "+
+				"BIP-39 English wordlist", i+1)
+		}
+		for j := 0; j < 11; j++ {
+			if index>>(10-j)&1 != 0 {
+				bit := 11*i + j
+				data[bit/8] |= 0x80 >> (bit % 8)
+			}
+		}
+	}
+	n := 4 * len(words) / 3
+	entropy := data[:n]
+	checksum := sha256.Sum256(entropy)
+	mask := byte(0xff) << (8 - len(words)/3)
+	if data[n]&mask != checksum[0]&mask {
+		return nil, ErrChecksum
+	}
+	return entropy, nil
+}
+
+// Validate checks the words and the checksum of the mnemonic.
+func Validate(mnemonic string) error {
+	_, err := ToEntropy(mnemonic)
+	return err
+}
+
+// Seed returns the seed of the mnemonic and the passphrase
+// (BIP-39, "From mnemonic to seed"), after checking the mnemonic.
+//
+// The mnemonic and the passphrase are converted to Unicode normalization
+// form NFKD, as BIP-39 requires,
+// and the words of the mnemonic are joined by single spaces.
+func Seed(mnemonic, passphrase string) ([]byte, error) {
+	mnemonic = norm.NFKD.String(mnemonic)
+	passphrase = norm.NFKD.String(passphrase)
+	if err := Validate(mnemonic); err != nil {
+		return nil, err
+	}
+	sentence := strings.Join(strings.Fields(mnemonic), " ")
+	return pbkdf2.Key(sha512.New, sentence, []byte("mnemonic"+passphrase),
+		pbkdf2Iterations, SeedSize)
+}
@@ -0,0 +1,276 @@
+package mnemonic
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/asn1"
+	"encoding/hex"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+	"github.com/harmony-one/asym-key-pkgs/pkg/hdkey"
+)
+
+func mustDecodeHex(t *testing.T, s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+// Test vectors of the reference implementation of BIP-39
+// (trezor/python-mnemonic, vectors.json), whose passphrase is "TREZOR".
+const vectorPassphrase = "TREZOR"
+
+var vectors = []struct {
+	entropy  string
+	mnemonic string
+	seed     string
+}{
+	{
+		"00000000000000000000000000000000",
+		"abandon abandon abandon abandon abandon abandon abandon abandon " +
+			"abandon abandon abandon about",
+		"c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e5349553" +
+			"1f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04",
+	},
+	{
+		"7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f",
+		"legal winner thank year wave sausage worth useful legal winner " +
+			"thank yellow",
+		"2e8905819b8723fe2c1d161860e5ee1830318dbf49a83bd451cfb8440c28bd6f" +
+			"a457fe1296106559a3c80937a1c1069be3a3a5bd381ee6260e8d9739fce1f607",
+	},
+	{
+		"80808080808080808080808080808080",
+		"letter advice cage absurd amount doctor acoustic avoid letter " +
+			"advice cage above",
+		"d71de856f81a8acc65e6fc851a38d4d7ec216fd0796d0a6827a3ad6ed5511a30" +
+			"fa280f12eb2e47ed2ac03b5c462a0358d18d69fe4f985ec81778c1b370b652a8",
+	},
+	{
+		"ffffffffffffffffffffffffffffffff",
+		"zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo wrong",
+		"ac27495480225222079d7be181583751e86f571027b0497b5b5d11218e0a8a13" +
+			"332572917f0f8e5a589620c6f15b11c61dee327651a14c34e18231052e48c069",
+	},
+	{
+		"7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f",
+		"legal winner thank year wave sausage worth useful legal winner " +
+			"thank year wave sausage worth useful legal will",
+		"f2b94508732bcbacbcc020faefecfc89feafa6649a5491b8c952cede496c214a" +
+			"0c7b3c392d168748f2d4a612bada0753b52a1c7ac53c1e93abd5c6320b9e95dd",
+	},
+	{
+		"8080808080808080808080808080808080808080808080808080808080808080",
+		"letter advice cage absurd amount doctor acoustic avoid letter " +
+			"advice cage absurd amount doctor acoustic avoid letter advice " +
+			"cage absurd amount doctor acoustic bless",
+		"c0c519bd0e91a2ed54357d9d1ebef6f5af218a153624cf4f2da911a0ed8f7a09" +
+			"e2ef61af0aca007096df430022f7a2b6fb91661a9589097069720d015e4e982f",
+	},
+	{
+		"ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+		"zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo " +
+			"zoo zoo zoo zoo zoo zoo zoo vote",
+		"dd48c104698c30cfe2b6142103248622fb7bb0ff692eebb00089b32d22484e16" +
+			"13912f0a5b694407be899ffd31ed3992c456cdf60f5d4564b8ba3f05a69890ad",
+	},
+}
+
+func TestVectors(t *testing.T) {
+	if len(wordList) != 2048 {
+		t.Fatalf("wordlist has %d words; expected 2048", len(wordList))
+	}
+	for _, v := range vectors {
+		entropy := mustDecodeHex(t, v.entropy)
+		mnemonic, err := FromEntropy(entropy)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if mnemonic != v.mnemonic {
+			t.Errorf("mnemonic of %s is %q; expected %q",
+				v.entropy, mnemonic, v.mnemonic)
+		}
+		entropy2, err := ToEntropy(v.mnemonic)
+		if err != nil {
+			t.Fatalf("cannot decode %q: %v", v.mnemonic, err)
+		}
+		if !bytes.Equal(entropy2, entropy) {
+			t.Errorf("entropy of %q is %x; expected %x",
+				v.mnemonic, entropy2, entropy)
+		}
+		seed, err := Seed(v.mnemonic, vectorPassphrase)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if expected := mustDecodeHex(t, v.seed); !bytes.Equal(seed, expected) {
+			t.Errorf("seed of %q is %x; expected %x",
+				v.mnemonic, seed, expected)
+		}
+	}
+}
+
+func TestNormalization(t *testing.T) {
+	v := vectors[0]
+	expected, err := Seed(v.mnemonic, "cafe\u0301 pass")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The composed é and the fullwidth letters are not in NFKD.
+	seed, err := Seed(v.mnemonic, "caf\u00e9 \uff50\uff41\uff53\uff53")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(seed, expected) {
+		t.Errorf("seed is %x; expected %x", seed, expected)
+	}
+	// Ideographic spaces, as in Japanese mnemonics, separate words.
+	mnemonic := strings.ReplaceAll(v.mnemonic, " ", "\u3000")
+	if seed, err = Seed(mnemonic, vectorPassphrase); err != nil {
+		t.Fatal(err)
+	}
+	if expected := mustDecodeHex(t, v.seed); !bytes.Equal(seed, expected) {
+		t.Errorf("seed is %x; expected %x", seed, expected)
+	}
+}
+
+func TestToEntropy(t *testing.T) {
+	valid := vectors[1].mnemonic
+	// Extra white space is ignored.
+	spaced := "  " + strings.ReplaceAll(valid, " ", "\n ")
+	if _, err := ToEntropy(spaced); err != nil {
+		t.Errorf("cannot decode mnemonic with extra white space: %v", err)
+	}
+	// Swapping two different words breaks the checksum.
+	words := strings.Fields(valid)
+	words[0], words[1] = words[1], words[0]
+	if _, err := ToEntropy(strings.Join(words, " ")); err != ErrChecksum {
+		t.Errorf("ToEntropy returned %v; expected %v", err, ErrChecksum)
+	}
+	for _, mnemonic := range []string{
+		"",
+		strings.Repeat("abandon ", 11),
+		strings.Repeat("abandon ", 13) + "about",
+		strings.Repeat("abandon ", 11) + "abcdef",
+		strings.Repeat("abandon ", 11) + "About",
+		strings.Repeat("abandon ", 27) + "about",
+	} {
+		if _, err := ToEntropy(mnemonic); err == nil {
+			t.Errorf("ToEntropy accepted %q", mnemonic)
+		}
+	}
+	if _, err := FromEntropy(make([]byte, 18)); err == nil {
+		t.Error("FromEntropy accepted 18 bytes of entropy")
+	}
+}
+
+func TestKeyPackage(t *testing.T) {
+	v := vectors[0]
+	for _, curve := range []hdkey.Curve{
+		hdkey.Secp256k1, hdkey.Ed25519, hdkey.P256,
+	} {
+		t.Run(curve.String(), func(t *testing.T) {
+			pkg, err := KeyPackage(v.mnemonic, vectorPassphrase, curve,
+				hdkey.Provisional, StoreEntropy)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// Encode the key package, as it would be stored.
+			encoded, err := asn1.Marshal(*pkg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var decoded akp.OneAsymmetricKey
+			if _, err = asn1.Unmarshal(encoded, &decoded); err != nil {
+				t.Fatal(err)
+			}
+			mnemonic, err := Mnemonic(&decoded)
+			if err != nil {
+				t.Fatalf("cannot get mnemonic: %v", err)
+			}
+			if mnemonic != v.mnemonic {
+				t.Errorf("mnemonic is %q; expected %q", mnemonic, v.mnemonic)
+			}
+			master, err := hdkey.Unpack(&decoded)
+			if err != nil {
+				t.Fatalf("cannot unpack master key: %v", err)
+			}
+			expected, err := hdkey.NewMaster(curve, mustDecodeHex(t, v.seed))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(master, expected) {
+				t.Errorf("master key is %+v; expected %+v", master, expected)
+			}
+		})
+	}
+	t.Run("NoEntropy", func(t *testing.T) {
+		pkg, err := KeyPackage(v.mnemonic, vectorPassphrase, hdkey.P256,
+			hdkey.Provisional)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = Mnemonic(pkg); err != ErrNoEntropy {
+			t.Errorf("Mnemonic returned %v; expected %v", err, ErrNoEntropy)
+		}
+	})
+	t.Run("NotProvisional", func(t *testing.T) {
+		_, err := KeyPackage(v.mnemonic, vectorPassphrase, hdkey.P256)
+		if err != hdkey.ErrProvisional {
+			t.Errorf("KeyPackage returned %v; expected %v",
+				err, hdkey.ErrProvisional)
+		}
+		_, err = KeyPackage(v.mnemonic, vectorPassphrase, hdkey.P256,
+			StoreEntropy)
+		if err != ErrProvisional {
+			t.Errorf("KeyPackage returned %v; expected %v",
+				err, ErrProvisional)
+		}
+	})
+	t.Run("BadChecksum", func(t *testing.T) {
+		mnemonic := strings.Repeat("abandon ", 11) + "abandon"
+		_, err := KeyPackage(mnemonic, "", hdkey.Secp256k1, hdkey.Provisional)
+		if err != ErrChecksum {
+			t.Errorf("KeyPackage returned %v; expected %v", err, ErrChecksum)
+		}
+	})
+}
+
+func TestGenerateKeyPackage(t *testing.T) {
+	pkg, mnemonic, err := GenerateKeyPackage(rand.Reader, MaxEntropySize,
+		"", hdkey.Ed25519, hdkey.Provisional, StoreEntropy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := len(strings.Fields(mnemonic)); n != 24 {
+		t.Errorf("mnemonic has %d words; expected 24", n)
+	}
+	mnemonic2, err := Mnemonic(pkg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mnemonic2 != mnemonic {
+		t.Errorf("mnemonic of key package is %q; expected %q",
+			mnemonic2, mnemonic)
+	}
+	pkg2, err := KeyPackage(mnemonic, "", hdkey.Ed25519,
+		hdkey.Provisional, StoreEntropy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(pkg2, pkg) {
+		t.Errorf("imported key package %+v is different from %+v", pkg2, pkg)
+	}
+	// Child keys are not generated from entropy.
+	child, err := hdkey.DeriveKeyPackage(pkg, hdkey.Path{hdkey.Hardened})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = Mnemonic(child); err != ErrNoEntropy {
+		t.Errorf("Mnemonic returned %v; expected %v", err, ErrNoEntropy)
+	}
+}
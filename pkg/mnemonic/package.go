@@ -0,0 +1,141 @@
+package mnemonic
+
+import (
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+	"github.com/harmony-one/asym-key-pkgs/pkg/hdkey"
+)
+
+// OIDEntropy is the attribute type of the BIP-39 entropy of a key package,
+// whose value is an OCTET STRING.
+//
+// It is provisional, like the attribute types of package hdkey, and for the
+// same reasons: see the documentation of hdkey.OIDChainCode.
+// KeyPackage only records it with the hdkey.Provisional option.
+var OIDEntropy = asn1.ObjectIdentifier{2, 999, 39, 1}
+
+// storeEntropy is the type of the StoreEntropy option.
+type storeEntropy struct{}
+
+// StoreEntropy is the KeyPackage option that records the entropy of the
+// mnemonic in the key package, so that Mnemonic can emit the mnemonic again.
+//
+// The entropy is recorded in cleartext, in an attribute, next to the
+// private key: anyone who can read the key package can recover the
+// mnemonic, and with the passphrase, every key derived from it,
+// not only the master key.
+// Only use it for key packages that are stored encrypted,
+// or that are as well protected as the mnemonic would be.
+var StoreEntropy storeEntropy
+
+// ErrProvisional means the entropy attribute was requested without the
+// hdkey.Provisional option.
+var ErrProvisional = errors.New(
+	"mnemonic entropy attribute type is provisional; " +
+		"pack with the hdkey.Provisional option")
+
+// ErrNoEntropy means the key package has no entropy attribute,
+// so it has no mnemonic.
+var ErrNoEntropy = errors.New("key package has no mnemonic entropy")
+
+// KeyPackage returns the key package of the master key of the given curve
+// for the mnemonic and the passphrase, after checking the mnemonic.
+//
+// The key package has the chain code and the derivation path attributes of
+// package hdkey, whose types are provisional:
+// the hdkey.Provisional option must be given.
+// With the StoreEntropy option, it also has the entropy attribute,
+// whose type is provisional too: KeyPackage fails with ErrProvisional
+// unless the hdkey.Provisional option is given.
+func KeyPackage(
+	mnemonic, passphrase string, curve hdkey.Curve, options ...interface{},
+) (*akp.OneAsymmetricKey, error) {
+	store, provisional := false, false
+	for _, option := range options {
+		switch option {
+		case StoreEntropy:
+			store = true
+		case hdkey.Provisional:
+			provisional = true
+		}
+	}
+	if store && !provisional {
+		return nil, ErrProvisional
+	}
+	entropy, err := ToEntropy(mnemonic)
+	if err != nil {
+		return nil, err
+	}
+	seed, err := Seed(mnemonic, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	master, err := hdkey.NewMaster(curve, seed)
+	if err != nil {
+		return nil, err
+	}
+	pkg, err := master.Pack(options...)
+	if err != nil {
+		return nil, err
+	}
+	if store {
+		setEntropy(pkg, entropy)
+	}
+	return pkg, nil
+}
+
+// GenerateKeyPackage generates a mnemonic from entropy of the given size
+// in bytes read from rand, and returns it with its key package,
+// as KeyPackage does with the given options.
+func GenerateKeyPackage(
+	rand io.Reader, size int, passphrase string, curve hdkey.Curve,
+	options ...interface{},
+) (pkg *akp.OneAsymmetricKey, mnemonic string, err error) {
+	entropy, err := GenerateEntropy(rand, size)
+	if err != nil {
+		return nil, "", err
+	}
+	if mnemonic, err = FromEntropy(entropy); err != nil {
+		return nil, "", err
+	}
+	pkg, err = KeyPackage(mnemonic, passphrase, curve, options...)
+	if err != nil {
+		return nil, "", err
+	}
+	return pkg, mnemonic, nil
+}
+
+func setEntropy(pkg *akp.OneAsymmetricKey, entropy []byte) {
+	pkg.SetAttribute(OIDEntropy, asn1.RawValue{
+		Class: asn1.ClassUniversal, Tag: asn1.TagOctetString, Bytes: entropy,
+	})
+}
+
+// Mnemonic returns the mnemonic of a key package made by KeyPackage or
+// GenerateKeyPackage with the StoreEntropy option,
+// from its entropy attribute.
+func Mnemonic(pkg *akp.OneAsymmetricKey) (string, error) {
+	attr := pkg.Attribute(OIDEntropy)
+	if attr == nil {
+		return "", ErrNoEntropy
+	}
+	if len(attr.Values) != 1 {
+		return "", errors.New("entropy attribute has multiple values")
+	}
+	der, err := asn1.Marshal(attr.Values[0])
+	if err != nil {
+		return "", err
+	}
+	var entropy []byte
+	rest, err := asn1.Unmarshal(der, &entropy)
+	if err != nil {
+		return "", fmt.Errorf("cannot parse entropy: %w", err)
+	} else if len(rest) > 0 {
+		return "", errors.New("trailing data after entropy")
+	}
+	return FromEntropy(entropy)
+}
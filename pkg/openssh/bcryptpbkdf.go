@@ -0,0 +1,88 @@
+package openssh
+
+import (
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+
+	"golang.org/x/crypto/blowfish"
+)
+
+// bcryptPBKDF derives a key from a password as done by OpenBSD's
+// bcrypt_pbkdf(3), which OpenSSH uses to encrypt private keys.
+func bcryptPBKDF(password, salt []byte, rounds, keyLen int) ([]byte, error) {
+	if rounds < 1 {
+		return nil, errors.New("bcrypt_pbkdf: number of rounds too small")
+	}
+	if len(password) == 0 {
+		return nil, errors.New("bcrypt_pbkdf: empty password")
+	}
+	if len(salt) == 0 || len(salt) > 1<<20 {
+		return nil, errors.New("bcrypt_pbkdf: bad salt length")
+	}
+	if keyLen <= 0 || keyLen > 1024 {
+		return nil, errors.New("bcrypt_pbkdf: bad key length")
+	}
+	numBlocks := (keyLen + bcryptHashSize - 1) / bcryptHashSize
+	key := make([]byte, numBlocks*bcryptHashSize)
+	h := sha512.New()
+	h.Write(password)
+	shaPass := h.Sum(nil)
+	var cnt [4]byte
+	shaSalt := make([]byte, 0, sha512.Size)
+	tmp := make([]byte, bcryptHashSize)
+	out := make([]byte, bcryptHashSize)
+	for block := 1; block <= numBlocks; block++ {
+		h.Reset()
+		h.Write(salt)
+		binary.BigEndian.PutUint32(cnt[:], uint32(block))
+		h.Write(cnt[:])
+		if err := bcryptHash(tmp, shaPass, h.Sum(shaSalt[:0])); err != nil {
+			return nil, err
+		}
+		copy(out, tmp)
+		for i := 2; i <= rounds; i++ {
+			h.Reset()
+			h.Write(tmp)
+			if err := bcryptHash(tmp, shaPass, h.Sum(shaSalt[:0])); err != nil {
+				return nil, err
+			}
+			for j := range out {
+				out[j] ^= tmp[j]
+			}
+		}
+		// The output bytes are interleaved across blocks.
+		for i, v := range out {
+			key[i*numBlocks+(block-1)] = v
+		}
+	}
+	return key[:keyLen], nil
+}
+
+const bcryptHashSize = 32
+
+var bcryptMagic = []byte("OxychromaticBlowfishSwatDynamite")
+
+func bcryptHash(out, shaPass, shaSalt []byte) error {
+	c, err := blowfish.NewSaltedCipher(shaPass, shaSalt)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < 64; i++ {
+		blowfish.ExpandKey(shaSalt, c)
+		blowfish.ExpandKey(shaPass, c)
+	}
+	copy(out, bcryptMagic)
+	for i := 0; i < bcryptHashSize; i += 8 {
+		for j := 0; j < 64; j++ {
+			c.Encrypt(out[i:i+8], out[i:i+8])
+		}
+	}
+	// Blowfish works on big-endian words, but bcrypt_pbkdf outputs
+	// little-endian ones.
+	for i := 0; i < bcryptHashSize; i += 4 {
+		out[i], out[i+1], out[i+2], out[i+3] =
+			out[i+3], out[i+2], out[i+1], out[i]
+	}
+	return nil
+}
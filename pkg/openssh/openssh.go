@@ -0,0 +1,296 @@
+// Package openssh converts between key packages and the OpenSSH private key
+// format ("openssh-key-v1", see PROTOCOL.key in the OpenSSH distribution).
+//
+// Key types are handled by the packers registered with akp.Packers that
+// implement akp.SSHPrivateKeyCodec and akp.SSHPublicKeyMarshaler.
+// The key comment maps onto the friendlyName attribute of the key package.
+package openssh
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/pem"
+	"errors"
+	"fmt"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+	"github.com/harmony-one/asym-key-pkgs/pkg/internal/sshwire"
+)
+
+// PEMType is the PEM block type of OpenSSH private keys.
+const PEMType = "OPENSSH PRIVATE KEY"
+
+const magic = "openssh-key-v1\x00"
+
+// DefaultRounds is the default number of bcrypt_pbkdf rounds,
+// the same as ssh-keygen(1) uses.
+const DefaultRounds = 16
+
+// ErrPassphraseRequired means the private key is encrypted,
+// but no passphrase was given.
+var ErrPassphraseRequired = errors.New("private key is encrypted; " +
+	"passphrase required")
+
+// ErrIncorrectPassphrase means the private key could not be decrypted
+// with the given passphrase.
+var ErrIncorrectPassphrase = errors.New("incorrect passphrase")
+
+// Supported ciphers and their key lengths.
+var cipherKeyLens = map[string]int{
+	"aes128-ctr": 16,
+	"aes192-ctr": 24,
+	"aes256-ctr": 32,
+}
+
+// Cipher is the cipher used to encrypt private keys.
+const Cipher = "aes256-ctr"
+
+// Decode decodes a PEM-encoded OpenSSH private key into a key package.
+//
+// The passphrase is used only if the private key is encrypted.
+func Decode(data []byte, passphrase []byte) (*akp.OneAsymmetricKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+	if block.Type != PEMType {
+		return nil, fmt.Errorf("unexpected PEM block type %q", block.Type)
+	}
+	return Unmarshal(block.Bytes, passphrase)
+}
+
+// Unmarshal converts a binary OpenSSH private key into a key package.
+//
+// The passphrase is used only if the private key is encrypted.
+func Unmarshal(data []byte, passphrase []byte) (
+	*akp.OneAsymmetricKey, error,
+) {
+	if !bytes.HasPrefix(data, []byte(magic)) {
+		return nil, errors.New("not an openssh-key-v1 private key")
+	}
+	p := sshwire.NewParser(data[len(magic):])
+	cipherName, err := p.String()
+	if err != nil {
+		return nil, err
+	}
+	kdfName, err := p.String()
+	if err != nil {
+		return nil, err
+	}
+	kdfOptions, err := p.String()
+	if err != nil {
+		return nil, err
+	}
+	n, err := p.Uint32()
+	if err != nil {
+		return nil, err
+	}
+	if n != 1 {
+		return nil, fmt.Errorf("%d keys in file; expected one", n)
+	}
+	pubBlob, err := p.String()
+	if err != nil {
+		return nil, err
+	}
+	privBlob, err := p.String()
+	if err != nil {
+		return nil, err
+	}
+	blockSize := 8
+	encrypted := string(cipherName) != "none"
+	if encrypted {
+		keyLen, ok := cipherKeyLens[string(cipherName)]
+		if !ok {
+			return nil, fmt.Errorf("unsupported cipher %q", cipherName)
+		}
+		if string(kdfName) != "bcrypt" {
+			return nil, fmt.Errorf("unsupported KDF %q", kdfName)
+		}
+		if len(passphrase) == 0 {
+			return nil, ErrPassphraseRequired
+		}
+		privBlob, err = crypt(keyLen, kdfOptions, passphrase, privBlob)
+		if err != nil {
+			return nil, err
+		}
+		blockSize = aes.BlockSize
+	} else if string(kdfName) != "none" {
+		return nil, fmt.Errorf("unexpected KDF %q for cleartext key", kdfName)
+	}
+	if len(privBlob)%blockSize != 0 {
+		return nil, errors.New("private key section not padded to block size")
+	}
+	return unmarshalPrivate(privBlob, pubBlob, encrypted)
+}
+
+func unmarshalPrivate(privBlob, pubBlob []byte, encrypted bool) (
+	*akp.OneAsymmetricKey, error,
+) {
+	p := sshwire.NewParser(privBlob)
+	check1, err1 := p.Uint32()
+	check2, err2 := p.Uint32()
+	if err1 != nil || err2 != nil || check1 != check2 {
+		if encrypted {
+			return nil, ErrIncorrectPassphrase
+		}
+		return nil, errors.New("corrupt private key section")
+	}
+	keyType, err := p.String()
+	if err != nil {
+		return nil, err
+	}
+	codec := akp.Packers.SSHPrivateKeyCodec(string(keyType))
+	if codec == nil {
+		return nil, fmt.Errorf("unsupported SSH key type %q", keyType)
+	}
+	priv, pub, rest, err := codec.UnmarshalSSHPrivateKey(string(keyType),
+		p.Rest())
+	if err != nil {
+		return nil, err
+	}
+	p = sshwire.NewParser(rest)
+	comment, err := p.String()
+	if err != nil {
+		return nil, err
+	}
+	for i, b := range p.Rest() {
+		if b != byte(i+1) {
+			return nil, errors.New("bad private key section padding")
+		}
+	}
+	pubBlob2, err := akp.Packers.MarshalSSHPublicKey(priv, pub)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(pubBlob, pubBlob2) {
+		return nil, errors.New("public key does not match private key")
+	}
+	pkg, err := akp.Pack(priv, pub)
+	if err != nil {
+		return nil, err
+	}
+	pkg.SetFriendlyName(string(comment))
+	return pkg, nil
+}
+
+// Encode converts a key package into a PEM-encoded OpenSSH private key.
+//
+// If passphrase is not empty,
+// the private key is encrypted with aes256-ctr and a key derived by
+// bcrypt_pbkdf with the given number of rounds (DefaultRounds if 0).
+func Encode(
+	pkg *akp.OneAsymmetricKey, passphrase []byte, rounds int,
+) ([]byte, error) {
+	data, err := Marshal(pkg, passphrase, rounds)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: PEMType, Bytes: data}), nil
+}
+
+// Marshal converts a key package into a binary OpenSSH private key.
+//
+// See Encode for the meaning of passphrase and rounds.
+func Marshal(
+	pkg *akp.OneAsymmetricKey, passphrase []byte, rounds int,
+) ([]byte, error) {
+	priv, pub, _, err := akp.Unpack(pkg)
+	if err != nil {
+		return nil, err
+	}
+	if pub == nil {
+		if pub, err = akp.DerivePublicKey(priv); err != nil {
+			return nil, err
+		}
+	}
+	keyType, fields, err := akp.Packers.MarshalSSHPrivateKey(priv)
+	if err == akp.ErrSkip {
+		return nil, fmt.Errorf("%T has no OpenSSH private key format", priv)
+	}
+	if err != nil {
+		return nil, err
+	}
+	pubBlob, err := akp.Packers.MarshalSSHPublicKey(priv, pub)
+	if err != nil {
+		return nil, err
+	}
+	comment, _ := pkg.FriendlyName()
+
+	var check [4]byte
+	if _, err = rand.Read(check[:]); err != nil {
+		return nil, err
+	}
+	var section sshwire.Builder
+	section.Raw(check[:]).Raw(check[:])
+	section.String([]byte(keyType)).Raw(fields).String([]byte(comment))
+
+	var cipherName, kdfName string
+	var kdfOptions []byte
+	blockSize := 8
+	if len(passphrase) == 0 {
+		cipherName, kdfName = "none", "none"
+	} else {
+		cipherName, kdfName = Cipher, "bcrypt"
+		blockSize = aes.BlockSize
+		if rounds == 0 {
+			rounds = DefaultRounds
+		}
+		salt := make([]byte, 16)
+		if _, err = rand.Read(salt); err != nil {
+			return nil, err
+		}
+		var opts sshwire.Builder
+		opts.String(salt).Uint32(uint32(rounds))
+		kdfOptions = opts.Bytes()
+	}
+	privBlob := section.Bytes()
+	for i := 1; len(privBlob)%blockSize != 0; i++ {
+		privBlob = append(privBlob, byte(i))
+	}
+	if len(passphrase) > 0 {
+		privBlob, err = crypt(cipherKeyLens[Cipher], kdfOptions, passphrase,
+			privBlob)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var b sshwire.Builder
+	b.Raw([]byte(magic))
+	b.String([]byte(cipherName)).String([]byte(kdfName)).String(kdfOptions)
+	b.Uint32(1)
+	b.String(pubBlob)
+	b.String(privBlob)
+	return b.Bytes(), nil
+}
+
+// crypt encrypts or decrypts (these are the same in CTR mode) the private
+// key section.
+func crypt(keyLen int, kdfOptions, passphrase, data []byte) ([]byte, error) {
+	p := sshwire.NewParser(kdfOptions)
+	salt, err := p.String()
+	if err != nil {
+		return nil, err
+	}
+	rounds, err := p.Uint32()
+	if err != nil {
+		return nil, err
+	}
+	if rounds > 1<<20 {
+		return nil, fmt.Errorf("too many bcrypt_pbkdf rounds (%d)", rounds)
+	}
+	keyIV, err := bcryptPBKDF(passphrase, salt, int(rounds),
+		keyLen+aes.BlockSize)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(keyIV[:keyLen])
+	if err != nil {
+		return nil, err
+	}
+	out := make([]byte, len(data))
+	cipher.NewCTR(block, keyIV[keyLen:]).XORKeyStream(out, data)
+	return out, nil
+}
@@ -0,0 +1,118 @@
+package openssh
+
+import (
+	"crypto/dsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"reflect"
+	"testing"
+
+	"golang.org/x/crypto/ssh"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+	_ "github.com/harmony-one/asym-key-pkgs/pkg/algo/dsa"
+	_ "github.com/harmony-one/asym-key-pkgs/pkg/algo/rsa"
+)
+
+func generateKeys(t *testing.T) []interface{} {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatalf("cannot generate RSA key pair: %v", err)
+	}
+	var dsaKey dsa.PrivateKey
+	err = dsa.GenerateParameters(&dsaKey.Parameters, rand.Reader, dsa.L1024N160)
+	if err != nil {
+		t.Fatalf("cannot generate DSA parameters: %v", err)
+	}
+	if err = dsa.GenerateKey(&dsaKey, rand.Reader); err != nil {
+		t.Fatalf("cannot generate DSA key pair: %v", err)
+	}
+	return []interface{}{rsaKey, &dsaKey}
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, priv := range generateKeys(t) {
+		for _, passphrase := range [][]byte{nil, []byte("s3cr3t")} {
+			pkg, err := akp.Pack(priv, nil)
+			if err != nil {
+				t.Fatalf("cannot pack %T: %v", priv, err)
+			}
+			pkg.SetFriendlyName("alice@example.com")
+			encoded, err := Encode(pkg, passphrase, 0)
+			if err != nil {
+				t.Fatalf("cannot encode %T: %v", priv, err)
+			}
+			if passphrase != nil {
+				if _, err = Decode(encoded, nil); err != ErrPassphraseRequired {
+					t.Errorf("Decode returned %+v; expected %+v",
+						err, ErrPassphraseRequired)
+				}
+				_, err = Decode(encoded, []byte("wrong"))
+				if err != ErrIncorrectPassphrase {
+					t.Errorf("Decode returned %+v; expected %+v",
+						err, ErrIncorrectPassphrase)
+				}
+			}
+			pkg2, err := Decode(encoded, passphrase)
+			if err != nil {
+				t.Fatalf("cannot decode %T: %v", priv, err)
+			}
+			if name, _ := pkg2.FriendlyName(); name != "alice@example.com" {
+				t.Errorf("comment is %q; expected %q", name, "alice@example.com")
+			}
+			priv2, _, _, err := akp.Unpack(pkg2)
+			if err != nil {
+				t.Fatalf("cannot unpack %T: %v", priv, err)
+			}
+			if !reflect.DeepEqual(priv, priv2) {
+				t.Errorf("decoded key %+v is different from the original %+v",
+					priv2, priv)
+			}
+		}
+	}
+}
+
+func TestInterop(t *testing.T) {
+	priv, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatalf("cannot generate RSA key pair: %v", err)
+	}
+	t.Run("Encode", func(t *testing.T) {
+		pkg, err := akp.Pack(priv, nil)
+		if err != nil {
+			t.Fatalf("cannot pack RSA key pair: %v", err)
+		}
+		encoded, err := Encode(pkg, []byte("pw"), 0)
+		if err != nil {
+			t.Fatalf("cannot encode RSA key pair: %v", err)
+		}
+		priv2, err := ssh.ParseRawPrivateKeyWithPassphrase(encoded, []byte("pw"))
+		if err != nil {
+			t.Fatalf("x/crypto/ssh cannot parse key: %v", err)
+		}
+		if !priv.Equal(priv2) {
+			t.Errorf("x/crypto/ssh parsed a different key")
+		}
+	})
+	t.Run("Decode", func(t *testing.T) {
+		block, err := ssh.MarshalPrivateKeyWithPassphrase(priv, "bob",
+			[]byte("pw"))
+		if err != nil {
+			t.Fatalf("x/crypto/ssh cannot marshal key: %v", err)
+		}
+		pkg, err := Unmarshal(block.Bytes, []byte("pw"))
+		if err != nil {
+			t.Fatalf("cannot unmarshal key: %v", err)
+		}
+		if name, _ := pkg.FriendlyName(); name != "bob" {
+			t.Errorf("comment is %q; expected %q", name, "bob")
+		}
+		priv2, _, _, err := akp.Unpack(pkg)
+		if err != nil {
+			t.Fatalf("cannot unpack RSA key pair: %v", err)
+		}
+		if !priv.Equal(priv2) {
+			t.Errorf("unmarshaled a different key")
+		}
+	})
+}
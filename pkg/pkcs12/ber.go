@@ -0,0 +1,161 @@
+package pkcs12
+
+import (
+	"errors"
+)
+
+// berToDER converts BER-encoded data, as produced by some PKCS #12
+// implementations, to DER:
+// Indefinite lengths become definite,
+// and constructed OCTET STRINGs become primitive.
+//
+// It does not sort SET OF elements or fix other non-DER encodings,
+// which encoding/asn1 tolerates.
+func berToDER(ber []byte) ([]byte, error) {
+	der, rest, err := convertBER(ber, 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) > 0 {
+		return nil, errors.New("ber: trailing data")
+	}
+	return der, nil
+}
+
+const maxBERDepth = 64
+
+var errBERTruncated = errors.New("ber: truncated")
+
+// convertBER converts one BER value at the start of ber,
+// and returns it along with the data after it.
+func convertBER(ber []byte, depth int) (der []byte, rest []byte, err error) {
+	if depth > maxBERDepth {
+		return nil, nil, errors.New("ber: nested too deeply")
+	}
+	if len(ber) < 2 {
+		return nil, nil, errBERTruncated
+	}
+	// Identifier octets
+	n := 1
+	if ber[0]&0x1f == 0x1f {
+		for {
+			if n >= len(ber) {
+				return nil, nil, errBERTruncated
+			}
+			n++
+			if ber[n-1]&0x80 == 0 {
+				break
+			}
+		}
+	}
+	tag := ber[:n]
+	constructed := ber[0]&0x20 != 0
+	if n >= len(ber) {
+		return nil, nil, errBERTruncated
+	}
+	// Length octets
+	lb := ber[n]
+	n++
+	indefinite := lb == 0x80
+	length := 0
+	if lb&0x80 == 0 {
+		length = int(lb)
+	} else if !indefinite {
+		numBytes := int(lb & 0x7f)
+		if numBytes > 4 || n+numBytes > len(ber) {
+			return nil, nil, errors.New("ber: bad length")
+		}
+		for _, b := range ber[n : n+numBytes] {
+			length = length<<8 | int(b)
+		}
+		n += numBytes
+	}
+	body := ber[n:]
+	if !indefinite {
+		if length > len(body) {
+			return nil, nil, errBERTruncated
+		}
+		body, rest = body[:length], body[length:]
+	}
+	if !constructed {
+		if indefinite {
+			return nil, nil, errors.New("ber: indefinite-length primitive")
+		}
+		return appendTLV(nil, tag, body), rest, nil
+	}
+	var content []byte
+	for {
+		if indefinite {
+			if len(body) < 2 {
+				return nil, nil, errBERTruncated
+			}
+			if body[0] == 0 && body[1] == 0 {
+				rest = body[2:]
+				break
+			}
+		} else if len(body) == 0 {
+			break
+		}
+		var child []byte
+		if child, body, err = convertBER(body, depth+1); err != nil {
+			return nil, nil, err
+		}
+		content = append(content, child...)
+	}
+	// A constructed OCTET STRING (universal tag 4) becomes primitive,
+	// with the concatenated contents of its segments.
+	if len(tag) == 1 && tag[0] == 0x24 {
+		var octets []byte
+		for len(content) > 0 {
+			_, segment, next, err := splitTLV(content)
+			if err != nil {
+				return nil, nil, err
+			}
+			octets = append(octets, segment...)
+			content = next
+		}
+		return appendTLV(nil, []byte{0x04}, octets), rest, nil
+	}
+	return appendTLV(nil, tag, content), rest, nil
+}
+
+// splitTLV splits a DER value into its tag, contents and the data after it.
+func splitTLV(der []byte) (tag, content, rest []byte, err error) {
+	if len(der) < 2 || der[0]&0x1f == 0x1f {
+		return nil, nil, nil, errors.New("ber: unexpected segment")
+	}
+	tag, n := der[:1], 2
+	length := int(der[1])
+	if der[1]&0x80 != 0 {
+		numBytes := int(der[1] & 0x7f)
+		if numBytes > 4 || n+numBytes > len(der) {
+			return nil, nil, nil, errors.New("ber: bad length")
+		}
+		length = 0
+		for _, b := range der[n : n+numBytes] {
+			length = length<<8 | int(b)
+		}
+		n += numBytes
+	}
+	if n+length > len(der) {
+		return nil, nil, nil, errBERTruncated
+	}
+	return tag, der[n : n+length], der[n+length:], nil
+}
+
+func appendTLV(b []byte, tag []byte, content []byte) []byte {
+	b = append(b, tag...)
+	switch l := len(content); {
+	case l < 0x80:
+		b = append(b, byte(l))
+	case l < 0x100:
+		b = append(b, 0x81, byte(l))
+	case l < 0x10000:
+		b = append(b, 0x82, byte(l>>8), byte(l))
+	case l < 0x1000000:
+		b = append(b, 0x83, byte(l>>16), byte(l>>8), byte(l))
+	default:
+		b = append(b, 0x84, byte(l>>24), byte(l>>16), byte(l>>8), byte(l))
+	}
+	return append(b, content...)
+}
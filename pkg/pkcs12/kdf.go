@@ -0,0 +1,79 @@
+package pkcs12
+
+import (
+	"hash"
+	"math/big"
+	"unicode/utf16"
+)
+
+// bmpPassword encodes a password as a NUL-terminated BMPString,
+// as the PKCS #12 key derivation function expects (RFC 7292, appendix B.1).
+func bmpPassword(password string) []byte {
+	units := utf16.Encode([]rune(password))
+	b := make([]byte, 0, 2*len(units)+2)
+	for _, u := range units {
+		b = append(b, byte(u>>8), byte(u))
+	}
+	return append(b, 0, 0)
+}
+
+// PKCS #12 key derivation purposes (RFC 7292, appendix B.3).
+const (
+	kdfKey byte = 1
+	kdfIV  byte = 2
+	kdfMAC byte = 3
+)
+
+// pkcs12KDF implements the PKCS #12 key derivation function
+// (RFC 7292, appendix B.2).
+//
+// password must already be in BMPString form (see bmpPassword).
+func pkcs12KDF(
+	newHash func() hash.Hash, salt, password []byte, iterations int,
+	id byte, size int,
+) []byte {
+	h := newHash()
+	u, v := h.Size(), h.BlockSize()
+	d := make([]byte, v)
+	for i := range d {
+		d[i] = id
+	}
+	fill := func(s []byte) []byte {
+		if len(s) == 0 {
+			return nil
+		}
+		out := make([]byte, v*((len(s)+v-1)/v))
+		for i := range out {
+			out[i] = s[i%len(s)]
+		}
+		return out
+	}
+	i := append(fill(salt), fill(password)...)
+	one := big.NewInt(1)
+	mod := new(big.Int).Lsh(one, uint(8*v))
+	var out []byte
+	for len(out) < size {
+		h.Reset()
+		h.Write(d)
+		h.Write(i)
+		a := h.Sum(nil)
+		for r := 1; r < iterations; r++ {
+			h.Reset()
+			h.Write(a)
+			a = h.Sum(a[:0])
+		}
+		out = append(out, a...)
+		if len(out) >= size {
+			break
+		}
+		// I_j = (I_j + B + 1) mod 2^(8v) for each v-byte block I_j of I.
+		b := new(big.Int).SetBytes(fill(a[:u])[:v])
+		b.Add(b, one)
+		for j := 0; j < len(i); j += v {
+			ij := new(big.Int).SetBytes(i[j : j+v])
+			ij.Add(ij, b).Mod(ij, mod)
+			ij.FillBytes(i[j : j+v])
+		}
+	}
+	return out[:size]
+}
@@ -0,0 +1,297 @@
+package pkcs12
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/des"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha1" // nolint: legacy PKCS #12 PBE schemes use SHA-1
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"hash"
+)
+
+// Algorithm OIDs
+var (
+	oidPBEWithSHAAnd3KeyTripleDESCBC = asn1.ObjectIdentifier{
+		1, 2, 840, 113549, 1, 12, 1, 3,
+	}
+	oidPBEWithSHAAnd128BitRC2CBC = asn1.ObjectIdentifier{
+		1, 2, 840, 113549, 1, 12, 1, 5,
+	}
+	oidPBEWithSHAAnd40BitRC2CBC = asn1.ObjectIdentifier{
+		1, 2, 840, 113549, 1, 12, 1, 6,
+	}
+	oidPBES2      = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 13}
+	oidPBKDF2     = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 12}
+	oidHMACSHA1   = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 7}
+	oidHMACSHA256 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 9}
+	oidHMACSHA384 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 10}
+	oidHMACSHA512 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 11}
+	oidAES128CBC  = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 2}
+	oidAES192CBC  = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 22}
+	oidAES256CBC  = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 42}
+	oidDESEDE3CBC = asn1.ObjectIdentifier{1, 2, 840, 113549, 3, 7}
+	oidSHA1       = asn1.ObjectIdentifier{1, 3, 14, 3, 2, 26}
+	oidSHA256     = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 1}
+	oidSHA384     = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 2}
+	oidSHA512     = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 3}
+)
+
+// ErrIncorrectPassword means data could not be decrypted or verified with
+// the given password.
+var ErrIncorrectPassword = errors.New("pkcs12: incorrect password")
+
+// pbeParams is the PKCS #12 PBE parameter structure (RFC 7292, appendix C).
+type pbeParams struct {
+	Salt       []byte
+	Iterations int
+}
+
+// pbes2Params is PBES2-params (RFC 8018, appendix A.4).
+type pbes2Params struct {
+	KeyDerivationFunc pkix.AlgorithmIdentifier
+	EncryptionScheme  pkix.AlgorithmIdentifier
+}
+
+// pbkdf2Params is PBKDF2-params (RFC 8018, appendix A.2).
+type pbkdf2Params struct {
+	Salt       []byte
+	Iterations int
+	KeyLength  int                      `asn1:"optional"`
+	PRF        pkix.AlgorithmIdentifier `asn1:"optional"`
+}
+
+// decrypt decrypts data encrypted with the given password-based encryption
+// scheme.
+func decrypt(
+	alg pkix.AlgorithmIdentifier, password string, data []byte,
+) ([]byte, error) {
+	block, iv, err := pbeCipher(alg, password)
+	if err != nil {
+		return nil, err
+	}
+	bs := block.BlockSize()
+	if len(data) == 0 || len(data)%bs != 0 {
+		return nil, errors.New("pkcs12: encrypted data not a multiple of " +
+			"the block size")
+	}
+	out := make([]byte, len(data))
+	cipher.NewCBCDecrypter(block, iv).CryptBlocks(out, data)
+	// PKCS #7 padding
+	pad := int(out[len(out)-1])
+	if pad == 0 || pad > bs ||
+		!bytes.Equal(out[len(out)-pad:], bytes.Repeat([]byte{byte(pad)}, pad)) {
+		return nil, ErrIncorrectPassword
+	}
+	return out[:len(out)-pad], nil
+}
+
+func pbeCipher(alg pkix.AlgorithmIdentifier, password string) (
+	block cipher.Block, iv []byte, err error,
+) {
+	switch {
+	case alg.Algorithm.Equal(oidPBES2):
+		return pbes2Cipher(alg.Parameters.FullBytes, password)
+	case alg.Algorithm.Equal(oidPBEWithSHAAnd3KeyTripleDESCBC),
+		alg.Algorithm.Equal(oidPBEWithSHAAnd128BitRC2CBC),
+		alg.Algorithm.Equal(oidPBEWithSHAAnd40BitRC2CBC):
+	default:
+		return nil, nil, fmt.Errorf("pkcs12: unsupported encryption "+
+			"algorithm %v", alg.Algorithm)
+	}
+	var params pbeParams
+	if err = unmarshalExact(alg.Parameters.FullBytes, &params); err != nil {
+		return nil, nil, err
+	}
+	if err = checkIterations(params.Iterations); err != nil {
+		return nil, nil, err
+	}
+	pw := bmpPassword(password)
+	derive := func(id byte, size int) []byte {
+		return pkcs12KDF(sha1.New, params.Salt, pw, params.Iterations, id, size)
+	}
+	switch {
+	case alg.Algorithm.Equal(oidPBEWithSHAAnd3KeyTripleDESCBC):
+		block, err = des.NewTripleDESCipher(derive(kdfKey, 24))
+	case alg.Algorithm.Equal(oidPBEWithSHAAnd128BitRC2CBC):
+		block = newRC2Cipher(derive(kdfKey, 16), 128)
+	default:
+		block = newRC2Cipher(derive(kdfKey, 5), 40)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return block, derive(kdfIV, 8), nil
+}
+
+func pbes2Cipher(der []byte, password string) (
+	block cipher.Block, iv []byte, err error,
+) {
+	var params pbes2Params
+	if err = unmarshalExact(der, &params); err != nil {
+		return nil, nil, err
+	}
+	if !params.KeyDerivationFunc.Algorithm.Equal(oidPBKDF2) {
+		return nil, nil, fmt.Errorf("pkcs12: unsupported KDF %v",
+			params.KeyDerivationFunc.Algorithm)
+	}
+	var kdfParams pbkdf2Params
+	err = unmarshalExact(params.KeyDerivationFunc.Parameters.FullBytes,
+		&kdfParams)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = checkIterations(kdfParams.Iterations); err != nil {
+		return nil, nil, err
+	}
+	prf := sha1.New
+	if kdfParams.PRF.Algorithm != nil {
+		if prf = hmacHash(kdfParams.PRF.Algorithm); prf == nil {
+			return nil, nil, fmt.Errorf("pkcs12: unsupported PRF %v",
+				kdfParams.PRF.Algorithm)
+		}
+	}
+	scheme := params.EncryptionScheme
+	var keyLen int
+	newCipher := aes.NewCipher
+	switch {
+	case scheme.Algorithm.Equal(oidAES128CBC):
+		keyLen = 16
+	case scheme.Algorithm.Equal(oidAES192CBC):
+		keyLen = 24
+	case scheme.Algorithm.Equal(oidAES256CBC):
+		keyLen = 32
+	case scheme.Algorithm.Equal(oidDESEDE3CBC):
+		keyLen, newCipher = 24, des.NewTripleDESCipher
+	default:
+		return nil, nil, fmt.Errorf("pkcs12: unsupported cipher %v",
+			scheme.Algorithm)
+	}
+	if kdfParams.KeyLength != 0 && kdfParams.KeyLength != keyLen {
+		return nil, nil, errors.New("pkcs12: PBKDF2 key length mismatch")
+	}
+	if err = unmarshalExact(scheme.Parameters.FullBytes, &iv); err != nil {
+		return nil, nil, err
+	}
+	// PBES2 takes the password as UTF-8 rather than as a BMPString,
+	// as OpenSSL does (see also RFC 9579, section 3).
+	key, err := pbkdf2.Key(prf, password, kdfParams.Salt,
+		kdfParams.Iterations, keyLen)
+	if err != nil {
+		return nil, nil, err
+	}
+	if block, err = newCipher(key); err != nil {
+		return nil, nil, err
+	}
+	if len(iv) != block.BlockSize() {
+		return nil, nil, errors.New("pkcs12: bad IV length")
+	}
+	return block, iv, nil
+}
+
+// encrypt encrypts data with PBES2, using PBKDF2 with HMAC-SHA256 and
+// AES-256-CBC.
+func encrypt(password string, iterations int, data []byte) (
+	alg pkix.AlgorithmIdentifier, encrypted []byte, err error,
+) {
+	salt := make([]byte, 16)
+	iv := make([]byte, aes.BlockSize)
+	if _, err = rand.Read(salt); err != nil {
+		return
+	}
+	if _, err = rand.Read(iv); err != nil {
+		return
+	}
+	kdfParams, err := asn1.Marshal(pbkdf2Params{
+		Salt: salt, Iterations: iterations,
+		PRF: pkix.AlgorithmIdentifier{
+			Algorithm: oidHMACSHA256, Parameters: asn1.NullRawValue,
+		},
+	})
+	if err != nil {
+		return
+	}
+	ivBytes, err := asn1.Marshal(iv)
+	if err != nil {
+		return
+	}
+	params, err := asn1.Marshal(pbes2Params{
+		KeyDerivationFunc: pkix.AlgorithmIdentifier{
+			Algorithm: oidPBKDF2, Parameters: asn1.RawValue{FullBytes: kdfParams},
+		},
+		EncryptionScheme: pkix.AlgorithmIdentifier{
+			Algorithm: oidAES256CBC, Parameters: asn1.RawValue{FullBytes: ivBytes},
+		},
+	})
+	if err != nil {
+		return
+	}
+	alg = pkix.AlgorithmIdentifier{
+		Algorithm: oidPBES2, Parameters: asn1.RawValue{FullBytes: params},
+	}
+	block, _, err := pbes2Cipher(params, password)
+	if err != nil {
+		return
+	}
+	pad := aes.BlockSize - len(data)%aes.BlockSize
+	encrypted = append(append([]byte(nil), data...),
+		bytes.Repeat([]byte{byte(pad)}, pad)...)
+	cipher.NewCBCEncrypter(block, iv).CryptBlocks(encrypted, encrypted)
+	return alg, encrypted, nil
+}
+
+func hmacHash(oid asn1.ObjectIdentifier) func() hash.Hash {
+	switch {
+	case oid.Equal(oidHMACSHA1):
+		return sha1.New
+	case oid.Equal(oidHMACSHA256):
+		return sha256.New
+	case oid.Equal(oidHMACSHA384):
+		return sha512.New384
+	case oid.Equal(oidHMACSHA512):
+		return sha512.New
+	}
+	return nil
+}
+
+func digestHash(oid asn1.ObjectIdentifier) func() hash.Hash {
+	switch {
+	case oid.Equal(oidSHA1):
+		return sha1.New
+	case oid.Equal(oidSHA256):
+		return sha256.New
+	case oid.Equal(oidSHA384):
+		return sha512.New384
+	case oid.Equal(oidSHA512):
+		return sha512.New
+	}
+	return nil
+}
+
+// maxIterations bounds the work that a malicious file can demand.
+const maxIterations = 1 << 24
+
+func checkIterations(iterations int) error {
+	if iterations < 1 || iterations > maxIterations {
+		return fmt.Errorf("pkcs12: iteration count %d out of range", iterations)
+	}
+	return nil
+}
+
+func unmarshalExact(der []byte, val interface{}) error {
+	rest, err := asn1.Unmarshal(der, val)
+	if err != nil {
+		return err
+	}
+	if len(rest) > 0 {
+		return errors.New("pkcs12: trailing data")
+	}
+	return nil
+}
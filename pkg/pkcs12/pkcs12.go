@@ -0,0 +1,418 @@
+// Package pkcs12 converts between key packages and PKCS #12 (PFX) files
+// (RFC 7292).
+//
+// Decode extracts the keyBag and pkcs8ShroudedKeyBag entries as key packages,
+// with their bag attributes, such as friendlyName and localKeyId,
+// carried over into the Attributes of the key packages,
+// and the certBag entries as certificates.
+// Encode does the reverse, encrypting with PBES2 (PBKDF2 with HMAC-SHA256 and
+// AES-256-CBC) and authenticating with HMAC-SHA256.
+package pkcs12
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1" // nolint: the certificate localKeyId is a SHA-1 hash
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+)
+
+// Content and bag type OIDs
+var (
+	oidData          = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 1}
+	oidEncryptedData = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 6}
+
+	oidKeyBag = asn1.ObjectIdentifier{
+		1, 2, 840, 113549, 1, 12, 10, 1, 1,
+	}
+	oidPKCS8ShroudedKeyBag = asn1.ObjectIdentifier{
+		1, 2, 840, 113549, 1, 12, 10, 1, 2,
+	}
+	oidCertBag = asn1.ObjectIdentifier{
+		1, 2, 840, 113549, 1, 12, 10, 1, 3,
+	}
+	oidSafeContentsBag = asn1.ObjectIdentifier{
+		1, 2, 840, 113549, 1, 12, 10, 1, 6,
+	}
+
+	oidX509Certificate = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 22, 1}
+)
+
+// PFX (RFC 7292, section 4)
+type pfx struct {
+	Version  int
+	AuthSafe contentInfo
+	MacData  macData `asn1:"optional"`
+}
+
+type contentInfo struct {
+	ContentType asn1.ObjectIdentifier
+	Content     asn1.RawValue `asn1:"tag:0,explicit,optional"`
+}
+
+type macData struct {
+	Mac        digestInfo
+	MacSalt    []byte
+	Iterations int `asn1:"optional,default:1"`
+}
+
+type digestInfo struct {
+	Algorithm pkix.AlgorithmIdentifier
+	Digest    []byte
+}
+
+type encryptedData struct {
+	Version              int
+	EncryptedContentInfo encryptedContentInfo
+}
+
+type encryptedContentInfo struct {
+	ContentType                asn1.ObjectIdentifier
+	ContentEncryptionAlgorithm pkix.AlgorithmIdentifier
+	EncryptedContent           []byte `asn1:"tag:0,optional"`
+}
+
+type safeBag struct {
+	ID         asn1.ObjectIdentifier
+	Value      asn1.RawValue   `asn1:"tag:0,explicit"`
+	Attributes []akp.Attribute `asn1:"set,optional"`
+}
+
+type certBag struct {
+	ID   asn1.ObjectIdentifier
+	Data []byte `asn1:"tag:0,explicit"`
+}
+
+// EncryptedPrivateKeyInfo (RFC 5958, section 3)
+type encryptedPrivateKeyInfo struct {
+	Algorithm     pkix.AlgorithmIdentifier
+	EncryptedData []byte
+}
+
+// DefaultIterations is the iteration count of the key derivations that Encode
+// performs, the same as OpenSSL uses.
+const DefaultIterations = 2048
+
+// Decode extracts the private keys and certificates from a PKCS #12 file.
+//
+// The MAC, if any, is verified.
+func Decode(data []byte, password string) (
+	pkgs []*akp.OneAsymmetricKey, certs []*x509.Certificate, err error,
+) {
+	der, err := berToDER(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	var p pfx
+	if err = unmarshalExact(der, &p); err != nil {
+		return nil, nil, err
+	}
+	if p.Version != 3 {
+		return nil, nil, fmt.Errorf("pkcs12: unsupported version %d", p.Version)
+	}
+	if !p.AuthSafe.ContentType.Equal(oidData) {
+		return nil, nil, errors.New("pkcs12: public-key integrity mode " +
+			"is not supported")
+	}
+	var authSafe []byte
+	if err = unmarshalExact(p.AuthSafe.Content.Bytes, &authSafe); err != nil {
+		return nil, nil, err
+	}
+	if p.MacData.Mac.Algorithm.Algorithm != nil {
+		if err = verifyMAC(&p.MacData, authSafe, password); err != nil {
+			return nil, nil, err
+		}
+	}
+	var contents []contentInfo
+	if err = unmarshalExact(authSafe, &contents); err != nil {
+		return nil, nil, err
+	}
+	var bags []safeBag
+	for _, ci := range contents {
+		var safeContents []byte
+		switch {
+		case ci.ContentType.Equal(oidData):
+			err = unmarshalExact(ci.Content.Bytes, &safeContents)
+		case ci.ContentType.Equal(oidEncryptedData):
+			var ed encryptedData
+			if err = unmarshalExact(ci.Content.Bytes, &ed); err != nil {
+				break
+			}
+			eci := &ed.EncryptedContentInfo
+			safeContents, err = decrypt(eci.ContentEncryptionAlgorithm,
+				password, eci.EncryptedContent)
+		default:
+			err = fmt.Errorf("pkcs12: unsupported content type %v",
+				ci.ContentType)
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		if bags, err = appendBags(bags, safeContents, 0); err != nil {
+			return nil, nil, err
+		}
+	}
+	for _, bag := range bags {
+		switch {
+		case bag.ID.Equal(oidKeyBag), bag.ID.Equal(oidPKCS8ShroudedKeyBag):
+			pkg, err := decodeKeyBag(&bag, password)
+			if err != nil {
+				return nil, nil, err
+			}
+			pkgs = append(pkgs, pkg)
+		case bag.ID.Equal(oidCertBag):
+			var cb certBag
+			if err = unmarshalExact(bag.Value.Bytes, &cb); err != nil {
+				return nil, nil, err
+			}
+			if !cb.ID.Equal(oidX509Certificate) {
+				continue
+			}
+			cert, err := x509.ParseCertificate(cb.Data)
+			if err != nil {
+				return nil, nil, err
+			}
+			certs = append(certs, cert)
+		}
+	}
+	return pkgs, certs, nil
+}
+
+const maxBagDepth = 8
+
+func appendBags(bags []safeBag, safeContents []byte, depth int) (
+	[]safeBag, error,
+) {
+	if depth > maxBagDepth {
+		return nil, errors.New("pkcs12: safe contents nested too deeply")
+	}
+	var contents []safeBag
+	if err := unmarshalExact(safeContents, &contents); err != nil {
+		return nil, err
+	}
+	for _, bag := range contents {
+		if !bag.ID.Equal(oidSafeContentsBag) {
+			bags = append(bags, bag)
+			continue
+		}
+		var err error
+		if bags, err = appendBags(bags, bag.Value.Bytes, depth+1); err != nil {
+			return nil, err
+		}
+	}
+	return bags, nil
+}
+
+func decodeKeyBag(bag *safeBag, password string) (
+	*akp.OneAsymmetricKey, error,
+) {
+	der := bag.Value.Bytes
+	if bag.ID.Equal(oidPKCS8ShroudedKeyBag) {
+		var epki encryptedPrivateKeyInfo
+		if err := unmarshalExact(der, &epki); err != nil {
+			return nil, err
+		}
+		var err error
+		der, err = decrypt(epki.Algorithm, password, epki.EncryptedData)
+		if err != nil {
+			return nil, err
+		}
+	}
+	var pkg akp.OneAsymmetricKey
+	if err := unmarshalExact(der, &pkg); err != nil {
+		if bag.ID.Equal(oidPKCS8ShroudedKeyBag) {
+			return nil, ErrIncorrectPassword
+		}
+		return nil, err
+	}
+	for _, attr := range bag.Attributes {
+		pkg.SetAttribute(attr.Type, attr.Values...)
+	}
+	return &pkg, nil
+}
+
+func verifyMAC(md *macData, content []byte, password string) error {
+	mac, err := computeMAC(md.Mac.Algorithm.Algorithm, md.MacSalt,
+		md.Iterations, content, password)
+	if err != nil {
+		return err
+	}
+	if !hmac.Equal(mac, md.Mac.Digest) {
+		return ErrIncorrectPassword
+	}
+	return nil
+}
+
+func computeMAC(
+	alg asn1.ObjectIdentifier, salt []byte, iterations int, content []byte,
+	password string,
+) ([]byte, error) {
+	h := digestHash(alg)
+	if h == nil {
+		return nil, fmt.Errorf("pkcs12: unsupported MAC algorithm %v", alg)
+	}
+	if err := checkIterations(iterations); err != nil {
+		return nil, err
+	}
+	key := pkcs12KDF(h, salt, bmpPassword(password), iterations, kdfMAC,
+		h().Size())
+	m := hmac.New(h, key)
+	m.Write(content)
+	return m.Sum(nil), nil
+}
+
+// Encode builds a PKCS #12 file from a key package and its certificate chain,
+// leaf certificate first.
+//
+// The friendlyName and localKeyId attributes of the key package become bag
+// attributes.
+// If the key package has no localKeyId but a certificate chain is given,
+// the SHA-1 hash of the leaf certificate serves as localKeyId,
+// as OpenSSL does.
+// The leaf certificate bag gets the same localKeyId and friendlyName.
+func Encode(
+	pkg *akp.OneAsymmetricKey, chain []*x509.Certificate, password string,
+) ([]byte, error) {
+	inner := *pkg
+	inner.Attributes = nil
+	var bagAttrs []akp.Attribute
+	for _, attr := range pkg.Attributes {
+		if attr.Type.Equal(akp.OIDFriendlyName) ||
+			attr.Type.Equal(akp.OIDLocalKeyID) {
+			bagAttrs = append(bagAttrs, attr)
+		} else {
+			inner.Attributes = append(inner.Attributes, attr)
+		}
+	}
+	if _, ok := pkg.LocalKeyID(); !ok && len(chain) > 0 {
+		id := sha1.Sum(chain[0].Raw) // nolint
+		bagAttrs = append(bagAttrs, akp.Attribute{
+			Type: akp.OIDLocalKeyID,
+			Values: []asn1.RawValue{{
+				Class: asn1.ClassUniversal, Tag: asn1.TagOctetString,
+				Bytes: id[:],
+			}},
+		})
+	}
+
+	// Key bag
+	pkcs8, err := asn1.Marshal(inner)
+	if err != nil {
+		return nil, err
+	}
+	alg, encrypted, err := encrypt(password, DefaultIterations, pkcs8)
+	if err != nil {
+		return nil, err
+	}
+	epki, err := asn1.Marshal(encryptedPrivateKeyInfo{
+		Algorithm: alg, EncryptedData: encrypted,
+	})
+	if err != nil {
+		return nil, err
+	}
+	keyBags, err := asn1.Marshal([]safeBag{{
+		ID:         oidPKCS8ShroudedKeyBag,
+		Value:      explicit0(epki),
+		Attributes: bagAttrs,
+	}})
+	if err != nil {
+		return nil, err
+	}
+	keyContent, err := dataContentInfo(keyBags)
+	if err != nil {
+		return nil, err
+	}
+	contents := []contentInfo{keyContent}
+
+	// Certificate bags
+	if len(chain) > 0 {
+		var certBags []safeBag
+		for i, cert := range chain {
+			cb, err := asn1.Marshal(certBag{
+				ID: oidX509Certificate, Data: cert.Raw,
+			})
+			if err != nil {
+				return nil, err
+			}
+			bag := safeBag{ID: oidCertBag, Value: explicit0(cb)}
+			if i == 0 {
+				bag.Attributes = bagAttrs
+			}
+			certBags = append(certBags, bag)
+		}
+		safeContents, err := asn1.Marshal(certBags)
+		if err != nil {
+			return nil, err
+		}
+		alg, encrypted, err := encrypt(password, DefaultIterations,
+			safeContents)
+		if err != nil {
+			return nil, err
+		}
+		ed, err := asn1.Marshal(encryptedData{
+			EncryptedContentInfo: encryptedContentInfo{
+				ContentType:                oidData,
+				ContentEncryptionAlgorithm: alg,
+				EncryptedContent:           encrypted,
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+		contents = append([]contentInfo{{
+			ContentType: oidEncryptedData, Content: explicit0(ed),
+		}}, contents...)
+	}
+
+	authSafe, err := asn1.Marshal(contents)
+	if err != nil {
+		return nil, err
+	}
+	authSafeContent, err := dataContentInfo(authSafe)
+	if err != nil {
+		return nil, err
+	}
+	salt := make([]byte, 16)
+	if _, err = rand.Read(salt); err != nil {
+		return nil, err
+	}
+	mac, err := computeMAC(oidSHA256, salt, DefaultIterations, authSafe,
+		password)
+	if err != nil {
+		return nil, err
+	}
+	return asn1.Marshal(pfx{
+		Version:  3,
+		AuthSafe: authSafeContent,
+		MacData: macData{
+			Mac: digestInfo{
+				Algorithm: pkix.AlgorithmIdentifier{
+					Algorithm: oidSHA256, Parameters: asn1.NullRawValue,
+				},
+				Digest: mac,
+			},
+			MacSalt:    salt,
+			Iterations: DefaultIterations,
+		},
+	})
+}
+
+func dataContentInfo(data []byte) (contentInfo, error) {
+	octets, err := asn1.Marshal(data)
+	if err != nil {
+		return contentInfo{}, err
+	}
+	return contentInfo{ContentType: oidData, Content: explicit0(octets)}, nil
+}
+
+// explicit0 wraps DER-encoded data in an [0] EXPLICIT tag.
+func explicit0(der []byte) asn1.RawValue {
+	return asn1.RawValue{
+		Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: der,
+	}
+}
@@ -0,0 +1,151 @@
+package pkcs12
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+	_ "github.com/harmony-one/asym-key-pkgs/pkg/algo/rsa"
+)
+
+// The testdata files were generated by OpenSSL 3.0:
+//
+//	openssl req -x509 -newkey rsa:1024 -nodes -keyout key.pem -out cert.pem \
+//		-subj /CN=test -days 3650
+//	openssl pkcs12 -export -inkey key.pem -in cert.pem -name "test key" \
+//		-passout pass:secret -out modern.p12
+//	openssl pkcs12 -export -legacy -inkey key.pem -in cert.pem \
+//		-name "test key" -passout pass:secret -out legacy.p12
+
+func readPEM(t *testing.T, filename string) []byte {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		t.Fatalf("%s: no PEM block", filename)
+	}
+	return block.Bytes
+}
+
+func checkDecoded(
+	t *testing.T, pkgs []*akp.OneAsymmetricKey, certs []*x509.Certificate,
+) {
+	expectedKey, err := x509.ParsePKCS8PrivateKey(
+		readPEM(t, "testdata/key.pem"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedCert := readPEM(t, "testdata/cert.pem")
+	if len(pkgs) != 1 {
+		t.Fatalf("got %d key packages; expected 1", len(pkgs))
+	}
+	priv, _, _, err := akp.Unpack(pkgs[0])
+	if err != nil {
+		t.Fatalf("cannot unpack key package: %v", err)
+	}
+	if !reflect.DeepEqual(priv, expectedKey) {
+		t.Errorf("decoded key %+v is different from %+v", priv, expectedKey)
+	}
+	if name, _ := pkgs[0].FriendlyName(); name != "test key" {
+		t.Errorf("friendlyName is %q; expected %q", name, "test key")
+	}
+	id, _ := pkgs[0].LocalKeyID()
+	if sum := sha1.Sum(expectedCert); !bytes.Equal(id, sum[:]) {
+		t.Errorf("localKeyId is %x; expected %x", id, sum)
+	}
+	if len(certs) != 1 || !bytes.Equal(certs[0].Raw, expectedCert) {
+		t.Errorf("unexpected certificates %+v", certs)
+	}
+}
+
+func TestDecode(t *testing.T) {
+	for _, name := range []string{"modern", "legacy"} {
+		t.Run(name, func(t *testing.T) {
+			data, err := os.ReadFile("testdata/" + name + ".p12")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, _, err = Decode(data, "wrong"); err != ErrIncorrectPassword {
+				t.Errorf("Decode returned %+v; expected %+v",
+					err, ErrIncorrectPassword)
+			}
+			pkgs, certs, err := Decode(data, "secret")
+			if err != nil {
+				t.Fatalf("cannot decode: %v", err)
+			}
+			checkDecoded(t, pkgs, certs)
+		})
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	data, err := os.ReadFile("testdata/modern.p12")
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkgs, certs, err := Decode(data, "secret")
+	if err != nil {
+		t.Fatalf("cannot decode: %v", err)
+	}
+	encoded, err := Encode(pkgs[0], certs, "passw0rd")
+	if err != nil {
+		t.Fatalf("cannot encode: %v", err)
+	}
+	pkgs, certs, err = Decode(encoded, "passw0rd")
+	if err != nil {
+		t.Fatalf("cannot decode: %v", err)
+	}
+	checkDecoded(t, pkgs, certs)
+}
+
+func TestBERToDER(t *testing.T) {
+	// SEQUENCE (indefinite) { OCTET STRING (constructed) { "ab", "c" } }
+	ber := []byte{
+		0x30, 0x80,
+		0x24, 0x80, 0x04, 0x02, 'a', 'b', 0x04, 0x01, 'c', 0x00, 0x00,
+		0x00, 0x00,
+	}
+	der, err := berToDER(ber)
+	if err != nil {
+		t.Fatalf("cannot convert BER: %v", err)
+	}
+	expected := []byte{0x30, 0x05, 0x04, 0x03, 'a', 'b', 'c'}
+	if !bytes.Equal(der, expected) {
+		t.Errorf("DER is %x; expected %x", der, expected)
+	}
+}
+
+func TestRC2(t *testing.T) {
+	// RFC 2268, section 5
+	for _, v := range []struct {
+		key, plain, cipher []byte
+		bits               int
+	}{
+		{
+			[]byte{0, 0, 0, 0, 0, 0, 0, 0}, make([]byte, 8),
+			[]byte{0xeb, 0xb7, 0x73, 0xf9, 0x93, 0x27, 0x8e, 0xff}, 63,
+		},
+		{
+			[]byte{0x88}, make([]byte, 8),
+			[]byte{0x61, 0xa8, 0xa2, 0x44, 0xad, 0xac, 0xcc, 0xf0}, 64,
+		},
+	} {
+		c := newRC2Cipher(v.key, v.bits)
+		out := make([]byte, 8)
+		c.Encrypt(out, v.plain)
+		if !bytes.Equal(out, v.cipher) {
+			t.Errorf("RC2 encryption is %x; expected %x", out, v.cipher)
+		}
+		c.Decrypt(out, out)
+		if !bytes.Equal(out, v.plain) {
+			t.Errorf("RC2 decryption is %x; expected %x", out, v.plain)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package split
+
+// Arithmetic in GF(2^8) with the AES polynomial x^8 + x^4 + x^3 + x + 1,
+// without secret-dependent branches or table lookups.
+// Addition and subtraction are XOR.
+
+// gfMul returns a * b.
+func gfMul(a, b byte) byte {
+	var p byte
+	for i := 0; i < 8; i++ {
+		p ^= -(b & 1) & a
+		b >>= 1
+		a = a<<1 ^ -(a>>7)&0x1b
+	}
+	return p
+}
+
+// gfInv returns the inverse of a, or 0 if a is 0, as a^254.
+func gfInv(a byte) byte {
+	r := a
+	for i := 0; i < 6; i++ {
+		r = gfMul(gfMul(r, r), a)
+	}
+	return gfMul(r, r)
+}
+
+// evaluate returns the value of the polynomial with the given coefficients,
+// lowest degree first, at x.
+func evaluate(coeffs []byte, x byte) byte {
+	var y byte
+	for i := len(coeffs) - 1; i >= 0; i-- {
+		y = gfMul(y, x) ^ coeffs[i]
+	}
+	return y
+}
+
+// interpolate returns the value at x of the polynomial through the points
+// (xs[i], ys[i]), whose xs are distinct.
+func interpolate(xs, ys []byte, x byte) byte {
+	var y byte
+	for i := range xs {
+		// Lagrange basis polynomial i at x.
+		l := byte(1)
+		for j := range xs {
+			if j != i {
+				l = gfMul(l, gfMul(x^xs[j], gfInv(xs[i]^xs[j])))
+			}
+		}
+		y ^= gfMul(l, ys[i])
+	}
+	return y
+}
@@ -0,0 +1,245 @@
+// Package split splits key packages into shares with Shamir's secret
+// sharing over GF(2^8), so that any threshold number of shares recombine
+// into the key package, and fewer shares reveal nothing about it.
+//
+// A key package is an encoded OneAsymmetricKey or AsymmetricKeyPackage.
+// Each share is a DER-encoded Share, with its index, the threshold,
+// the fingerprint of the key and a checksum.
+//
+// The fingerprint is the RFC 7093 key identifier of the (first) key,
+// so the package of its algorithm must be imported,
+// as for akp.Unpack.
+package split
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+)
+
+// Share is one share of a key package.
+//
+//	Share ::= SEQUENCE {
+//	    version     INTEGER { v1(0) },
+//	    threshold   INTEGER (2..255),
+//	    index       INTEGER (1..255),
+//	    fingerprint OCTET STRING,
+//	    value       OCTET STRING,
+//	    checksum    OCTET STRING }
+type Share struct {
+	Version int
+
+	// Threshold is the number of shares that recombine into the key
+	// package.
+	Threshold int
+
+	// Index is the point at which the value was computed, from 1 up to the
+	// number of shares.
+	Index int
+
+	// Fingerprint is the RFC 7093 method 1 key identifier of the key,
+	// or of the first key of an AsymmetricKeyPackage.
+	Fingerprint []byte
+
+	// Value is the share of the encoded key package,
+	// as long as the encoded key package.
+	Value []byte
+
+	// Checksum is the SHA-256 hash of the DER encoding of the share with an
+	// empty checksum.
+	Checksum []byte
+}
+
+// V1 is the version of shares.
+const V1 = 0
+
+// MaxShares is the maximum number of shares of a key package.
+const MaxShares = 255
+
+var (
+	// ErrCorrupt means the checksum of a share does not match its contents.
+	ErrCorrupt = errors.New("share is corrupt")
+
+	// ErrInconsistent means shares do not belong to the same split of a key
+	// package, or some of them were altered.
+	ErrInconsistent = errors.New("shares are inconsistent")
+
+	// ErrTooFewShares means there are fewer shares than their threshold.
+	ErrTooFewShares = errors.New("too few shares")
+)
+
+// fingerprint returns the fingerprint of the encoded key package.
+func fingerprint(encoded []byte) ([]byte, error) {
+	var pkg akp.OneAsymmetricKey
+	rest, err := asn1.Unmarshal(encoded, &pkg)
+	if err != nil {
+		var pkgs akp.AsymmetricKeyPackage
+		if rest, err = asn1.Unmarshal(encoded, &pkgs); err != nil {
+			return nil, errors.New(
+				"not an encoded OneAsymmetricKey or AsymmetricKeyPackage")
+		}
+		if len(pkgs) == 0 {
+			return nil, errors.New("AsymmetricKeyPackage is empty")
+		}
+		pkg = pkgs[0]
+	}
+	if len(rest) > 0 {
+		return nil, errors.New("trailing data after key package")
+	}
+	fp, err := pkg.Fingerprint()
+	if err != nil {
+		return nil, fmt.Errorf("cannot fingerprint key: %w", err)
+	}
+	return fp.KeyIDSHA256, nil
+}
+
+// checksum returns the checksum of the share.
+func (share *Share) checksum() ([]byte, error) {
+	s := *share
+	s.Checksum = []byte{}
+	der, err := asn1.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(der)
+	return sum[:], nil
+}
+
+// Split splits the encoded key package into n shares,
+// any threshold of which recombine into it,
+// reading the random coefficients from rand.
+// It returns the DER-encoded shares.
+func Split(
+	rand io.Reader, encoded []byte, n, threshold int,
+) ([][]byte, error) {
+	if threshold < 2 || threshold > n || n > MaxShares {
+		return nil, fmt.Errorf("cannot split into %d shares with threshold %d",
+			n, threshold)
+	}
+	fp, err := fingerprint(encoded)
+	if err != nil {
+		return nil, err
+	}
+	shares := make([]Share, n)
+	for i := range shares {
+		shares[i] = Share{
+			Version: V1, Threshold: threshold, Index: i + 1, Fingerprint: fp,
+			Value: make([]byte, len(encoded)),
+		}
+	}
+	coeffs := make([]byte, threshold)
+	for j, b := range encoded {
+		coeffs[0] = b
+		if _, err = io.ReadFull(rand, coeffs[1:]); err != nil {
+			return nil, err
+		}
+		for i := range shares {
+			shares[i].Value[j] = evaluate(coeffs, byte(i+1))
+		}
+	}
+	clear(coeffs)
+	encodedShares := make([][]byte, n)
+	for i := range shares {
+		if shares[i].Checksum, err = shares[i].checksum(); err != nil {
+			return nil, err
+		}
+		if encodedShares[i], err = asn1.Marshal(shares[i]); err != nil {
+			return nil, err
+		}
+	}
+	return encodedShares, nil
+}
+
+// ParseShare parses a DER-encoded share and checks its checksum.
+func ParseShare(der []byte) (*Share, error) {
+	share := new(Share)
+	rest, err := asn1.Unmarshal(der, share)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse share: %w", err)
+	}
+	if len(rest) > 0 {
+		return nil, errors.New("trailing data after share")
+	}
+	if share.Version != V1 {
+		return nil, fmt.Errorf("unsupported share version %d", share.Version)
+	}
+	if share.Threshold < 2 || share.Threshold > MaxShares ||
+		share.Index < 1 || share.Index > MaxShares {
+		return nil, fmt.Errorf("share %d of threshold %d is out of range",
+			share.Index, share.Threshold)
+	}
+	sum, err := share.checksum()
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(sum, share.Checksum) {
+		return nil, ErrCorrupt
+	}
+	return share, nil
+}
+
+// Combine recombines DER-encoded shares into the encoded key package.
+//
+// Combine checks that the shares are intact and consistent:
+// they must have the same threshold, fingerprint and length,
+// and distinct indices.
+// Shares beyond the threshold must agree with the others,
+// and the recombined key package must have the fingerprint of the shares.
+func Combine(encodedShares [][]byte) ([]byte, error) {
+	if len(encodedShares) == 0 {
+		return nil, ErrTooFewShares
+	}
+	shares := make([]*Share, len(encodedShares))
+	for i, der := range encodedShares {
+		share, err := ParseShare(der)
+		if err != nil {
+			return nil, fmt.Errorf("share %d: %w", i+1, err)
+		}
+		shares[i] = share
+	}
+	first := shares[0]
+	seen := make(map[int]bool, len(shares))
+	for _, share := range shares {
+		if share.Threshold != first.Threshold ||
+			!bytes.Equal(share.Fingerprint, first.Fingerprint) ||
+			len(share.Value) != len(first.Value) || seen[share.Index] {
+			return nil, ErrInconsistent
+		}
+		seen[share.Index] = true
+	}
+	k := first.Threshold
+	if len(shares) < k {
+		return nil, ErrTooFewShares
+	}
+	xs := make([]byte, k)
+	ys := make([]byte, k)
+	for i := range xs {
+		xs[i] = byte(shares[i].Index)
+	}
+	encoded := make([]byte, len(first.Value))
+	for j := range encoded {
+		for i := range ys {
+			ys[i] = shares[i].Value[j]
+		}
+		encoded[j] = interpolate(xs, ys, 0)
+		for _, extra := range shares[k:] {
+			if interpolate(xs, ys, byte(extra.Index)) != extra.Value[j] {
+				return nil, ErrInconsistent
+			}
+		}
+	}
+	clear(ys)
+	fp, err := fingerprint(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInconsistent, err)
+	}
+	if !bytes.Equal(fp, first.Fingerprint) {
+		return nil, ErrInconsistent
+	}
+	return encoded, nil
+}
@@ -0,0 +1,210 @@
+package split
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/asn1"
+	"errors"
+	"testing"
+
+	"github.com/harmony-one/asym-key-pkgs/pkg/akp"
+	_ "github.com/harmony-one/asym-key-pkgs/pkg/algo/ed25519"
+	"github.com/harmony-one/asym-key-pkgs/pkg/algo/secp256k1"
+)
+
+func generateKey(t *testing.T) []byte {
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	encoded, err := akp.Encode(priv, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return encoded
+}
+
+func TestGF256(t *testing.T) {
+	// FIPS 197, section 4.2: {57} • {83} = {c1}.
+	if p := gfMul(0x57, 0x83); p != 0xc1 {
+		t.Errorf("{57} • {83} = {%02x}; expected {c1}", p)
+	}
+	for a := 1; a < 256; a++ {
+		if p := gfMul(byte(a), gfInv(byte(a))); p != 1 {
+			t.Fatalf("{%02x} • {%02x} = {%02x}", a, gfInv(byte(a)), p)
+		}
+	}
+}
+
+func TestCombine(t *testing.T) {
+	encoded := generateKey(t)
+	shares, err := Split(rand.Reader, encoded, 5, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, subset := range [][]int{
+		{0, 1, 2}, {4, 2, 0}, {1, 3, 4}, {0, 1, 2, 3}, {4, 3, 2, 1, 0},
+	} {
+		var s [][]byte
+		for _, i := range subset {
+			s = append(s, shares[i])
+		}
+		combined, err := Combine(s)
+		if err != nil {
+			t.Errorf("cannot combine shares %v: %v", subset, err)
+			continue
+		}
+		if !bytes.Equal(combined, encoded) {
+			t.Errorf("shares %v combine into %x; expected %x",
+				subset, combined, encoded)
+		}
+	}
+	if _, err = Combine(shares[:2]); err != ErrTooFewShares {
+		t.Errorf("Combine returned %v; expected %v", err, ErrTooFewShares)
+	}
+	share, err := ParseShare(shares[3])
+	if err != nil {
+		t.Fatal(err)
+	}
+	fp, err := fingerprint(encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if share.Index != 4 || share.Threshold != 3 ||
+		!bytes.Equal(share.Fingerprint, fp) {
+		t.Errorf("share is %+v", share)
+	}
+}
+
+func TestKeyPackages(t *testing.T) {
+	ecKey, err := secp256k1kp.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var pkgs akp.AsymmetricKeyPackage
+	for _, priv := range []interface{}{ecKey, nil} {
+		if priv == nil {
+			_, priv, _ = ed25519.GenerateKey(rand.Reader)
+		}
+		pkg, err := akp.Pack(priv, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pkgs = append(pkgs, *pkg)
+	}
+	encoded, err := asn1.Marshal(pkgs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	shares, err := Split(rand.Reader, encoded, 2, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	combined, err := Combine(shares)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(combined, encoded) {
+		t.Errorf("shares combine into %x; expected %x", combined, encoded)
+	}
+	share, err := ParseShare(shares[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	fp, err := pkgs[0].Fingerprint()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(share.Fingerprint, fp.KeyIDSHA256) {
+		t.Errorf("fingerprint is %x; expected %x of the first key",
+			share.Fingerprint, fp.KeyIDSHA256)
+	}
+}
+
+// reencode re-encodes a share after modifying it, with a valid checksum.
+func reencode(t *testing.T, der []byte, modify func(*Share)) []byte {
+	share, err := ParseShare(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	modify(share)
+	if share.Checksum, err = share.checksum(); err != nil {
+		t.Fatal(err)
+	}
+	if der, err = asn1.Marshal(*share); err != nil {
+		t.Fatal(err)
+	}
+	return der
+}
+
+func TestBadShares(t *testing.T) {
+	encoded := generateKey(t)
+	shares, err := Split(rand.Reader, encoded, 4, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := Split(rand.Reader, generateKey(t), 4, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	alter := func(share *Share) { share.Value[len(share.Value)/2] ^= 1 }
+	for _, test := range []struct {
+		name     string
+		shares   [][]byte
+		expected error
+	}{
+		{"TrailingData", [][]byte{
+			shares[0], shares[1],
+			append(shares[2][:len(shares[2]):len(shares[2])], 0),
+		}, nil},
+		{"FlippedBit", [][]byte{
+			shares[0], shares[1], func() []byte {
+				der := bytes.Clone(shares[2])
+				der[len(der)/2] ^= 1
+				return der
+			}(),
+		}, ErrCorrupt},
+		{"Duplicate", [][]byte{shares[0], shares[1], shares[1]},
+			ErrInconsistent},
+		{"OtherKey", [][]byte{shares[0], shares[1], other[2]},
+			ErrInconsistent},
+		{"OtherThreshold", [][]byte{
+			shares[0], shares[1], reencode(t, shares[2], func(s *Share) {
+				s.Threshold = 2
+			}),
+		}, ErrInconsistent},
+		{"AlteredExtra", [][]byte{
+			shares[0], shares[1], shares[2], reencode(t, shares[3], alter),
+		}, ErrInconsistent},
+		{"Altered", [][]byte{
+			shares[0], shares[1], reencode(t, shares[2], alter),
+		}, ErrInconsistent},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := Combine(test.shares)
+			switch {
+			case err == nil:
+				t.Error("Combine succeeded")
+			case test.expected != nil && !errors.Is(err, test.expected):
+				t.Errorf("Combine returned %v; expected %v", err, test.expected)
+			}
+		})
+	}
+}
+
+func TestSplitArguments(t *testing.T) {
+	encoded := generateKey(t)
+	for _, test := range []struct{ n, threshold int }{
+		{3, 1}, {2, 3}, {256, 2},
+	} {
+		_, err := Split(rand.Reader, encoded, test.n, test.threshold)
+		if err == nil {
+			t.Errorf("Split accepted %d shares with threshold %d",
+				test.n, test.threshold)
+		}
+	}
+	if _, err := Split(rand.Reader, []byte("secret"), 3, 2); err == nil {
+		t.Error("Split accepted data that is not a key package")
+	}
+}